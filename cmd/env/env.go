@@ -0,0 +1,28 @@
+package env
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewEnvCmd creates a new cobra command for the env sub command
+func NewEnvCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	envCmd := &cobra.Command{
+		Use:   "env",
+		Short: "Works with the environment of containers",
+		Long: `
+#######################################################
+#################### devspace env #####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	envCmd.AddCommand(newExportCmd(f, globalFlags))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(envCmd, plugins, "env")
+	return envCmd
+}
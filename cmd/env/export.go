@@ -0,0 +1,228 @@
+package env
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	runtimevar "github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/services/containerenv"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type exportCmd struct {
+	*flags.GlobalFlags
+
+	Dev           string
+	LabelSelector string
+	ImageSelector string
+	Container     string
+	Pod           string
+	Pick          bool
+	Wait          bool
+
+	Output      string
+	Format      string
+	MirrorFiles bool
+	Dir         string
+}
+
+func newExportCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &exportCmd{GlobalFlags: globalFlags}
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the environment of a container",
+		Long: `
+#######################################################
+################ devspace env export ##################
+#######################################################
+Resolves the environment of a container including env,
+envFrom, config map & secret references and downward
+API values and writes it to a dotenv file or an exec
+wrapper script that can be used to run local processes
+with the environment of the container.
+
+Examples:
+devspace env export --dev my-dev -o .env
+devspace env export -l app=api --format wrapper -o run.sh
+devspace env export --image-selector nginx --mirror-files
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f)
+		}}
+
+	exportCmd.Flags().StringVar(&cmd.Dev, "dev", "", "The dev configuration to use to select the container")
+	exportCmd.Flags().StringVarP(&cmd.Container, "container", "c", "", "Container name within pod to export the environment of")
+	exportCmd.Flags().StringVar(&cmd.Pod, "pod", "", "Pod to export the environment of")
+	exportCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
+	exportCmd.Flags().StringVar(&cmd.ImageSelector, "image-selector", "", "The image to search a pod for (e.g. nginx, nginx:latest, ${runtime.images.app}, nginx:${runtime.images.app.tag})")
+	exportCmd.Flags().BoolVar(&cmd.Pick, "pick", true, "Select a pod / container if multiple are found")
+	exportCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Wait for the pod(s) to start if they are not running")
+
+	exportCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The file to write to. If empty, will print to stdout")
+	exportCmd.Flags().StringVar(&cmd.Format, "format", "dotenv", "The output format. Can be either dotenv or wrapper")
+	exportCmd.Flags().BoolVar(&cmd.MirrorFiles, "mirror-files", false, "If enabled, will mirror mounted secrets and config maps to a local directory")
+	exportCmd.Flags().StringVar(&cmd.Dir, "dir", "", "The directory to mirror files to. If empty, a temporary directory is used")
+	return exportCmd
+}
+
+// Run executes the command logic
+func (cmd *exportCmd) Run(f factory.Factory) error {
+	if cmd.Format != "dotenv" && cmd.Format != "wrapper" {
+		return errors.Errorf("unsupported value for flag --format: %s", cmd.Format)
+	}
+
+	// Set config root
+	logger := f.GetLog().ErrorStreamOnly()
+	configOptions := cmd.ToConfigOptions()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	}
+
+	// Get kubectl client
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "new kube client")
+	}
+
+	// create the context
+	ctx := devspacecontext.NewContext(context.Background(), nil, logger).WithKubeClient(client)
+	if configExists {
+		localCache, err := configLoader.LoadLocalCache()
+		if err != nil {
+			return err
+		}
+
+		client, err = kubectl.CheckKubeContext(client, localCache, cmd.NoWarn, cmd.SwitchContext, false, logger)
+		if err != nil {
+			return err
+		}
+		ctx = ctx.WithKubeClient(client)
+	}
+
+	// load the config if we need it to select the container
+	labelSelector, imageSelector, container, namespace := cmd.LabelSelector, cmd.ImageSelector, cmd.Container, cmd.Namespace
+	if cmd.Dev != "" || imageSelector != "" {
+		if !configExists {
+			return errors.New(message.ConfigNotFound)
+		}
+
+		config, err := configLoader.Load(ctx.Context(), client, configOptions, logger)
+		if err != nil {
+			return err
+		}
+		ctx = ctx.WithConfig(config)
+
+		if cmd.Dev != "" {
+			devPod, ok := config.Config().Dev[cmd.Dev]
+			if !ok {
+				return errors.Errorf("couldn't find dev configuration %s", cmd.Dev)
+			}
+
+			labelSelector = ""
+			for k, v := range devPod.LabelSelector {
+				if labelSelector != "" {
+					labelSelector += ","
+				}
+				labelSelector += k + "=" + v
+			}
+			imageSelector = devPod.ImageSelector
+			if devPod.Namespace != "" {
+				namespace = devPod.Namespace
+			}
+			if container == "" {
+				container = devPod.Container
+			}
+		}
+
+		if imageSelector != "" {
+			dependencies, err := dependency.NewManager(ctx, configOptions).ResolveAll(ctx, dependency.ResolveOptions{})
+			if err != nil {
+				logger.Warnf("Error resolving dependencies: %v", err)
+			}
+
+			resolved, err := runtimevar.NewRuntimeResolver(ctx.WorkingDir(), true).FillRuntimeVariablesAsImageSelector(ctx.Context(), imageSelector, config, dependencies)
+			if err != nil {
+				return err
+			}
+			imageSelector = resolved.Image
+		}
+	}
+
+	// select the container
+	var imageSelectors []string
+	if imageSelector != "" {
+		imageSelectors = []string{imageSelector}
+	}
+	selectorOptions := targetselector.NewOptionsFromFlags(container, labelSelector, imageSelectors, namespace, cmd.Pod).
+		WithPick(cmd.Pick).
+		WithWait(cmd.Wait).
+		WithQuestion("Which container do you want to export the environment of?")
+	if cmd.Wait {
+		selectorOptions = selectorOptions.WithWaitingStrategy(targetselector.NewUntilNewestRunningWaitingStrategy(time.Second))
+	}
+	selectedContainer, err := targetselector.NewTargetSelector(selectorOptions).SelectSingleContainer(ctx.Context(), client, logger)
+	if err != nil {
+		return err
+	}
+
+	// resolve the environment
+	environment, err := containerenv.Resolve(ctx.Context(), client, selectedContainer.Pod, selectedContainer.Container.Name, &containerenv.Options{
+		MirrorFiles: cmd.MirrorFiles,
+		Dir:         cmd.Dir,
+	})
+	if err != nil {
+		return err
+	}
+	for mountPath, localPath := range environment.Mounts {
+		logger.Infof("Mirrored %s to %s", mountPath, localPath)
+	}
+
+	// write the environment
+	var out io.Writer = os.Stdout
+	if cmd.Output != "" {
+		mode := os.FileMode(0600)
+		if cmd.Format == "wrapper" {
+			mode = 0700
+		}
+
+		file, err := os.OpenFile(cmd.Output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	if cmd.Format == "wrapper" {
+		err = containerenv.WriteWrapper(out, environment)
+	} else {
+		err = containerenv.WriteDotEnv(out, environment)
+	}
+	if err != nil {
+		return err
+	}
+
+	if cmd.Output != "" {
+		logger.Donef("Successfully exported environment of %s/%s to %s", selectedContainer.Pod.Name, selectedContainer.Container.Name, cmd.Output)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ type GlobalFlags struct {
 	Debug                    bool
 	DisableProfileActivation bool
 	SwitchContext            bool
+	NonInteractive           bool
 	InactivityTimeout        int
 	KubeConfig               string
 	OverrideName             string
@@ -49,6 +50,7 @@ func SetGlobalFlags(flags *flag.FlagSet) *GlobalFlags {
 	flags.BoolVar(&globalFlags.NoColors, "no-colors", false, "Do not show color highlighting in log output. This avoids invisible output with different terminal background colors")
 	flags.BoolVar(&globalFlags.Debug, "debug", false, "Prints the stack trace if an error occurs")
 	flags.BoolVar(&globalFlags.Silent, "silent", false, "Run in silent mode and prevents any devspace log output except panics & fatals")
+	flags.BoolVar(&globalFlags.NonInteractive, "non-interactive", false, "Fails instead of asking questions and lists the flags or environment variables that provide the missing inputs")
 
 	flags.StringSliceVarP(&globalFlags.Profiles, "profile", "p", []string{}, "The DevSpace profiles to apply. Multiple profiles are applied in the order they are specified")
 	flags.BoolVar(&globalFlags.DisableProfileActivation, "disable-profile-activation", false, "If true will ignore all profile activations")
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
+	"github.com/loft-sh/devspace/pkg/devspace/lint"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// LintCmd holds the lint cmd flags
+type LintCmd struct {
+	*flags.GlobalFlags
+
+	Out    io.Writer
+	Output string
+	FailOn string
+}
+
+// NewLintCmd creates a new lint command
+func NewLintCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &LintCmd{
+		GlobalFlags: globalFlags,
+		Out:         os.Stdout,
+	}
+
+	lintCmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Checks the devspace.yaml for errors and suspicious configuration",
+		Long: `
+#######################################################
+################### devspace lint #####################
+#######################################################
+Checks the devspace.yaml and the files it imports for
+errors and suspicious but valid configuration such as
+overlapping sync paths, ports that are forwarded twice
+or unused variables.
+
+Findings can be suppressed with a comment on the same
+or the previous line:
+  # devspace-lint-disable unused-var,sync-overlap
+or for the whole file:
+  # devspace-lint-disable-file deprecated-field
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		},
+	}
+
+	lintCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the findings. Can be either empty, json or sarif")
+	lintCmd.Flags().StringVar(&cmd.FailOn, "fail-on", string(lint.SeverityError), "The minimum severity that lets the command fail. Can be either error, warning or none")
+	return lintCmd
+}
+
+// Run executes the command logic
+func (cmd *LintCmd) Run(f factory.Factory) error {
+	if cmd.Output != "" && cmd.Output != "json" && cmd.Output != "sarif" {
+		return fmt.Errorf("unsupported output format %s, please use either empty, json or sarif", cmd.Output)
+	} else if cmd.FailOn != string(lint.SeverityError) && cmd.FailOn != string(lint.SeverityWarning) && cmd.FailOn != "none" {
+		return fmt.Errorf("unsupported --fail-on %s, please use either error, warning or none", cmd.FailOn)
+	}
+
+	log := f.GetLog()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	content, err := os.ReadFile(configLoader.ConfigPath())
+	if err != nil {
+		return err
+	}
+
+	target := &lint.Target{
+		File:    configLoader.ConfigPath(),
+		Content: content,
+		Raw:     map[string]interface{}{},
+	}
+	err = yaml.Unmarshal(content, &target.Raw)
+	if err != nil {
+		target.LoadErr = err
+	} else {
+		// the trace records the imported files, so that they are linted as well
+		trace := explain.NewTrace()
+		configOptions := cmd.ToConfigOptions()
+		configOptions.Dry = true
+		config, err := configLoader.LoadWithParser(explain.WithTrace(context.Background(), trace), nil, nil, loader.NewLintParser(), configOptions, log)
+		if err != nil {
+			target.LoadErr = err
+		} else {
+			target.Config = config.Config()
+		}
+
+		target.Imports, err = importTargets(trace, configLoader.ConfigPath())
+		if err != nil {
+			return err
+		}
+	}
+
+	findings, err := lint.Lint(target, lint.Rules)
+	if err != nil {
+		return err
+	}
+
+	cwd, _ := os.Getwd()
+	configDir := filepath.Dir(configLoader.ConfigPath())
+	switch cmd.Output {
+	case "json":
+		err = lint.WriteJSON(cmd.Out, findings)
+	case "sarif":
+		err = lint.WriteSARIF(cmd.Out, findings, lint.Rules, upgrade.GetVersion(), configDir)
+	default:
+		err = lint.WriteText(cmd.Out, findings, cwd)
+		if err == nil {
+			if len(findings) == 0 {
+				log.Done("No problems found")
+			} else {
+				log.Infof("Found %d error(s) and %d warning(s)", lint.Count(findings, lint.SeverityError), lint.Count(findings, lint.SeverityWarning)-lint.Count(findings, lint.SeverityError))
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if cmd.FailOn != "none" {
+		failed := lint.Count(findings, lint.Severity(cmd.FailOn))
+		if failed > 0 {
+			return fmt.Errorf("lint failed with %d finding(s) of severity %s or higher", failed, cmd.FailOn)
+		}
+	}
+
+	return nil
+}
+
+// importTargets returns the lint targets for all files besides the main config the config was loaded from
+func importTargets(trace *explain.Trace, configPath string) ([]*lint.Target, error) {
+	absConfigPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []*lint.Target{}
+	for _, file := range trace.Files() {
+		if file == configPath || file == absConfigPath {
+			continue
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		target := &lint.Target{
+			File:    file,
+			Content: content,
+			Raw:     map[string]interface{}{},
+		}
+		err = yaml.Unmarshal(content, &target.Raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse %s", file)
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
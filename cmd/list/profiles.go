@@ -2,8 +2,12 @@ package list
 
 import (
 	"context"
-	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"strings"
 
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -12,10 +16,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type profilesCmd struct{}
+type profilesCmd struct {
+	*flags.GlobalFlags
+}
 
-func newProfilesCmd(f factory.Factory) *cobra.Command {
-	cmd := &profilesCmd{}
+func newProfilesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &profilesCmd{GlobalFlags: globalFlags}
 
 	profilesCmd := &cobra.Command{
 		Use:   "profiles",
@@ -24,7 +30,8 @@ func newProfilesCmd(f factory.Factory) *cobra.Command {
 #######################################################
 ############## devspace list profiles #################
 #######################################################
-Lists all DevSpace configurations for this project
+Lists all DevSpace profiles for this project and shows
+which profiles are active and why
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -39,7 +46,7 @@ Lists all DevSpace configurations for this project
 func (cmd *profilesCmd) RunListProfiles(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
 	logger := f.GetLog()
 	// Set config root
-	configLoader, err := f.NewConfigLoader("")
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
 	if err != nil {
 		return err
 	}
@@ -51,23 +58,58 @@ func (cmd *profilesCmd) RunListProfiles(f factory.Factory, cobraCmd *cobra.Comma
 		return errors.New(message.ConfigNotFound)
 	}
 
-	config, err := configLoader.LoadWithParser(context.Background(), nil, nil, loader.NewProfilesParser(), nil, logger)
+	// the kube client is only needed for activations that match the kube context or namespace
+	var client kubectl.Client
+	if cmd.KubeContext != "" || cmd.Namespace != "" {
+		client, err = f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	// record the activation results while the profiles are applied
+	recorder := &versions.ActivationRecorder{}
+	ctx := versions.WithActivationRecorder(context.Background(), recorder)
+	config, err := configLoader.LoadWithParser(ctx, nil, client, loader.NewProfilesParser(), cmd.ToConfigOptions(), logger)
 	if err != nil {
 		return err
 	}
 
+	activations := map[string]*versions.ActivationResult{}
+	for _, result := range recorder.Results {
+		activations[result.Profile] = result
+	}
+	selected := map[string]bool{}
+	for _, profile := range cmd.Profiles {
+		selected[profile] = true
+	}
+
 	profiles := config.Config().Profiles
 
 	// Specify the table column names
 	headerColumnNames := []string{
 		"Name",
+		"Active",
+		"Reason",
 		"Description",
 	}
 
 	configRows := make([][]string, 0, len(profiles))
 	for _, profile := range profiles {
+		active, reason := "false", ""
+		if selected[profile.Name] {
+			active, reason = "true", "--profile flag"
+		} else if result, ok := activations[profile.Name]; ok {
+			if result.Active {
+				active = "true"
+			}
+			reason = strings.Join(result.Reasons, "; ")
+		}
+
 		configRows = append(configRows, []string{
 			profile.Name,
+			active,
+			reason,
 			profile.Description,
 		})
 	}
@@ -7,6 +7,11 @@ import (
 	"sort"
 
 	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/teamcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -17,7 +22,8 @@ import (
 type varsCmd struct {
 	*flags.GlobalFlags
 
-	Output string
+	Output     string
+	ShowSource bool
 }
 
 func newVarsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
@@ -31,7 +37,9 @@ func newVarsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comman
 ############### devspace list vars ####################
 #######################################################
 Lists the defined vars in the devspace config with their
-values
+values. Values of secret vars are masked. Use
+--show-source to see where each value was loaded from
+(flag, env, cache, team, default, ...)
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -40,6 +48,7 @@ values
 		}}
 
 	varsCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the command. Can be either empty, keyvalue or json")
+	varsCmd.Flags().BoolVar(&cmd.ShowSource, "show-source", false, "Show where the value of each variable was loaded from")
 	return varsCmd
 }
 
@@ -59,11 +68,31 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 		return errors.New(message.ConfigNotFound)
 	}
 
+	// the kube client is only needed to load the shared team variables
+	rawConfig, err := configLoader.LoadRaw()
+	if err != nil {
+		return err
+	}
+	teamVars, err := teamcache.ParseTeamVars(rawConfig)
+	if err != nil {
+		return err
+	}
+	var client kubectl.Client
+	if teamVars != nil {
+		client, err = f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+		if err != nil {
+			logger.Warnf("Unable to load team vars from namespace %s: %v", teamVars.Namespace, err)
+			client = nil
+		}
+	}
+
 	// Fill variables config
-	config, err := configLoader.Load(context.Background(), nil, cmd.ToConfigOptions(), logger)
+	parser := &sourceParser{Parser: loader.NewDefaultParser()}
+	config, err := configLoader.LoadWithParser(context.Background(), nil, client, parser, cmd.ToConfigOptions(), logger)
 	if err != nil {
 		return err
 	}
+	sources := parser.resolver.Sources()
 
 	switch cmd.Output {
 	case "":
@@ -72,13 +101,21 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 			"Variable",
 			"Value",
 		}
+		if cmd.ShowSource {
+			headerColumnNames = append(headerColumnNames, "Source")
+		}
 
 		varRow := make([][]string, 0, len(config.Variables()))
 		for name, value := range config.Variables() {
-			varRow = append(varRow, []string{
+			row := []string{
 				name,
-				fmt.Sprintf("%v", value),
-			})
+				fmt.Sprintf("%v", maskValue(value, sources[name])),
+			}
+			if cmd.ShowSource {
+				row = append(row, string(sources[name]))
+			}
+
+			varRow = append(varRow, row)
 		}
 		sort.Slice(varRow, func(i, j int) bool {
 			return varRow[i][0] < varRow[j][0]
@@ -97,10 +134,28 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 		log.PrintTable(logger, headerColumnNames, varRow)
 	case "keyvalue":
 		for name, value := range config.Variables() {
-			fmt.Printf("%s=%v\n", name, value)
+			fmt.Printf("%s=%v\n", name, maskValue(value, sources[name]))
 		}
 	case "json":
-		out, err := json.MarshalIndent(config.Variables(), "", "  ")
+		maskedVars := map[string]interface{}{}
+		for name, value := range config.Variables() {
+			maskedVars[name] = maskValue(value, sources[name])
+		}
+
+		var vars interface{} = maskedVars
+		if cmd.ShowSource {
+			varsWithSource := map[string]variableWithSource{}
+			for name, value := range maskedVars {
+				varsWithSource[name] = variableWithSource{
+					Value:  value,
+					Source: sources[name],
+				}
+			}
+
+			vars = varsWithSource
+		}
+
+		out, err := json.MarshalIndent(vars, "", "  ")
 		if err != nil {
 			return err
 		}
@@ -112,3 +167,32 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 
 	return nil
 }
+
+// maskValue hides the values of secret variables and the secrets within other values, the
+// same way they are redacted from the log output
+func maskValue(value interface{}, source variable.Source) interface{} {
+	if source == variable.SourceSecret {
+		return log.Redacted
+	} else if str, ok := value.(string); ok {
+		return log.Redact(str)
+	}
+
+	return value
+}
+
+type variableWithSource struct {
+	Value  interface{}     `json:"value"`
+	Source variable.Source `json:"source"`
+}
+
+// sourceParser parses the config with the given parser and remembers the variable resolver
+type sourceParser struct {
+	loader.Parser
+
+	resolver variable.Resolver
+}
+
+func (s *sourceParser) Parse(ctx context.Context, originalRawConfig map[string]interface{}, rawConfig map[string]interface{}, resolver variable.Resolver, log log.Logger) (*latest.Config, map[string]interface{}, error) {
+	s.resolver = resolver
+	return s.Parser.Parse(ctx, originalRawConfig, rawConfig, resolver, log)
+}
@@ -33,6 +33,7 @@ type LogsCmd struct {
 	Container     string
 	Pod           string
 	Pick          bool
+	All           bool
 
 	Follow            bool
 	Wait              bool
@@ -56,6 +57,7 @@ to it
 Example:
 devspace logs
 devspace logs --namespace=mynamespace
+devspace logs --label-selector=app=api --all -f
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -70,6 +72,7 @@ devspace logs --namespace=mynamespace
 	logsCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 	logsCmd.Flags().StringVar(&cmd.ImageSelector, "image-selector", "", "The image to search a pod for (e.g. nginx, nginx:latest, ${runtime.images.app}, nginx:${runtime.images.app.tag})")
 	logsCmd.Flags().BoolVar(&cmd.Pick, "pick", true, "Select a pod")
+	logsCmd.Flags().BoolVar(&cmd.All, "all", false, "Print the logs of all matching pods (e.g. all replicas of a dev pod) prefixed with the pod name")
 	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Attach to logs afterwards")
 	logsCmd.Flags().IntVar(&cmd.LastAmountOfLines, "lines", 200, "Max amount of lines to print from the last log")
 	logsCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Wait for the pod(s) to start if they are not running")
@@ -127,6 +130,24 @@ func (cmd *LogsCmd) RunLogs(f factory.Factory) error {
 		return err
 	}
 
+	// print logs of all matching containers
+	if cmd.All {
+		containers, err := selector.NewFilterWithSort(client, selector.SortContainersByNewest).SelectContainers(ctx.Context(), selector.Selector{
+			ImageSelector:      imageSelector,
+			LabelSelector:      cmd.LabelSelector,
+			Pod:                cmd.Pod,
+			ContainerName:      cmd.Container,
+			Namespace:          cmd.Namespace,
+			SkipInitContainers: true,
+			FilterContainer:    selector.FilterTerminatingContainers,
+		})
+		if err != nil {
+			return err
+		}
+
+		return logs.StartMultipleLogsWithWriter(ctx, containers, cmd.Follow, int64(cmd.LastAmountOfLines), os.Stdout)
+	}
+
 	// Build options
 	options := targetselector.NewOptionsFromFlags(cmd.Container, cmd.LabelSelector, imageSelector, cmd.Namespace, cmd.Pod).
 		WithPick(cmd.Pick).
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/lsp"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// LspCmd holds the lsp cmd flags
+type LspCmd struct {
+	*flags.GlobalFlags
+
+	Stdio bool
+
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// NewLspCmd creates a new lsp command
+func NewLspCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &LspCmd{
+		GlobalFlags: globalFlags,
+		In:          os.Stdin,
+		Out:         os.Stdout,
+		ErrOut:      os.Stderr,
+	}
+
+	lspCmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Starts a language server for devspace.yaml files",
+		Long: `
+#######################################################
+#################### devspace lsp #####################
+#######################################################
+Starts a language server that implements the language
+server protocol over stdio. Configure your editor to
+run 'devspace lsp' for devspace.yaml files to get:
+- completion of config keys, ${VAR} and runtime
+  variable references and image, deployment and dev
+  names in pipeline scripts
+- hover docs for config keys and variables
+- diagnostics from devspace lint
+- go to definition of variables, images, deployments,
+  dev configs and functions across imports
+
+Diagnostics from the config loader are disabled by
+default, because loading executes command variables
+and $(...) expressions and downloads imports. Enable
+them with the initialization option
+{"loadConfig": true}.
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+
+	// most editors pass --stdio to language servers, stdio is the only supported transport
+	lspCmd.Flags().BoolVar(&cmd.Stdio, "stdio", true, "Communicate with the client via stdin and stdout")
+	return lspCmd
+}
+
+// Run executes the command logic
+func (cmd *LspCmd) Run(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// stdout is reserved for the protocol, so everything else is logged to stderr
+	level := logrus.InfoLevel
+	if cmd.Debug {
+		level = logrus.DebugLevel
+	}
+
+	return lsp.NewServer(cmd.In, cmd.Out, upgrade.GetVersion(), log.NewStreamLogger(cmd.ErrOut, cmd.ErrOut, level)).Run(ctx)
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/migrate"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/loft-sh/devspace/pkg/util/survey"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// MigrateConfigCmd holds the migrate-config cmd flags
+type MigrateConfigCmd struct {
+	*flags.GlobalFlags
+
+	Imports      bool
+	Dependencies bool
+	DryRun       bool
+	Yes          bool
+
+	Out io.Writer
+}
+
+// NewMigrateConfigCmd creates a new migrate-config command
+func NewMigrateConfigCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &MigrateConfigCmd{
+		GlobalFlags: globalFlags,
+		Out:         os.Stdout,
+	}
+
+	migrateConfigCmd := &cobra.Command{
+		Use:   "migrate-config",
+		Short: "Migrates the devspace.yaml to the latest config version",
+		Long: `
+#######################################################
+############## devspace migrate-config ################
+#######################################################
+Rewrites the devspace.yaml to the latest config version
+and keeps comments and the order of keys. A diff of the
+changes is shown before anything is written and fields
+whose semantics changed are reported.
+
+Use --imports and --dependencies to also migrate the
+configs of local imports and dependencies.
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f)
+		},
+	}
+
+	migrateConfigCmd.Flags().BoolVar(&cmd.Imports, "imports", false, "Also migrate the configs of local imports")
+	migrateConfigCmd.Flags().BoolVar(&cmd.Dependencies, "dependencies", false, "Also migrate the configs of local dependencies")
+	migrateConfigCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Only show the diff and do not write the migrated configs")
+	migrateConfigCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Write the migrated configs without asking for confirmation")
+	return migrateConfigCmd
+}
+
+// Run executes the command logic
+func (cmd *MigrateConfigCmd) Run(f factory.Factory) error {
+	logger := f.GetLog()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	results, err := cmd.migrate(configLoader.ConfigPath(), logger)
+	if err != nil {
+		return err
+	}
+
+	cwd, _ := os.Getwd()
+	for _, result := range results {
+		path := result.Path
+		if rel, err := filepath.Rel(cwd, path); err == nil {
+			path = rel
+		}
+		if !result.Changed() {
+			logger.Donef("%s already uses config version %s", path, latest.Version)
+			continue
+		}
+
+		_, _ = fmt.Fprint(cmd.Out, result.Diff())
+		for _, change := range result.Changes {
+			logger.Warnf("%s: %s", path, change)
+		}
+		if cmd.DryRun {
+			continue
+		}
+
+		if !cmd.Yes {
+			optionNo := "No"
+			answer, err := logger.Question(&survey.QuestionOptions{
+				Question: fmt.Sprintf("Do you want to write the migrated config to %s?", path),
+				Options:  []string{"Yes", optionNo},
+			})
+			if err != nil {
+				return err
+			} else if answer == optionNo {
+				continue
+			}
+		}
+
+		err = result.Write()
+		if err != nil {
+			return errors.Wrapf(err, "write %s", path)
+		}
+
+		logger.Donef("Migrated %s from %s to %s", path, result.FromVersion, latest.Version)
+	}
+
+	return nil
+}
+
+// migrate migrates the config at the given path and, if enabled, the configs of its local
+// imports and dependencies
+func (cmd *MigrateConfigCmd) migrate(configPath string, log log.Logger) ([]*migrate.Result, error) {
+	results := []*migrate.Result{}
+	visited := map[string]bool{}
+	queue := []string{configPath}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if visited[path] {
+			continue
+		}
+		visited[path] = true
+
+		result, err := migrate.File(path)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+
+		basePath := filepath.Dir(path)
+		if cmd.Imports {
+			for _, i := range result.Config.Imports {
+				if i.Path == "" {
+					log.Infof("Skipping import %s, because only local imports can be migrated", i.Git)
+					continue
+				}
+
+				queue = append(queue, migrate.ConfigPath(basePath, i.Path))
+			}
+		}
+		if cmd.Dependencies {
+			names := make([]string, 0, len(result.Config.Dependencies))
+			for name := range result.Config.Dependencies {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				dependency := result.Config.Dependencies[name]
+				if dependency.Source == nil || dependency.Source.Path == "" {
+					log.Infof("Skipping dependency %s, because only local dependencies can be migrated", name)
+					continue
+				}
+
+				queue = append(queue, migrate.ConfigPath(basePath, dependency.Source.Path))
+			}
+		}
+	}
+
+	return results, nil
+}
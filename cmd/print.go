@@ -2,24 +2,32 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/sirupsen/logrus"
-	"io"
-	"os"
-	"sort"
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	logger "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/loft-sh/devspace/pkg/util/survey"
 	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v3"
 
@@ -34,7 +42,10 @@ type PrintCmd struct {
 	StripNames bool
 	SkipInfo   bool
 
-	Dependency string
+	Dependency     string
+	Explain        string
+	Output         string
+	RequiredInputs bool
 }
 
 // NewPrintCmd creates a new devspace print command
@@ -63,6 +74,9 @@ profile after all patching and variable substitution
 
 	printCmd.Flags().BoolVar(&cmd.SkipInfo, "skip-info", false, "When enabled, only prints the configuration without additional information")
 	printCmd.Flags().StringVar(&cmd.Dependency, "dependency", "", "The dependency to print the config from. Use dot to access nested dependencies (e.g. dep1.dep2)")
+	printCmd.Flags().StringVar(&cmd.Explain, "explain", "", "Explains where the final value of the given config path (e.g. deployments.api.helm.values.replicas) came from. Keys that contain dots can be quoted (e.g. deployments.api.helm.values.\"app.kubernetes.io/name\")")
+	printCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of --explain. Can be either empty or json")
+	printCmd.Flags().BoolVar(&cmd.RequiredInputs, "required-inputs", false, "Lists the inputs that would be asked for and the flags or environment variables that provide them")
 
 	return printCmd
 }
@@ -91,11 +105,40 @@ func (cmd *PrintCmd) Run(f factory.Factory) error {
 
 	parser := loader.NewEagerParser()
 
+	// record the contributions to the config values if we should explain a value
+	loadCtx := context.Background()
+	var trace *explain.Trace
+	if cmd.Explain != "" {
+		if cmd.Dependency != "" {
+			return fmt.Errorf("--explain cannot be used together with --dependency")
+		}
+
+		trace = explain.NewTrace()
+		loadCtx = explain.WithTrace(loadCtx, trace)
+	} else if cmd.Output != "" {
+		return fmt.Errorf("--output can only be used together with --explain")
+	}
+
+	// fail instead of asking to find out which inputs are missing
+	if cmd.RequiredInputs {
+		if cmd.Explain != "" {
+			return fmt.Errorf("--required-inputs cannot be used together with --explain")
+		}
+
+		survey.SetNonInteractive(true)
+		configOptions.Dry = true
+	}
+
 	// load config
-	config, err := configLoader.LoadWithParser(context.Background(), nil, client, parser, configOptions, log)
-	if err != nil {
+	config, err := configLoader.LoadWithParser(loadCtx, nil, client, parser, configOptions, log)
+	if cmd.RequiredInputs {
+		return printRequiredInputs(err, log)
+	} else if err != nil {
 		return err
 	}
+	if trace != nil {
+		return cmd.printExplain(config.Config(), trace, log)
+	}
 
 	// create devspace context
 	ctx := devspacecontext.NewContext(context.Background(), config.Variables(), log).
@@ -148,6 +191,123 @@ func (cmd *PrintCmd) Run(f factory.Factory) error {
 	return nil
 }
 
+type explainOutput struct {
+	Path          string                  `json:"path"`
+	Found         bool                    `json:"found"`
+	Value         interface{}             `json:"value,omitempty"`
+	Contributions []*explain.Contribution `json:"contributions"`
+}
+
+func (cmd *PrintCmd) printExplain(config *latest.Config, trace *explain.Trace, log logger.Logger) error {
+	rawConfig := map[string]interface{}{}
+	err := util.Convert(config, &rawConfig)
+	if err != nil {
+		return err
+	}
+
+	output := &explainOutput{
+		Path:          cmd.Explain,
+		Contributions: trace.Explain(cmd.Explain),
+	}
+	output.Value, output.Found = explain.Lookup(rawConfig, cmd.Explain)
+
+	switch cmd.Output {
+	case "":
+	case "json":
+		out, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, err = cmd.Out.Write(append(out, '\n'))
+		return err
+	default:
+		return fmt.Errorf("unsupported output format %s, please use either empty or json", cmd.Output)
+	}
+
+	if !output.Found {
+		log.WriteString(logrus.InfoLevel, cmd.Explain+" is not set\n")
+	} else {
+		out, err := yaml.Marshal(output.Value)
+		if err != nil {
+			return err
+		}
+
+		value := strings.TrimSpace(string(out))
+		if strings.Contains(value, "\n") {
+			value = "\n" + value
+		}
+		log.WriteString(logrus.InfoLevel, cmd.Explain+": "+value+"\n")
+	}
+	if len(output.Contributions) == 0 {
+		log.Info("No contributions found")
+		return nil
+	}
+
+	cwd, _ := os.Getwd()
+	values := [][]string{}
+	for _, contribution := range output.Contributions {
+		source := contribution.File
+		if cwd != "" && source != "" {
+			if rel, err := filepath.Rel(cwd, source); err == nil && !strings.HasPrefix(rel, "..") {
+				source = rel
+			}
+		}
+		if source != "" && contribution.Line > 0 {
+			source += ":" + strconv.Itoa(contribution.Line)
+		}
+
+		details := []string{}
+		if contribution.Import != "" {
+			details = append(details, "import "+contribution.Import)
+		}
+		if contribution.Module != "" {
+			details = append(details, "module "+contribution.Module)
+		}
+		if contribution.Profile != "" {
+			details = append(details, "profile "+contribution.Profile)
+		}
+		for _, name := range contribution.Variable {
+			details = append(details, "${"+name+"}")
+		}
+		if len(details) > 0 {
+			source = strings.TrimSpace(source + " (" + strings.Join(details, ", ") + ")")
+		}
+
+		value := fmt.Sprintf("%v", contribution.Value)
+		if contribution.Removed {
+			value = "<removed>"
+		}
+		values = append(values, []string{
+			contribution.Path,
+			string(contribution.Step),
+			source,
+			value,
+		})
+	}
+
+	logger.PrintTable(log, []string{"Path", "Step", "Source", "Value"}, values)
+	return nil
+}
+
+func printRequiredInputs(err error, log logger.Logger) error {
+	missingInputs := &survey.MissingInputError{}
+	if err != nil && !missingInputs.Add(err) {
+		return err
+	} else if len(missingInputs.Inputs) == 0 {
+		log.Info("No inputs required")
+		return nil
+	}
+
+	values := [][]string{}
+	for _, input := range missingInputs.Inputs {
+		values = append(values, []string{input.Question, input.Hint})
+	}
+
+	logger.PrintTable(log, []string{"Question", "Provide With"}, values)
+	return nil
+}
+
 func marshalConfig(config *latest.Config, stripNames bool) ([]byte, error) {
 	// remove the auto generated names
 	if stripNames {
@@ -207,6 +367,15 @@ func printExtraInfo(config config.Config, dependencies []types.Dependency, log l
 
 	log.WriteString(logrus.InfoLevel, "\n-------------------\n\nLoaded path: "+config.Path()+"\n\n-------------------\n\n")
 
+	fragments, err := loader.LoadFragments(config.Path())
+	if err != nil {
+		return err
+	} else if len(fragments) > 0 {
+		log.WriteString(logrus.InfoLevel, "Fragments:\n")
+		printFragments(fragments, log)
+		log.WriteString(logrus.InfoLevel, "\n-------------------\n\n")
+	}
+
 	if len(dependencies) > 0 {
 		log.WriteString(logrus.InfoLevel, "Dependency Tree:\n\n> Root\n")
 		for _, dep := range dependencies {
@@ -218,6 +387,42 @@ func printExtraInfo(config config.Config, dependencies []types.Dependency, log l
 	return nil
 }
 
+func printFragments(fragments []*loader.Fragment, log logger.Logger) {
+	cwd, _ := os.Getwd()
+	values := [][]string{}
+	for _, fragment := range fragments {
+		path := fragment.Path
+		if rel, err := filepath.Rel(cwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+
+		for _, section := range loader.FragmentSections {
+			switch value := fragment.Data[section].(type) {
+			case map[string]interface{}:
+				keys := make([]string, 0, len(value))
+				for key := range value {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					values = append(values, []string{section + "." + key, path})
+				}
+			case []interface{}:
+				for i, item := range value {
+					name := strconv.Itoa(i)
+					if itemMap, ok := item.(map[string]interface{}); ok && itemMap["name"] != nil {
+						name = fmt.Sprintf("%v", itemMap["name"])
+					}
+
+					values = append(values, []string{section + "." + name, path})
+				}
+			}
+		}
+	}
+
+	logger.PrintTable(log, []string{"Section", "Fragment"}, values)
+}
+
 func printDependencyRecursive(prefix string, dep types.Dependency, maxDepth int, log logger.Logger) {
 	if maxDepth == 0 {
 		return
@@ -8,6 +8,7 @@ import (
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/devspace/services/intercept"
 	"github.com/loft-sh/devspace/pkg/devspace/services/podreplace"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
@@ -131,6 +132,16 @@ func ResetPodsRecursive(ctx devspacecontext.Context, dependencies, force bool) i
 	// create pod replacer
 	podReplacer := podreplace.NewPodReplacer()
 	for _, replacePodCache := range ctx.Config().RemoteCache().ListDevPods() {
+		if replacePodCache.Intercept != nil {
+			err := intercept.RestoreIntercept(ctx, &replacePodCache)
+			if err != nil {
+				ctx.Log().Warnf("Error restoring intercepted service: %v", err)
+				continue
+			} else if replacePodCache.Deployment == "" {
+				continue
+			}
+		}
+
 		deleted, err := podReplacer.RevertReplacePod(ctx, &replacePodCache, &deploy.PurgeOptions{ForcePurge: force})
 		if err != nil {
 			ctx.Log().Warnf("Error resetting replaced pod: %v", err)
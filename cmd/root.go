@@ -26,13 +26,16 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/loft-sh/devspace/cmd/add"
 	"github.com/loft-sh/devspace/cmd/cleanup"
+	envcmd "github.com/loft-sh/devspace/cmd/env"
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/cmd/list"
 	"github.com/loft-sh/devspace/cmd/remove"
 	"github.com/loft-sh/devspace/cmd/reset"
 	"github.com/loft-sh/devspace/cmd/set"
+	"github.com/loft-sh/devspace/cmd/trace"
 	"github.com/loft-sh/devspace/cmd/update"
 	"github.com/loft-sh/devspace/cmd/use"
+	"github.com/loft-sh/devspace/cmd/workspace"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
@@ -40,6 +43,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	flagspkg "github.com/loft-sh/devspace/pkg/util/flags"
 	"github.com/loft-sh/devspace/pkg/util/idle"
+	"github.com/loft-sh/devspace/pkg/util/survey"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -68,6 +72,7 @@ func NewRootCmd(f factory.Factory) *cobra.Command {
 			}
 
 			ansi.DisableColors(globalFlags.NoColors)
+			survey.SetNonInteractive(globalFlags.NonInteractive)
 
 			if globalFlags.KubeConfig != "" {
 				err := os.Setenv("KUBECONFIG", globalFlags.KubeConfig)
@@ -99,6 +104,7 @@ func NewRootCmd(f factory.Factory) *cobra.Command {
 				} else if globalFlags.Debug {
 					log.SetLevel(logrus.DebugLevel)
 				}
+				survey.SetNonInteractive(globalFlags.NonInteractive)
 
 				// call inactivity timeout
 				if globalFlags.InactivityTimeout > 0 {
@@ -266,10 +272,13 @@ Additional run commands:
 	// Add sub commands
 	rootCmd.AddCommand(add.NewAddCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(cleanup.NewCleanupCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(envcmd.NewEnvCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(workspace.NewWorkspaceCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(list.NewListCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(remove.NewRemoveCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(reset.NewResetCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(set.NewSetCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(trace.NewTraceCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(use.NewUseCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(update.NewUpdateCmd(f, globalFlags, plugins))
 
@@ -287,6 +296,10 @@ Additional run commands:
 	rootCmd.AddCommand(NewRunCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewAttachCmd(f, globalFlags))
 	rootCmd.AddCommand(NewPrintCmd(f, globalFlags))
+	rootCmd.AddCommand(NewLintCmd(f, globalFlags))
+	rootCmd.AddCommand(NewTestCmd(f, globalFlags))
+	rootCmd.AddCommand(NewLspCmd(globalFlags))
+	rootCmd.AddCommand(NewMigrateConfigCmd(f, globalFlags))
 	rootCmd.AddCommand(NewRunPipelineCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewCompletionCmd())
 	rootCmd.AddCommand(NewVersionCmd())
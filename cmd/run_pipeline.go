@@ -8,7 +8,9 @@ import (
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/build"
+	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
@@ -20,11 +22,16 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/kill"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/lock"
 	pipelinepkg "github.com/loft-sh/devspace/pkg/devspace/pipeline"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/debugger"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/matrix"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
 	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/interrupt"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -32,6 +39,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 	"gopkg.in/yaml.v3"
 )
 
@@ -61,6 +69,20 @@ type RunPipelineCmd struct {
 
 	ShowUI bool
 
+	TraceFile     string
+	TraceEndpoint string
+
+	WaitForLock bool
+	ForceUnlock bool
+
+	DebugStep bool
+
+	Matrix              []string
+	MatrixMaxConcurrent int
+
+	// pipelineMatrix is the matrix of the pipeline config
+	pipelineMatrix *latest.PipelineMatrix
+
 	// used for testing to allow interruption
 	Ctx          context.Context
 	RenderWriter io.Writer
@@ -89,7 +111,19 @@ func (cmd *RunPipelineCmd) AddPipelineFlags(f factory.Factory, command *cobra.Co
 
 	command.Flags().BoolVar(&cmd.ShowUI, "show-ui", cmd.ShowUI, "Shows the ui server")
 
+	command.Flags().BoolVar(&cmd.WaitForLock, "wait-for-lock", cmd.WaitForLock, "Waits until the namespace is not locked by another DevSpace run anymore")
+	command.Flags().BoolVar(&cmd.ForceUnlock, "force-unlock", cmd.ForceUnlock, "Takes over the lock of the namespace even if it is held by another DevSpace run")
+
+	command.Flags().BoolVar(&cmd.DebugStep, "debug-step", cmd.DebugStep, "Pauses the pipeline before each command to inspect and change the execution, continued pipelines pause again at breakpoint statements")
+
+	command.Flags().StringArrayVar(&cmd.Matrix, "matrix", cmd.Matrix, "Runs the pipeline once per combination of kube contexts and namespaces, e.g. --matrix contexts=a,b --matrix namespaces=x,y")
+	command.Flags().IntVar(&cmd.MatrixMaxConcurrent, "matrix-max-concurrent", cmd.MatrixMaxConcurrent, "The maximum number of matrix combinations run in parallel (0 for infinite)")
+
+	command.Flags().StringVar(&cmd.TraceFile, "trace-file", cmd.TraceFile, "Writes an OpenTelemetry trace of the execution as json to the given file")
+	command.Flags().StringVar(&cmd.TraceEndpoint, "trace-endpoint", tracing.EndpointFromEnv(), "Sends an OpenTelemetry trace of the execution to the given OTLP/HTTP endpoint, e.g. http://localhost:4318")
+
 	if pipeline != nil {
+		cmd.pipelineMatrix = pipeline.Matrix
 		for _, pipelineFlag := range pipeline.Flags {
 			if pipelineFlag.Name == "" {
 				continue
@@ -229,18 +263,211 @@ func (cmd *RunPipelineCmd) Run(cobraCmd *cobra.Command, args []string, f factory
 	}
 
 	// set command in context
+	commandName := "devspace run-pipeline " + cmd.Pipeline
 	if cobraCmd != nil {
 		cmd.Ctx = values.WithCommandFlags(cmd.Ctx, cobraCmd.Flags())
+		commandName = cobraCmd.CommandPath()
+	}
+
+	// pause before each pipeline command
+	if cmd.DebugStep {
+		cmd.Ctx = debugger.WithDebugger(cmd.Ctx, debugger.New(os.Stdin, os.Stdout))
+	}
+
+	// start tracing, the trace is also flushed if devspace is interrupted
+	var stopTracing func(err error)
+	cmd.Ctx, stopTracing = tracing.StartCommand(cmd.Ctx, commandName, tracing.Options{
+		File:     cmd.TraceFile,
+		Endpoint: cmd.TraceEndpoint,
+	}, cmd.Log)
+	return interrupt.Global.Run(func() (err error) {
+		defer func() {
+			stopTracing(err)
+		}()
+
+		options := cmd.BuildOptions(cmd.ToConfigOptions())
+		cells, maxConcurrent, err := cmd.matrixCells()
+		if err != nil {
+			return err
+		} else if len(cells) > 0 {
+			return cmd.runMatrix(f, cells, maxConcurrent, options, args, hookName, commandName)
+		}
+
+		return cmd.execute(cmd.Ctx, f, options, args, hookName, commandName, cmd.Log)
+	}, func() {
+		stopTracing(errors.New("interrupted"))
+	})
+}
+
+// execute initializes the devspace context and runs the pipeline with the hooks while holding the namespace lock
+func (cmd *RunPipelineCmd) execute(ctx context.Context, f factory.Factory, options *CommandOptions, args []string, hookName, commandName string, logger log.Logger) error {
+	devCtx, err := initialize(ctx, f, options, logger)
+	if err != nil {
+		return err
+	} else if devCtx.KubeClient() == nil || !cmd.locksNamespace() {
+		return runWithHooks(devCtx, hookName, func() error {
+			return runPipeline(devCtx, args, options)
+		})
+	}
+
+	// lock the namespace, so that concurrent runs don't overwrite each other's changes
+	namespaceLock := lock.NewLock(devCtx.KubeClient(), devCtx.Log())
+	err = namespaceLock.Acquire(devCtx.Context(), lock.Options{
+		Wait:    cmd.WaitForLock,
+		Force:   cmd.ForceUnlock,
+		Command: commandName,
+	})
+	if err != nil {
+		lockedErr := &lock.LockedError{}
+		if errors.As(err, &lockedErr) {
+			return errors.Errorf("%v, use --wait-for-lock to wait until it is released or --force-unlock to take it over", err)
+		}
+
+		return errors.Wrap(err, "lock namespace")
+	}
+
+	devCtx = devCtx.WithContext(lock.WithLock(devCtx.Context(), namespaceLock))
+	return interrupt.Global.RunAlways(func() error {
+		return runWithHooks(devCtx, hookName, func() error {
+			return runPipeline(devCtx, args, options)
+		})
+	}, namespaceLock.Release)
+}
+
+// matrixCells returns the kube context and namespace combinations to run the pipeline in and
+// how many of them are run in parallel
+func (cmd *RunPipelineCmd) matrixCells() ([]matrix.Cell, int, error) {
+	pipelineMatrix, err := matrix.Parse(cmd.pipelineMatrix, cmd.Matrix)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "parse --matrix")
+	}
+
+	maxConcurrent := cmd.MatrixMaxConcurrent
+	if maxConcurrent == 0 && pipelineMatrix != nil {
+		maxConcurrent = pipelineMatrix.MaxConcurrent
+	}
+
+	return matrix.Cells(pipelineMatrix), maxConcurrent, nil
+}
+
+// runMatrix builds the images once and then runs the pipeline once per cell, each with its own
+// kube client, config and dependencies, and prints a summary of the results
+func (cmd *RunPipelineCmd) runMatrix(f factory.Factory, cells []matrix.Cell, maxConcurrent int, options *CommandOptions, args []string, hookName, commandName string) error {
+	if cmd.DebugStep {
+		return errors.New("--debug-step cannot be used together with a matrix")
+	} else if cmd.Render {
+		// rendered manifests of several cells shouldn't mix
+		maxConcurrent = 1
 	}
-	options := cmd.BuildOptions(cmd.ToConfigOptions())
-	ctx, err := initialize(cmd.Ctx, f, options, cmd.Log)
+
+	// the cells share the local cache, so that they don't write the cache file at the same time
+	localCache, err := loadLocalCache(f, options, cmd.Log)
 	if err != nil {
 		return err
 	}
+	matrixOptions := *options
+	matrixOptions.LocalCache = localCache
+	options = &matrixOptions
+
+	// build the images once, so that the cells don't build and push the same images in parallel
+	if !options.BuildOptions.SkipBuild && !cmd.Render && cmd.Pipeline != "purge" {
+		cmd.Log.Infof("Build images before running pipeline %s in %d matrix combinations", cmd.Pipeline, len(cells))
+		err = buildImages(cmd.Ctx, f, matrixCellOptions(options, cells[0]), cmd.Log)
+		if err != nil {
+			return errors.Wrap(err, "build images")
+		}
+
+		options.BuildOptions.SkipBuild = true
+	}
 
-	return runWithHooks(ctx, hookName, func() error {
-		return runPipeline(ctx, args, options)
+	cmd.Log.Infof("Run pipeline %s in %d matrix combinations", cmd.Pipeline, len(cells))
+	results := matrix.Run(cmd.Ctx, cells, maxConcurrent, func(ctx context.Context, cell matrix.Cell) (err error) {
+		ctx, span := tracing.Start(ctx, "matrix "+cell.String(),
+			attribute.String("devspace.kube_context", cell.Context),
+			attribute.String("devspace.namespace", cell.Namespace),
+		)
+		defer func() {
+			tracing.End(span, err)
+		}()
+
+		// prefix every line, because the logs of the cells are printed at the same time
+		hashNumber := int(hash.StringToNumber(cell.String()))
+		if hashNumber < 0 {
+			hashNumber = hashNumber * -1
+		}
+		prefix := ansi.Color(cell.String()+" ", log.Colors[hashNumber%len(log.Colors)])
+		stdout := matrix.NewPrefixWriter(os.Stdout, prefix)
+		defer stdout.Close()
+		stderr := matrix.NewPrefixWriter(os.Stderr, prefix)
+		defer stderr.Close()
+
+		logger := log.NewStreamLoggerWithFormat(stdout, stderr, cmd.Log.GetLevel(), log.TextFormat).WithSink(log.GetFileLogger("default").WithPrefix(cell.String() + " "))
+		err = cmd.execute(ctx, f, matrixCellOptions(options, cell), args, hookName, commandName, logger)
+		if err != nil {
+			logger.Errorf("%v", err)
+		}
+		return err
 	})
+
+	matrix.PrintSummary(cmd.Log, results)
+	return matrix.Err(results)
+}
+
+// matrixCellOptions returns a copy of the options that runs the pipeline in the kube context and namespace of the cell
+func matrixCellOptions(options *CommandOptions, cell matrix.Cell) *CommandOptions {
+	cellOptions := *options
+	configOptions := *options.ConfigOptions
+	cellOptions.ConfigOptions = &configOptions
+	if cell.Context != "" {
+		cellOptions.KubeContext = cell.Context
+	}
+	if cell.Namespace != "" {
+		cellOptions.Namespace = cell.Namespace
+	}
+
+	// the cells run in other kube contexts and namespaces on purpose, so we don't warn or ask
+	cellOptions.NoWarn = true
+	cellOptions.SwitchContext = false
+	return &cellOptions
+}
+
+// buildImages runs the build pipeline with the given options
+func buildImages(ctx context.Context, f factory.Factory, options *CommandOptions, logger log.Logger) error {
+	buildOptions := *options
+	buildOptions.Pipeline = "build"
+	devCtx, err := initialize(ctx, f, &buildOptions, logger)
+	if err != nil {
+		return err
+	}
+	defer deleteTempFolder(devCtx.Context(), devCtx.Log())
+
+	return runPipeline(devCtx, nil, &buildOptions)
+}
+
+// loadLocalCache loads the local cache of the config
+func loadLocalCache(f factory.Factory, options *CommandOptions, logger log.Logger) (localcache.Cache, error) {
+	configLoader, err := f.NewConfigLoader(options.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return nil, err
+	} else if !configExists {
+		return nil, errors.New(message.ConfigNotFound)
+	}
+
+	localCache, err := configLoader.LoadLocalCache()
+	if err != nil {
+		return nil, errors.Errorf("error loading local cache: %v", err)
+	}
+
+	return localCache, nil
+}
+
+// locksNamespace returns true if the pipeline changes the namespace and should hold its lock
+func (cmd *RunPipelineCmd) locksNamespace() bool {
+	return !cmd.Render && cmd.Pipeline != "build"
 }
 
 type CommandOptions struct {
@@ -249,6 +476,9 @@ type CommandOptions struct {
 
 	ConfigOptions *loader.ConfigOptions
 
+	// LocalCache is used instead of loading the local cache of the config if set
+	LocalCache localcache.Cache
+
 	Pipeline string
 	ShowUI   bool
 	UIPort   int
@@ -288,9 +518,12 @@ func initialize(ctx context.Context, f factory.Factory, options *CommandOptions,
 	}
 
 	// load generated config
-	localCache, err := configLoader.LoadLocalCache()
-	if err != nil {
-		return nil, errors.Errorf("error loading local cache: %v", err)
+	localCache := options.LocalCache
+	if localCache == nil {
+		localCache, err = configLoader.LoadLocalCache()
+		if err != nil {
+			return nil, errors.Errorf("error loading local cache: %v", err)
+		}
 	}
 
 	if client != nil {
@@ -312,7 +545,7 @@ func initialize(ctx context.Context, f factory.Factory, options *CommandOptions,
 	ctx = values.WithRootName(ctx, configInterface.Config().Name)
 
 	// create devspace context
-	devCtx := devspacecontext.NewContext(ctx, configInterface.Variables(), logger).
+	devCtx := devspacecontext.NewContext(ctx, config.PipelineVariables(configInterface), logger).
 		WithConfig(configInterface).
 		WithKubeClient(client)
 
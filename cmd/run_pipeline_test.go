@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/matrix"
+	"github.com/spf13/cobra"
+	"gotest.tools/assert"
+)
+
+type matrixFlagTestCase struct {
+	name     string
+	args     []string
+	pipeline *latest.Pipeline
+
+	expectedCells         []matrix.Cell
+	expectedMaxConcurrent int
+	expectedArgs          []string
+}
+
+func TestMatrixFlag(t *testing.T) {
+	cells := []matrix.Cell{
+		{Context: "a", Namespace: "x"},
+		{Context: "a", Namespace: "y"},
+		{Context: "b", Namespace: "x"},
+		{Context: "b", Namespace: "y"},
+	}
+	testCases := []matrixFlagTestCase{
+		{
+			name:          "repeated flag",
+			args:          []string{"--matrix", "contexts=a,b", "--matrix", "namespaces=x,y"},
+			expectedCells: cells,
+			expectedArgs:  []string{},
+		},
+		{
+			name:          "quoted axes",
+			args:          []string{"--matrix", "contexts=a,b namespaces=x,y"},
+			expectedCells: cells,
+			expectedArgs:  []string{},
+		},
+		{
+			name:          "unquoted axes",
+			args:          []string{"--matrix", "contexts=a,b", "namespaces=x,y"},
+			expectedCells: []matrix.Cell{{Context: "a"}, {Context: "b"}},
+			expectedArgs:  []string{"namespaces=x,y"},
+		},
+		{
+			name: "override pipeline matrix",
+			args: []string{"--matrix", "contexts=a,b", "--matrix-max-concurrent", "1"},
+			pipeline: &latest.Pipeline{
+				Matrix: &latest.PipelineMatrix{Contexts: []string{"c"}, Namespaces: []string{"x", "y"}, MaxConcurrent: 2},
+			},
+			expectedCells:         cells,
+			expectedMaxConcurrent: 1,
+			expectedArgs:          []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		cmd := &RunPipelineCmd{}
+		command := &cobra.Command{}
+		cmd.AddPipelineFlags(nil, command, testCase.pipeline)
+		assert.NilError(t, command.ParseFlags(testCase.args), testCase.name)
+		assert.DeepEqual(t, command.Flags().Args(), testCase.expectedArgs)
+
+		cells, maxConcurrent, err := cmd.matrixCells()
+		assert.NilError(t, err, testCase.name)
+		assert.DeepEqual(t, cells, testCase.expectedCells)
+		assert.Equal(t, maxConcurrent, testCase.expectedMaxConcurrent, testCase.name)
+	}
+}
@@ -2,11 +2,14 @@ package set
 
 import (
 	"context"
+	"path/filepath"
 	"strings"
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/teamcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
@@ -16,10 +19,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	scopePersonal = "personal"
+	scopeTeam     = "team"
+)
+
 type varCmd struct {
 	*flags.GlobalFlags
 
 	Overwrite bool
+	Scope     string
 }
 
 func newVarCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
@@ -37,6 +46,7 @@ Sets a specific variable
 Examples:
 devspace set var key=value
 devspace set var key=value key2=value2
+devspace set var key=value --scope team
 #######################################################
 	`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
@@ -44,6 +54,7 @@ devspace set var key=value key2=value2
 		}}
 
 	varsCmd.Flags().BoolVar(&cmd.Overwrite, "overwrite", true, "If true will overwrite the variables value even if its set already")
+	varsCmd.Flags().StringVar(&cmd.Scope, "scope", scopePersonal, "Where to store the variables. Can be either personal (local cache) or team (shared team vars of the config)")
 	return varsCmd
 }
 
@@ -63,6 +74,10 @@ func (cmd *varCmd) RunSetVar(f factory.Factory, cobraCmd *cobra.Command, args []
 		return errors.New(message.ConfigNotFound)
 	}
 
+	if cmd.Scope != scopePersonal && cmd.Scope != scopeTeam {
+		return errors.Errorf("unsupported value for flag --scope: %s, please use either %s or %s", cmd.Scope, scopePersonal, scopeTeam)
+	}
+
 	// Load config and find all variables in it
 	variableParser := &variableParser{}
 	c, err := configLoader.LoadWithParser(context.Background(), nil, nil, variableParser, cmd.ToConfigOptions(), log)
@@ -70,7 +85,33 @@ func (cmd *varCmd) RunSetVar(f factory.Factory, cobraCmd *cobra.Command, args []
 		return err
 	}
 
+	// the team scope stores the variables in the team vars secret
+	var (
+		client    kubectl.Client
+		teamCache teamcache.Cache
+	)
+	if cmd.Scope == scopeTeam {
+		if variableParser.TeamVars == nil {
+			return errors.Errorf("cannot set team variables, because teamVars is not configured in %s", filepath.Base(configLoader.ConfigPath()))
+		}
+
+		client, err = f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+		if err != nil {
+			return errors.Wrap(err, "create kube client")
+		}
+
+		name, _ := c.Raw()["name"].(string)
+		teamCache, err = teamcache.Load(context.Background(), client, name, variableParser.TeamVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Set vars
+	getVar, setVar := c.LocalCache().GetVar, c.LocalCache().SetVar
+	if teamCache != nil {
+		getVar, setVar = teamCache.GetVar, teamCache.SetVar
+	}
 	for _, v := range args {
 		if v == "" {
 			continue
@@ -103,21 +144,32 @@ func (cmd *varCmd) RunSetVar(f factory.Factory, cobraCmd *cobra.Command, args []
 		// try to find it in definitions
 		for _, def := range variableParser.Definitions {
 			if def.Name == splitted[0] {
-				if def.Command != "" || len(def.Commands) > 0 || def.Source == latest.VariableSourceCommand || def.Source == latest.VariableSourceEnv || def.Source == latest.VariableSourceNone {
+				if def.Command != "" || len(def.Commands) > 0 || def.Source == latest.VariableSourceCommand || def.Source == latest.VariableSourceEnv || def.Source == latest.VariableSourceNone || def.Source == latest.VariableSourceSecret || def.Secret != nil {
 					return errors.Errorf("cannot set variable %s, because variable is not loaded from cache. Please change variable type to cache it", def.Name)
 				}
 			}
 		}
 
 		// only overwrite it if the flag is true and value is not set yet
-		_, found = c.LocalCache().GetVar(splitted[0])
+		_, found = getVar(splitted[0])
 		if cmd.Overwrite || !found {
-			c.LocalCache().SetVar(splitted[0], splitted[1])
+			setVar(splitted[0], splitted[1])
 		} else {
 			log.Infof("Skip variable %s, because it already has a value", splitted[0])
 		}
 	}
 
+	// Save the team vars
+	if teamCache != nil {
+		err = teamCache.Save(context.Background(), client)
+		if err != nil {
+			return err
+		}
+
+		log.Donef("Successfully changed team variables in secret %s/%s", teamCache.Namespace(), teamCache.Name())
+		return nil
+	}
+
 	// Save the config
 	err = c.LocalCache().Save()
 	if err != nil {
@@ -131,6 +183,7 @@ func (cmd *varCmd) RunSetVar(f factory.Factory, cobraCmd *cobra.Command, args []
 type variableParser struct {
 	Definitions map[string]*latest.Variable
 	Used        []*latest.Variable
+	TeamVars    *latest.TeamVars
 }
 
 func (v *variableParser) Parse(ctx context.Context, originalRawConfig map[string]interface{}, rawConfig map[string]interface{}, resolver variable.Resolver, log log.Logger) (*latest.Config, map[string]interface{}, error) {
@@ -140,6 +193,11 @@ func (v *variableParser) Parse(ctx context.Context, originalRawConfig map[string
 		return nil, nil, err
 	}
 
+	v.TeamVars, err = teamcache.ParseTeamVars(originalRawConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	v.Definitions = resolver.DefinedVars()
 	v.Used = varsUsed
 	return latest.NewRaw(), map[string]interface{}{}, nil
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/pipelinetest"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// TestPipelinesCmd holds the test pipelines cmd flags
+type TestPipelinesCmd struct {
+	*flags.GlobalFlags
+
+	File string
+	Run  string
+}
+
+// NewTestCmd creates a new test command
+func NewTestCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	testCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Tests parts of the DevSpace configuration",
+		Long: `
+#######################################################
+#################### devspace test ####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	testCmd.AddCommand(newTestPipelinesCmd(f, globalFlags))
+	return testCmd
+}
+
+func newTestPipelinesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TestPipelinesCmd{GlobalFlags: globalFlags}
+	testPipelinesCmd := &cobra.Command{
+		Use:   "pipelines",
+		Short: "Runs the pipeline test cases with stubbed pipeline commands",
+		Long: `
+#######################################################
+############### devspace test pipelines ###############
+#######################################################
+Runs the pipelines of the devspace.yaml with every
+pipeline command such as create_deployments or
+build_images replaced by a stub that records the call.
+The test cases are loaded from devspace.test.yaml:
+
+tests:
+- name: deploy stops if the build fails
+  pipeline: deploy
+  stubs:
+  - command: build_images
+    exitCode: 1
+  expect:
+    fail: true
+    calls:
+    - command: build_images
+      args: ["--all"]
+    notCalled: ["create_deployments"]
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.RunTests(f)
+		},
+	}
+
+	testPipelinesCmd.Flags().StringVarP(&cmd.File, "file", "f", pipelinetest.DefaultFile, "The file to load the test cases from")
+	testPipelinesCmd.Flags().StringVar(&cmd.Run, "run", "", "Only runs the test cases whose name matches the regular expression")
+	return testPipelinesCmd
+}
+
+// RunTests executes the command logic
+func (cmd *TestPipelinesCmd) RunTests(f factory.Factory) error {
+	var filter *regexp.Regexp
+	if cmd.Run != "" {
+		var err error
+		filter, err = regexp.Compile(cmd.Run)
+		if err != nil {
+			return errors.Wrap(err, "parse --run")
+		}
+	}
+
+	logger := f.GetLog()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	testFile, err := pipelinetest.LoadFile(cmd.File)
+	if err != nil {
+		return err
+	}
+
+	passed, failed := 0, 0
+	for _, testCase := range testFile.Tests {
+		if filter != nil && !filter.MatchString(testCase.Name) {
+			continue
+		}
+
+		result, failures := cmd.runTest(f, testCase, logger)
+		if len(failures) == 0 {
+			passed++
+			logger.Donef("PASS %s", testCase.Name)
+			continue
+		}
+
+		failed++
+		logger.Errorf("FAIL %s", testCase.Name)
+		for _, failure := range failures {
+			logger.Errorf("    %s", failure)
+		}
+		if result != nil {
+			calls := []string{}
+			for _, call := range result.Calls {
+				calls = append(calls, "      "+call.String())
+			}
+			if len(calls) > 0 {
+				logger.Infof("    Recorded calls:\n%s", strings.Join(calls, "\n"))
+			}
+			if result.Output != "" {
+				logger.Infof("    Output:\n%s", strings.TrimRight(result.Output, "\n"))
+			}
+		}
+	}
+
+	if passed+failed == 0 {
+		return fmt.Errorf("no test cases found in %s", cmd.File)
+	} else if failed > 0 {
+		return fmt.Errorf("%d of %d pipeline tests failed", failed, passed+failed)
+	}
+
+	logger.Donef("%d pipeline tests passed", passed)
+	return nil
+}
+
+func (cmd *TestPipelinesCmd) runTest(f factory.Factory, testCase *pipelinetest.TestCase, logger log.Logger) (*pipelinetest.Result, []string) {
+	configOptions := cmd.ToConfigOptions()
+	configOptions.Dry = true
+	configOptions.Profiles = append(configOptions.Profiles, testCase.Profiles...)
+	configOptions.Vars = append([]string{}, configOptions.Vars...)
+	varNames := []string{}
+	for name := range testCase.Vars {
+		varNames = append(varNames, name)
+	}
+	sort.Strings(varNames)
+	for _, name := range varNames {
+		configOptions.Vars = append(configOptions.Vars, name+"="+testCase.Vars[name])
+	}
+
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	// the config is loaded without a kube client and with an empty cache, so that tests are
+	// independent of the current cluster and earlier runs
+	ctx := context.Background()
+	configInterface, err := configLoader.LoadWithCache(ctx, localcache.New(""), nil, configOptions, log.Discard)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("load config: %v", err)}
+	}
+
+	// parse the flags the same way devspace run-pipeline does
+	pipelineConfig := configInterface.Config().Pipelines[testCase.Pipeline]
+	flagsCmd := &cobra.Command{Use: testCase.Pipeline}
+	(&RunPipelineCmd{GlobalFlags: cmd.GlobalFlags}).AddPipelineFlags(f, flagsCmd, pipelineConfig)
+	flagNames := []string{}
+	for name := range testCase.Flags {
+		flagNames = append(flagNames, name)
+	}
+	sort.Strings(flagNames)
+	for _, name := range flagNames {
+		items := []interface{}{testCase.Flags[name]}
+		if slice, ok := testCase.Flags[name].([]interface{}); ok {
+			items = slice
+		}
+		for _, value := range items {
+			err = flagsCmd.Flags().Set(name, fmt.Sprint(value))
+			if err != nil {
+				return nil, []string{fmt.Sprintf("set flag %s: %v", name, err)}
+			}
+		}
+	}
+
+	ctx = values.WithRootName(ctx, configInterface.Config().Name)
+	ctx = values.WithCommandFlags(ctx, flagsCmd.Flags())
+	devCtx := devspacecontext.NewContext(ctx, config.PipelineVariables(configInterface), logger).WithConfig(configInterface)
+	result, err := pipelinetest.Run(devCtx, testCase)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	return result, pipelinetest.Verify(testCase, result)
+}
@@ -0,0 +1,31 @@
+package trace
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewTraceCmd creates a new cobra command for the trace sub command
+func NewTraceCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	traceCmd := &cobra.Command{
+		Use:   "trace",
+		Short: "Shows recorded traces of pipeline executions",
+		Long: `
+#######################################################
+################### devspace trace ####################
+#######################################################
+DevSpace records an OpenTelemetry trace of every
+pipeline execution in .devspace/traces
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	traceCmd.AddCommand(newViewCmd(f, globalFlags))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(traceCmd, plugins, "trace")
+	return traceCmd
+}
@@ -0,0 +1,92 @@
+package trace
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type viewCmd struct {
+	*flags.GlobalFlags
+
+	MinDuration time.Duration
+	Width       int
+}
+
+func newViewCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &viewCmd{GlobalFlags: globalFlags}
+
+	viewCmd := &cobra.Command{
+		Use:   "view [file]",
+		Short: "Prints a recorded trace as waterfall",
+		Long: `
+#######################################################
+################ devspace trace view ##################
+#######################################################
+Prints the spans of a trace as waterfall. If no file is
+given, the most recent trace of the project is shown.
+
+Examples:
+devspace trace view
+devspace trace view --min-duration 1s
+devspace trace view trace.json
+#######################################################
+	`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f, args)
+		}}
+
+	viewCmd.Flags().DurationVar(&cmd.MinDuration, "min-duration", 0, "Hides spans that took less than the given duration, e.g. 500ms")
+	viewCmd.Flags().IntVar(&cmd.Width, "width", 50, "The width of the timeline")
+	return viewCmd
+}
+
+// Run executes the command logic
+func (cmd *viewCmd) Run(f factory.Factory, args []string) error {
+	logger := f.GetLog()
+
+	file := ""
+	if len(args) > 0 {
+		file = args[0]
+	} else {
+		configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+		if err != nil {
+			return err
+		}
+		_, err = configLoader.SetDevSpaceRoot(logger)
+		if err != nil {
+			return err
+		}
+
+		traces, err := tracing.ListHistory(tracing.HistoryDir)
+		if err != nil {
+			return err
+		} else if len(traces) == 0 {
+			return fmt.Errorf("no traces found in %s, please run a pipeline first", tracing.HistoryDir)
+		}
+
+		file = traces[len(traces)-1]
+	}
+	if cmd.Width <= 0 {
+		return errors.Errorf("invalid --width %d", cmd.Width)
+	}
+
+	roots, err := tracing.LoadFile(file)
+	if err != nil {
+		return err
+	} else if len(roots) == 0 {
+		return fmt.Errorf("trace %s has no spans", file)
+	}
+
+	logger.Infof("Trace %s", filepath.Base(file))
+	logger.WriteString(logrus.InfoLevel, "\n"+tracing.Waterfall(roots, cmd.Width, cmd.MinDuration)+"\n")
+	return nil
+}
@@ -0,0 +1,56 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/services/workspace"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+type deleteCmd struct {
+	*flags.GlobalFlags
+}
+
+func newDeleteCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &deleteCmd{GlobalFlags: globalFlags}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes a workspace and its snapshots",
+		Long: `
+#######################################################
+############# devspace workspace delete ###############
+#######################################################
+Deletes a workspace including all of its snapshots.
+Workspaces are not deleted by devspace purge.
+
+Examples:
+devspace workspace delete go-cache
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f, args[0])
+		}}
+
+	return deleteCmd
+}
+
+// Run executes the command logic
+func (cmd *deleteCmd) Run(f factory.Factory, name string) error {
+	logger := f.GetLog()
+	client, err := newKubeClient(f, cmd.GlobalFlags, logger)
+	if err != nil {
+		return err
+	}
+
+	err = workspace.Delete(context.Background(), client, client.Namespace(), name)
+	if err != nil {
+		return err
+	}
+
+	logger.Donef("Successfully deleted workspace %s", name)
+	return nil
+}
@@ -0,0 +1,88 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/services/workspace"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+type listCmd struct {
+	*flags.GlobalFlags
+
+	Snapshots bool
+}
+
+func newListCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &listCmd{GlobalFlags: globalFlags}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the workspaces of the current user",
+		Long: `
+#######################################################
+############### devspace workspace list ###############
+#######################################################
+Lists the workspaces of the current user in the namespace
+
+Examples:
+devspace workspace list
+devspace workspace list --snapshots
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f)
+		}}
+
+	listCmd.Flags().BoolVar(&cmd.Snapshots, "snapshots", false, "If true, will list the snapshots of the workspaces")
+	return listCmd
+}
+
+// Run executes the command logic
+func (cmd *listCmd) Run(f factory.Factory) error {
+	logger := f.GetLog()
+	client, err := newKubeClient(f, cmd.GlobalFlags, logger)
+	if err != nil {
+		return err
+	}
+
+	claims, err := workspace.List(context.Background(), client, client.Namespace(), workspace.CurrentUser(), cmd.Snapshots)
+	if err != nil {
+		return err
+	} else if len(claims) == 0 {
+		if cmd.Snapshots {
+			logger.Infof("No workspace snapshots found in namespace %s", client.Namespace())
+		} else {
+			logger.Infof("No workspaces found in namespace %s", client.Namespace())
+		}
+		return nil
+	}
+
+	headerColumnNames := []string{"Workspace"}
+	if cmd.Snapshots {
+		headerColumnNames = append(headerColumnNames, "Snapshot")
+	}
+	headerColumnNames = append(headerColumnNames, "Claim", "Size", "Status", "Age")
+
+	rows := make([][]string, 0, len(claims))
+	for _, claim := range claims {
+		row := []string{claim.Labels[workspace.WorkspaceLabel]}
+		if cmd.Snapshots {
+			row = append(row, claim.Labels[workspace.SnapshotLabel])
+		}
+
+		size := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+		row = append(row, claim.Name, size.String(), string(claim.Status.Phase), duration.HumanDuration(time.Since(claim.CreationTimestamp.Time)))
+		rows = append(rows, row)
+	}
+
+	log.PrintTable(logger, headerColumnNames, rows)
+	return nil
+}
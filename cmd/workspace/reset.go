@@ -0,0 +1,72 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/services/workspace"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+type resetCmd struct {
+	*flags.GlobalFlags
+
+	Snapshot string
+}
+
+func newResetCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &resetCmd{GlobalFlags: globalFlags}
+
+	resetCmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Resets a workspace",
+		Long: `
+#######################################################
+############## devspace workspace reset ###############
+#######################################################
+Deletes the contents of a workspace or restores it from
+a snapshot. Make sure no dev pod is using the workspace
+while it is reset.
+
+Examples:
+devspace workspace reset go-cache
+devspace workspace reset go-cache --from-snapshot before-upgrade
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f, args[0])
+		}}
+
+	resetCmd.Flags().StringVar(&cmd.Snapshot, "from-snapshot", "", "The snapshot to restore the workspace from")
+	return resetCmd
+}
+
+// Run executes the command logic
+func (cmd *resetCmd) Run(f factory.Factory, name string) error {
+	if cmd.Snapshot != "" {
+		err := workspace.ValidateSnapshotName(cmd.Snapshot)
+		if err != nil {
+			return err
+		}
+	}
+
+	logger := f.GetLog()
+	client, err := newKubeClient(f, cmd.GlobalFlags, logger)
+	if err != nil {
+		return err
+	}
+
+	err = workspace.Reset(context.Background(), client, client.Namespace(), name, cmd.Snapshot, logger)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Snapshot != "" {
+		logger.Donef("Successfully restored workspace %s from snapshot %s", name, cmd.Snapshot)
+	} else {
+		logger.Donef("Successfully reset workspace %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/services/workspace"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+type snapshotCmd struct {
+	*flags.GlobalFlags
+}
+
+func newSnapshotCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &snapshotCmd{GlobalFlags: globalFlags}
+
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Creates a snapshot of a workspace",
+		Long: `
+#######################################################
+############# devspace workspace snapshot #############
+#######################################################
+Creates a snapshot of a workspace by cloning its
+persistent volume claim. The storage class of the
+workspace needs to support volume cloning. Snapshot
+names must be valid DNS labels.
+
+Examples:
+devspace workspace snapshot go-cache
+devspace workspace snapshot go-cache before-upgrade
+#######################################################
+	`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			snapshot := time.Now().Format("20060102-150405")
+			if len(args) > 1 {
+				snapshot = args[1]
+			}
+
+			return cmd.Run(f, args[0], snapshot)
+		}}
+
+	return snapshotCmd
+}
+
+// Run executes the command logic
+func (cmd *snapshotCmd) Run(f factory.Factory, name, snapshot string) error {
+	err := workspace.ValidateSnapshotName(snapshot)
+	if err != nil {
+		return err
+	}
+
+	logger := f.GetLog()
+	client, err := newKubeClient(f, cmd.GlobalFlags, logger)
+	if err != nil {
+		return err
+	}
+
+	err = workspace.Snapshot(context.Background(), client, client.Namespace(), name, snapshot)
+	if err != nil {
+		return err
+	}
+
+	logger.Donef("Successfully created snapshot %s of workspace %s", snapshot, name)
+	return nil
+}
@@ -0,0 +1,80 @@
+package workspace
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services/workspace"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// userName is the user set through --user
+var userName string
+
+// NewWorkspaceCmd creates a new cobra command for the workspace sub command
+func NewWorkspaceCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	workspaceCmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Manages persistent workspaces",
+		Long: `
+#######################################################
+################# devspace workspace ##################
+#######################################################
+Workspaces are named persistent volumes that are scoped
+to the current user and survive pod replacements as well
+as devspace purge. The user is the global git email or
+the local user name and can be set with --user or the
+DEVSPACE_WORKSPACE_USER environment variable.
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	workspaceCmd.PersistentFlags().StringVar(&userName, "user", "", "The user the workspaces are scoped to, defaults to the global git email")
+	workspaceCmd.AddCommand(newListCmd(f, globalFlags))
+	workspaceCmd.AddCommand(newResetCmd(f, globalFlags))
+	workspaceCmd.AddCommand(newSnapshotCmd(f, globalFlags))
+	workspaceCmd.AddCommand(newDeleteCmd(f, globalFlags))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(workspaceCmd, plugins, "workspace")
+	return workspaceCmd
+}
+
+// newKubeClient creates a new kube client and checks the kube context against the last used one
+func newKubeClient(f factory.Factory, globalFlags *flags.GlobalFlags, logger log.Logger) (kubectl.Client, error) {
+	if userName != "" {
+		workspace.SetUser(userName)
+	}
+
+	configLoader, err := f.NewConfigLoader(globalFlags.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := f.NewKubeClientFromContext(globalFlags.KubeContext, globalFlags.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "new kube client")
+	}
+
+	if configExists {
+		localCache, err := configLoader.LoadLocalCache()
+		if err != nil {
+			return nil, err
+		}
+
+		client, err = kubectl.CheckKubeContext(client, localCache, globalFlags.NoWarn, globalFlags.SwitchContext, false, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
@@ -378,6 +378,37 @@ var Functions = []Function{
 		Flags:       commands.WaitPodOptions{},
 		Group:       groupOther,
 	},
+	{
+		Name:        "wait_http",
+		Description: "Waits until the url provided as argument returns the expected status code (e.g. `wait_http --timeout 60 http://localhost:8080/healthz`)",
+		Args:        `[url]`,
+		Handler:     commands.WaitHTTP,
+		Flags:       commands.WaitHTTPOptions{},
+		Group:       groupOther,
+	},
+	{
+		Name:        "wait_tcp",
+		Description: "Waits until the address provided as argument accepts tcp connections (e.g. `wait_tcp localhost:5432`)",
+		Args:        `[host:port]`,
+		Handler:     commands.WaitTCP,
+		Flags:       commands.WaitTCPOptions{},
+		Group:       groupOther,
+	},
+	{
+		Name:        "wait_resource",
+		Description: "Waits until the Kubernetes resources provided as arguments reach a state (e.g. `wait_resource --for condition=Complete job/migrate` or `wait_resource --for jsonpath={.status.phase}=Bound pvc/data`)",
+		Args:        `[kind/name] ...`,
+		Handler:     commands.WaitResource,
+		Flags:       commands.WaitResourceOptions{},
+		Group:       groupOther,
+	},
+	{
+		Name:        "set_output",
+		Description: "Sets an output declared in `pipelines.*.outputs` of the current pipeline, which can be used via `${runtime.pipelines.NAME.outputs.OUTPUT}` or `${runtime.dependencies.NAME.outputs.OUTPUT}` afterwards (e.g. `set_output url http://localhost:8080`)",
+		Args:        `[name] [value]`,
+		Handler:     commands.SetOutput,
+		Group:       groupOther,
+	},
 	{
 		Name:        "exec_container",
 		Description: `Executes the command provided as argument inside a container`,
@@ -386,6 +417,14 @@ var Functions = []Function{
 		Handler:     commands.ExecContainer,
 		Group:       groupOther,
 	},
+	{
+		Name:        "run_with_container_env",
+		Description: `Executes the command provided as argument locally with the environment of a container`,
+		Args:        `[command]`,
+		Flags:       commands.RunWithContainerEnvOptions{},
+		Handler:     commands.RunWithContainerEnv,
+		Group:       groupOther,
+	},
 	{
 		Name:        "get_config_value",
 		Description: `Returns the value of the config loaded from devspace.yaml`,
@@ -412,6 +451,32 @@ var Functions = []Function{
 		Group:       groupOther,
 		IsGlobal:    true,
 	},
+	{
+		Name:        "parallel",
+		Description: "Executes the commands separated by `:::` in parallel, prefixes their output and fails if any of them fails (e.g. `parallel --max 2 -- build_images api ::: build_images worker`)",
+		Args:        `[command-1] ::: [command-2] ...`,
+		Handler:     basiccommands.Parallel,
+		Flags:       basiccommands.ParallelOptions{},
+		Group:       groupOther,
+		IsGlobal:    true,
+	},
+	{
+		Name:        "cached",
+		Description: "Skips the command provided as argument if its inputs didn't change since its last successful run (e.g. `cached --key-files go.sum,**/*.proto --outputs gen -- make generate`)",
+		Args:        `[command]`,
+		Handler:     commands.Cached,
+		Flags:       commands.CachedOptions{},
+		Group:       groupOther,
+	},
+	{
+		Name:        "retry",
+		Description: "Executes the command provided as argument again if it fails (e.g. `retry --attempts 5 --backoff 2s -- create_deployments api`)",
+		Args:        `[command]`,
+		Handler:     basiccommands.Retry,
+		Flags:       basiccommands.RetryOptions{},
+		Group:       groupOther,
+		IsGlobal:    true,
+	},
 	{
 		Name:        "run_watch",
 		Description: `Executes the command provided as argument and watches for conditions to restart the command`,
@@ -429,6 +494,20 @@ var Functions = []Function{
 		Group:       groupOther,
 		IsGlobal:    true,
 	},
+	{
+		Name:        "breakpoint",
+		Description: "Pauses the pipeline execution if the pipeline is run with `devspace run-pipeline --debug-step`, does nothing otherwise",
+		Group:       groupOther,
+		IsGlobal:    true,
+	},
+	{
+		Name:        "timeout",
+		Description: "Executes the command provided as argument and cancels it if it does not finish within the duration, which is either a number of seconds or a value like `5m` (e.g. `timeout 5m -- wait_pod --label-selector app=api`)",
+		Args:        `[duration] [command]`,
+		Handler:     basiccommands.Timeout,
+		Group:       groupOther,
+		IsGlobal:    true,
+	},
 	{
 		Name:        "xargs",
 		Description: "Reads from stdin, splits input by blanks and executes the command provided as argument for each blank-separated input value (often used in pipes, e.g. `echo 'image-1 image-2' | xargs build_images`)",
@@ -19,6 +19,8 @@ module.exports = {
       link: { type: 'doc', id: 'configuration/reference' },
       items: [
         'configuration/imports/README',
+        'configuration/fragments/README',
+        'configuration/modules/README',
         'configuration/functions/README',
         'configuration/pipelines/README',
         'configuration/hooks/README',
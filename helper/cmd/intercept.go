@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/devspace/helper/intercept"
+	"github.com/spf13/cobra"
+)
+
+// InterceptCmd holds the intercept cmd flags
+type InterceptCmd struct {
+	Address  string
+	Target   string
+	Fallback string
+	Headers  []string
+}
+
+// NewInterceptCmd creates a new intercept command
+func NewInterceptCmd() *cobra.Command {
+	cmd := &InterceptCmd{}
+	interceptCmd := &cobra.Command{
+		Use:   "intercept",
+		Short: "Starts a new http proxy that routes requests with matching headers to a different target",
+		Args:  cobra.NoArgs,
+		RunE:  cmd.Run,
+	}
+
+	interceptCmd.Flags().StringVar(&cmd.Address, "address", "", "Address to listen to")
+	interceptCmd.Flags().StringVar(&cmd.Target, "target", "", "The url to route matching requests to")
+	interceptCmd.Flags().StringVar(&cmd.Fallback, "fallback", "", "The url to route all other requests to")
+	interceptCmd.Flags().StringArrayVar(&cmd.Headers, "header", []string{}, "Header in the form Name=Value a request needs to match")
+	return interceptCmd
+}
+
+// Run runs the command logic
+func (cmd *InterceptCmd) Run(_ *cobra.Command, _ []string) error {
+	if cmd.Address == "" || cmd.Target == "" || cmd.Fallback == "" {
+		return fmt.Errorf("--address, --target and --fallback are required")
+	}
+
+	headers := map[string]string{}
+	for _, header := range cmd.Headers {
+		splitted := strings.SplitN(header, "=", 2)
+		if len(splitted) != 2 {
+			return fmt.Errorf("unexpected header format %s, expected Name=Value", header)
+		}
+
+		headers[splitted[0]] = splitted[1]
+	}
+
+	proxy, err := intercept.NewProxy(cmd.Target, cmd.Fallback, headers)
+	if err != nil {
+		return err
+	}
+
+	return proxy.ListenAndServe(cmd.Address)
+}
@@ -40,6 +40,7 @@ func BuildRoot() *cobra.Command {
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewTunnelCmd())
 	rootCmd.AddCommand(NewSSHCmd())
+	rootCmd.AddCommand(NewInterceptCmd())
 	rootCmd.AddCommand(sync.NewSyncCmd())
 	rootCmd.AddCommand(proxycommands.NewProxyCommands())
 	return rootCmd
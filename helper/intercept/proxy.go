@@ -0,0 +1,66 @@
+package intercept
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/loft-sh/devspace/helper/util/stderrlog"
+	"github.com/pkg/errors"
+)
+
+// Proxy routes http requests that match certain headers to a different target
+type Proxy struct {
+	// Headers that need to match for the request to be routed to the target
+	Headers map[string]string
+
+	target   *httputil.ReverseProxy
+	fallback *httputil.ReverseProxy
+}
+
+// NewProxy creates a new intercept proxy that will route all requests matching
+// the given headers to target and all others to fallback
+func NewProxy(target, fallback string, headers map[string]string) (*Proxy, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse target")
+	}
+
+	fallbackURL, err := url.Parse(fallback)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse fallback")
+	}
+
+	return &Proxy{
+		Headers:  headers,
+		target:   httputil.NewSingleHostReverseProxy(targetURL),
+		fallback: httputil.NewSingleHostReverseProxy(fallbackURL),
+	}, nil
+}
+
+// Matches checks if the request contains all configured headers
+func (p *Proxy) Matches(req *http.Request) bool {
+	for k, v := range p.Headers {
+		if req.Header.Get(k) != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ServeHTTP implements the http.Handler interface
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if p.Matches(req) {
+		stderrlog.Debugf("intercept %s %s", req.Method, req.URL.String())
+		p.target.ServeHTTP(w, req)
+		return
+	}
+
+	p.fallback.ServeHTTP(w, req)
+}
+
+// ListenAndServe starts the proxy on the given address
+func (p *Proxy) ListenAndServe(address string) error {
+	return http.ListenAndServe(address, p)
+}
@@ -0,0 +1,46 @@
+package intercept
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestProxy(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("target"))
+	}))
+	defer target.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("fallback"))
+	}))
+	defer fallback.Close()
+
+	proxy, err := NewProxy(target.URL, fallback.URL, map[string]string{"X-Dev-User": "alice"})
+	assert.NilError(t, err)
+	server := httptest.NewServer(proxy)
+	defer server.Close()
+
+	testCases := map[string]string{
+		"":      "fallback",
+		"bob":   "fallback",
+		"alice": "target",
+	}
+	for header, expected := range testCases {
+		req, err := http.NewRequest("GET", server.URL, nil)
+		assert.NilError(t, err)
+		if header != "" {
+			req.Header.Set("X-Dev-User", header)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NilError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), expected, "header %q", header)
+	}
+}
@@ -1,18 +1,26 @@
 package main
 
 import (
+	_ "embed"
 	"os"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"github.com/loft-sh/devspace/cmd"
+	"github.com/loft-sh/devspace/pkg/devspace/lsp"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
 )
 
 var version = ""
 
+// schema is the json schema of the devspace.yaml that is used by the language server
+//
+//go:embed devspace-schema.json
+var schema []byte
+
 func main() {
 	upgrade.SetVersion(version)
+	lsp.SetSchema(schema)
 
 	cmd.Execute()
 	os.Exit(0)
@@ -5,6 +5,8 @@ import (
 
 	dockerclient "github.com/loft-sh/devspace/pkg/devspace/docker"
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
 	"github.com/loft-sh/devspace/pkg/devspace/build/types"
@@ -215,7 +217,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 		// Sequential or parallel build?
 		if options.Sequential {
 			// Build the image
-			err = builder.Build(ctx)
+			err = buildImage(ctx, builder, imageConfigName, resolvedImage, imageTags)
 			if err != nil {
 				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 					"IMAGE_CONFIG_NAME": imageConfigName,
@@ -271,7 +273,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 			imagesToBuild++
 			go func(ctx devspacecontext.Context) {
 				// Build the image
-				err := builder.Build(ctx)
+				err := buildImage(ctx, builder, imageConfigName, resolvedImage, imageTags)
 				if err != nil {
 					hook.LogExecuteHooks(ctx, map[string]interface{}{
 						"IMAGE_CONFIG_NAME": imageConfigName,
@@ -382,3 +384,15 @@ func (c *controller) waitForBuild(ctx devspacecontext.Context, errChan <-chan er
 
 	return nil
 }
+
+// buildImage builds a single image and records the build as span
+func buildImage(ctx devspacecontext.Context, imageBuilder builder.Interface, imageConfigName, imageName string, imageTags []string) error {
+	spanCtx, span := tracing.Start(ctx.Context(), "build image "+imageConfigName,
+		attribute.String("devspace.image", imageConfigName),
+		attribute.String("devspace.image.name", imageName),
+		attribute.StringSlice("devspace.image.tags", imageTags),
+	)
+	err := imageBuilder.Build(ctx.WithContext(spanCtx))
+	tracing.End(span, err)
+	return err
+}
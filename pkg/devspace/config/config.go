@@ -122,3 +122,20 @@ func Ensure(config Config) Config {
 
 	return retConfig
 }
+
+// PipelineVariables returns the variables of the config together with the outputs of
+// the modules, which are available in pipelines as ${runtime.modules.NAME.OUTPUT}
+func PipelineVariables(config Config) map[string]interface{} {
+	variables := config.Variables()
+	if config.Config() == nil {
+		return variables
+	}
+
+	for name, module := range config.Config().Modules {
+		for output, value := range module.Outputs {
+			variables["runtime.modules."+name+"."+output] = value
+		}
+	}
+
+	return variables
+}
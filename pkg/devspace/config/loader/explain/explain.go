@@ -0,0 +1,517 @@
+package explain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"gopkg.in/yaml.v3"
+)
+
+// Step describes the loader step that contributed a value
+type Step string
+
+const (
+	StepFile           Step = "file"
+	StepFragment       Step = "fragment"
+	StepImport         Step = "import"
+	StepModule         Step = "module"
+	StepProfileReplace Step = "profile.replace"
+	StepProfileMerge   Step = "profile.merge"
+	StepProfilePatch   Step = "profile.patch"
+	StepVariable       Step = "variable"
+	StepExpression     Step = "expression"
+)
+
+// Contribution is a single change of a config value during loading
+type Contribution struct {
+	Path     string      `json:"path"`
+	Step     Step        `json:"step"`
+	Value    interface{} `json:"value,omitempty"`
+	Removed  bool        `json:"removed,omitempty"`
+	File     string      `json:"file,omitempty"`
+	Line     int         `json:"line,omitempty"`
+	Profile  string      `json:"profile,omitempty"`
+	Import   string      `json:"import,omitempty"`
+	Module   string      `json:"module,omitempty"`
+	Variable []string    `json:"variable,omitempty"`
+}
+
+// Locator returns the file and line a config path was defined at
+type Locator func(path []string) (string, int)
+
+// Snapshot is a flattened copy of the config leaves
+type Snapshot map[string]interface{}
+
+// Trace records the contributions to the config values while the config is loaded. All
+// methods can be called on a nil trace, in which case nothing is recorded.
+type Trace struct {
+	m sync.Mutex
+
+	documents     map[string]*yaml.Node
+	documentOrder []string
+	contributions []*Contribution
+}
+
+// NewTrace creates a new empty trace
+func NewTrace() *Trace {
+	return &Trace{
+		documents: map[string]*yaml.Node{},
+	}
+}
+
+type traceKey struct{}
+
+// WithTrace returns a context that carries the trace to the config loader
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// FromContext returns the trace of the context or nil if there is none
+func FromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+	return trace
+}
+
+// AddFile parses the given yaml file to be able to locate source positions in it
+func (t *Trace) AddFile(path string) error {
+	if t == nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc := &yaml.Node{}
+	err = yaml.Unmarshal(content, doc)
+	if err != nil {
+		return err
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	if _, ok := t.documents[path]; !ok {
+		t.documentOrder = append(t.documentOrder, path)
+	}
+	t.documents[path] = doc
+	return nil
+}
+
+// Files returns all files that were parsed in the order they were added
+func (t *Trace) Files() []string {
+	if t == nil {
+		return nil
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	return append([]string{}, t.documentOrder...)
+}
+
+// FileLocator returns a locator that looks up the path in the given file
+func (t *Trace) FileLocator(file string) Locator {
+	return func(path []string) (string, int) {
+		return file, t.line(file, path)
+	}
+}
+
+// ProfileLocator returns a locator that looks up the path below the given section of the
+// profile (e.g. merge or replace) in the file the profile was defined in.
+func (t *Trace) ProfileLocator(profile string, section ...string) Locator {
+	return func(path []string) (string, int) {
+		if t == nil {
+			return "", 0
+		}
+
+		t.m.Lock()
+		defer t.m.Unlock()
+		for _, file := range t.documentOrder {
+			profileNode := findProfile(t.documents[file], profile)
+			if profileNode == nil {
+				continue
+			}
+
+			fullPath := append(append([]string{}, section...), path...)
+			return file, LineOf(profileNode, fullPath)
+		}
+
+		return "", 0
+	}
+}
+
+func (t *Trace) line(file string, path []string) int {
+	if t == nil {
+		return 0
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	doc, ok := t.documents[file]
+	if !ok {
+		return 0
+	}
+
+	return LineOf(doc, path)
+}
+
+// Snapshot flattens the given config so that it can be compared later
+func (t *Trace) Snapshot(config interface{}) Snapshot {
+	if t == nil {
+		return nil
+	}
+
+	snapshot := Snapshot{}
+	flatten("", config, snapshot)
+	return snapshot
+}
+
+// Record records all leaves that changed between the snapshot and the given config as
+// contributions of the step
+func (t *Trace) Record(template Contribution, before Snapshot, after interface{}, locate Locator) {
+	if t == nil {
+		return
+	}
+
+	afterSnapshot := t.Snapshot(after)
+	for _, path := range sortedKeys(afterSnapshot) {
+		value := afterSnapshot[path]
+		if beforeValue, ok := before[path]; ok && reflect.DeepEqual(beforeValue, value) {
+			continue
+		}
+
+		t.add(template, path, value, false, locate)
+	}
+	for _, path := range sortedKeys(before) {
+		if _, ok := afterSnapshot[path]; ok {
+			continue
+		}
+
+		t.add(template, path, nil, true, locate)
+	}
+}
+
+// RecordVariables records the leaves that contained variables or expressions before they were
+// resolved together with their resolved values. The source position is taken from the contribution
+// that introduced the unresolved value.
+func (t *Trace) RecordVariables(before Snapshot, after interface{}) {
+	if t == nil {
+		return
+	}
+
+	afterSnapshot := t.Snapshot(after)
+	for _, path := range sortedKeys(before) {
+		str, ok := before[path].(string)
+		if !ok {
+			continue
+		}
+		value, ok := afterSnapshot[path]
+		if !ok || reflect.DeepEqual(value, str) {
+			continue
+		}
+
+		contribution := Contribution{}
+		if strings.Contains(str, "$(") || strings.Contains(str, "$!(") {
+			contribution.Step = StepExpression
+		} else if matches := varspkg.VarMatchRegex.FindAllString(str, -1); len(matches) > 0 {
+			contribution.Step = StepVariable
+			for _, match := range matches {
+				match = strings.TrimLeft(match, "$!")
+				contribution.Variable = append(contribution.Variable, strings.TrimSuffix(strings.TrimPrefix(match, "{"), "}"))
+			}
+		} else {
+			continue
+		}
+
+		if last := t.last(path); last != nil {
+			contribution.File = last.File
+			contribution.Profile = last.Profile
+			contribution.Import = last.Import
+			contribution.Module = last.Module
+			t.add(contribution, path, value, false, func([]string) (string, int) {
+				return last.File, last.Line
+			})
+			continue
+		}
+
+		t.add(contribution, path, value, false, nil)
+	}
+}
+
+func (t *Trace) last(path string) *Contribution {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	for i := len(t.contributions) - 1; i >= 0; i-- {
+		if t.contributions[i].Path == path {
+			return t.contributions[i]
+		}
+	}
+
+	return nil
+}
+
+func (t *Trace) add(template Contribution, path string, value interface{}, removed bool, locate Locator) {
+	contribution := template
+	contribution.Path = path
+	contribution.Value = value
+	contribution.Removed = removed
+	if locate != nil {
+		file, line := locate(SplitPath(path))
+		if contribution.File == "" {
+			contribution.File = file
+		}
+		contribution.Line = line
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.contributions = append(t.contributions, &contribution)
+}
+
+// Explain returns the ordered contributions to the given path and all paths below it
+func (t *Trace) Explain(path string) []*Contribution {
+	if t == nil {
+		return nil
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	// quoting segments that contain dots is optional, e.g. images.orders.api matches
+	// the module entry images."orders.api"
+	path = strings.Join(SplitPath(path), ".")
+	retContributions := []*Contribution{}
+	for _, contribution := range t.contributions {
+		contributionPath := strings.Join(SplitPath(contribution.Path), ".")
+		if path == "" || contributionPath == path || strings.HasPrefix(contributionPath, path+".") || strings.HasPrefix(path, contributionPath+".") {
+			retContributions = append(retContributions, contribution)
+		}
+	}
+
+	return retContributions
+}
+
+// Lookup returns the value at the given dot separated path in the config
+func Lookup(config interface{}, path string) (interface{}, bool) {
+	current := config
+	for _, segment := range SplitPath(path) {
+		switch t := current.(type) {
+		case map[string]interface{}:
+			value, ok := t[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(t) {
+				return nil, false
+			}
+			current = t[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// SplitPath splits a dot separated config path. Segments that contain dots themselves
+// (e.g. helm values like "app.kubernetes.io/name") can be quoted or the dots can be
+// escaped with a backslash.
+func SplitPath(path string) []string {
+	path = strings.Trim(path, ".")
+	if path == "" {
+		return nil
+	}
+
+	segments := []string{}
+	segment := strings.Builder{}
+	quoted := false
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '\\' && i+1 < len(path):
+			i++
+			segment.WriteByte(path[i])
+		case c == '"':
+			quoted = !quoted
+		case c == '.' && !quoted:
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(c)
+		}
+	}
+
+	return append(segments, segment.String())
+}
+
+// JoinPath joins the segments to a dot separated config path, segments that contain
+// dots are quoted so that SplitPath returns them unchanged
+func JoinPath(segments ...string) string {
+	path := strings.Builder{}
+	for i, segment := range segments {
+		if i > 0 {
+			path.WriteByte('.')
+		}
+		if strings.ContainsAny(segment, ".\"\\") {
+			segment = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(segment) + `"`
+		}
+
+		path.WriteString(segment)
+	}
+
+	return path.String()
+}
+
+func flatten(prefix string, value interface{}, out Snapshot) {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 && prefix != "" {
+			out[prefix] = map[string]interface{}{}
+			return
+		}
+		for k, v := range t {
+			flatten(joinPath(prefix, k), v, out)
+		}
+	case map[interface{}]interface{}:
+		if len(t) == 0 && prefix != "" {
+			out[prefix] = map[string]interface{}{}
+			return
+		}
+		for k, v := range t {
+			flatten(joinPath(prefix, fmt.Sprintf("%v", k)), v, out)
+		}
+	case []interface{}:
+		if len(t) == 0 && prefix != "" {
+			out[prefix] = []interface{}{}
+			return
+		}
+		for i, v := range t {
+			flatten(joinPath(prefix, strconv.Itoa(i)), v, out)
+		}
+	default:
+		if prefix != "" {
+			out[prefix] = normalize(t)
+		}
+	}
+}
+
+// normalize makes sure numbers that went through json and yaml are comparable
+func normalize(value interface{}) interface{} {
+	switch t := value.(type) {
+	case int:
+		return float64(t)
+	case int64:
+		return float64(t)
+	case uint64:
+		return float64(t)
+	case float32:
+		return float64(t)
+	default:
+		return value
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return JoinPath(key)
+	}
+
+	return prefix + "." + JoinPath(key)
+}
+
+func sortedKeys(snapshot Snapshot) []string {
+	keys := make([]string, 0, len(snapshot))
+	for k := range snapshot {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func findProfile(doc *yaml.Node, name string) *yaml.Node {
+	profiles := child(doc, "profiles")
+	if profiles == nil || profiles.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, profile := range profiles.Content {
+		nameNode := child(profile, "name")
+		if nameNode != nil && nameNode.Value == name {
+			return profile
+		}
+	}
+
+	return nil
+}
+
+// LineOf returns the line of the deepest node in the yaml document that exists along the path
+func LineOf(doc *yaml.Node, path []string) int {
+	line, _ := Defines(doc, path)
+	return line
+}
+
+// Defines returns the line of the deepest node along the path and true if the yaml document
+// contains the whole path
+func Defines(doc *yaml.Node, path []string) (int, bool) {
+	current := doc
+	if current != nil && current.Kind == yaml.DocumentNode && len(current.Content) > 0 {
+		current = current.Content[0]
+	}
+	if current == nil {
+		return 0, false
+	}
+
+	line := current.Line
+	for _, segment := range path {
+		key, value := entry(current, segment)
+		if value == nil {
+			return line, false
+		}
+
+		line = key.Line
+		current = value
+	}
+
+	return line, true
+}
+
+func child(node *yaml.Node, segment string) *yaml.Node {
+	_, value := entry(node, segment)
+	return value
+}
+
+// entry returns the key and value node of a mapping entry or the item of a sequence
+func entry(node *yaml.Node, segment string) (*yaml.Node, *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(segment)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], node.Content[index]
+		}
+	}
+
+	return nil, nil
+}
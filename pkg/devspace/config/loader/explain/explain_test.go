@@ -0,0 +1,54 @@
+package explain
+
+import (
+	"reflect"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type splitPathTestCase struct {
+	name string
+	path string
+
+	expectedSegments []string
+	expectedPath     string
+}
+
+func TestSplitPath(t *testing.T) {
+	testCases := []splitPathTestCase{
+		{
+			name: "Empty path",
+		},
+		{
+			name:             "Simple path",
+			path:             "deployments.api.helm.values.replicas",
+			expectedSegments: []string{"deployments", "api", "helm", "values", "replicas"},
+			expectedPath:     "deployments.api.helm.values.replicas",
+		},
+		{
+			name:             "Quoted segment",
+			path:             `deployments.api.helm.values.labels."app.kubernetes.io/name"`,
+			expectedSegments: []string{"deployments", "api", "helm", "values", "labels", "app.kubernetes.io/name"},
+			expectedPath:     `deployments.api.helm.values.labels."app.kubernetes.io/name"`,
+		},
+		{
+			name:             "Escaped dot",
+			path:             `images.backend\.api.image`,
+			expectedSegments: []string{"images", "backend.api", "image"},
+			expectedPath:     `images."backend.api".image`,
+		},
+		{
+			name:             "Escaped quote",
+			path:             `vars."a\".b"`,
+			expectedSegments: []string{"vars", `a".b`},
+			expectedPath:     `vars."a\".b"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		segments := SplitPath(testCase.path)
+		assert.Assert(t, reflect.DeepEqual(segments, testCase.expectedSegments), "Unexpected segments in %s: %#v", testCase.name, segments)
+		assert.Equal(t, JoinPath(segments...), testCase.expectedPath, "Unexpected path in "+testCase.name)
+	}
+}
@@ -0,0 +1,199 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+)
+
+// FragmentsDir is the directory next to the devspace.yaml whose yaml files are merged into the config
+const FragmentsDir = "devspace.d"
+
+// FragmentSections are the config sections a fragment can define
+var FragmentSections = []string{
+	"vars",
+	"images",
+	"deployments",
+	"dev",
+	"pipelines",
+	"functions",
+	"commands",
+	"pullSecrets",
+	"dependencies",
+	"hooks",
+	"profiles",
+}
+
+// Fragment is a config file in the devspace.d directory
+type Fragment struct {
+	// Path is the absolute path of the fragment
+	Path string
+
+	// Data is the content of the fragment
+	Data map[string]interface{}
+}
+
+// LoadFragments loads the fragments next to the given config in the lexical order of their file names
+func LoadFragments(configPath string) ([]*Fragment, error) {
+	dir := filepath.Join(filepath.Dir(configPath), FragmentsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "read fragments")
+	}
+
+	fragments := []*Fragment{}
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		if entry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		fileContent, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "read fragment")
+		}
+
+		data := map[string]interface{}{}
+		err = yamlutil.Unmarshal(fileContent, &data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse fragment %s", path)
+		}
+
+		for key := range data {
+			if key != "version" && !contains(FragmentSections, key) {
+				return nil, fmt.Errorf("section %s is not supported in fragment %s, please use one of %s", key, path, strings.Join(FragmentSections, ", "))
+			}
+		}
+
+		fragments = append(fragments, &Fragment{
+			Path: path,
+			Data: data,
+		})
+	}
+
+	return fragments, nil
+}
+
+// ResolveFragments merges the fragments next to the config into the raw config. Object sections are
+// merged like profile merges, list sections are appended. A fragment cannot redefine an entry that is
+// already defined by the config or another fragment.
+func ResolveFragments(ctx context.Context, configPath string, rawData map[string]interface{}) (map[string]interface{}, error) {
+	fragments, err := LoadFragments(configPath)
+	if err != nil {
+		return nil, err
+	} else if len(fragments) == 0 {
+		return rawData, nil
+	}
+
+	mergedMap := map[string]interface{}{}
+	err = util.Convert(rawData, &mergedMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// origins holds the fragment that defined an entry, entries without origin are defined by the config
+	origins := map[string]string{}
+	origin := func(path string) string {
+		if origins[path] != "" {
+			return origins[path]
+		}
+
+		return configPath
+	}
+
+	version, _ := rawData["version"].(string)
+	trace := explain.FromContext(ctx)
+	for _, fragment := range fragments {
+		configVersion, ok := fragment.Data["version"].(string)
+		if ok && configVersion != version {
+			return nil, fmt.Errorf("fragment mismatch %s != %s. Fragment %s has different version than currently used devspace.yaml, please make sure the versions match or remove the version from the fragment", version, configVersion, fragment.Path)
+		}
+
+		before := trace.Snapshot(mergedMap)
+		for _, section := range FragmentSections {
+			switch value := fragment.Data[section].(type) {
+			case nil:
+				continue
+			case map[string]interface{}:
+				if mergedMap[section] != nil {
+					existing, ok := mergedMap[section].(map[string]interface{})
+					if !ok {
+						return nil, fmt.Errorf("%s is not an object", section)
+					}
+
+					for key := range value {
+						if _, ok := existing[key]; ok {
+							return nil, fmt.Errorf("%s.%s is defined in both %s and %s", section, key, origin(section+"."+key), fragment.Path)
+						}
+					}
+				}
+				for key := range value {
+					origins[section+"."+key] = fragment.Path
+				}
+
+				mergedMap, err = applyMerge(mergedMap, section, value)
+				if err != nil {
+					return nil, errors.Wrapf(err, "merge fragment %s", fragment.Path)
+				}
+			case []interface{}:
+				existing, ok := mergedMap[section].([]interface{})
+				if mergedMap[section] != nil && !ok {
+					return nil, fmt.Errorf("%s is not a list", section)
+				}
+
+				// profiles are identified by their name
+				if section == "profiles" {
+					for _, profile := range value {
+						name := profileName(profile)
+						if name == "" {
+							continue
+						}
+
+						for _, existingProfile := range existing {
+							if profileName(existingProfile) == name {
+								return nil, fmt.Errorf("profiles.%s is defined in both %s and %s", name, origin("profiles."+name), fragment.Path)
+							}
+						}
+						origins["profiles."+name] = fragment.Path
+					}
+				}
+
+				mergedMap[section] = append(existing, value...)
+			default:
+				return nil, fmt.Errorf("%s in fragment %s is neither an object nor a list", section, fragment.Path)
+			}
+		}
+
+		if trace != nil {
+			err = trace.AddFile(fragment.Path)
+			if err != nil {
+				return nil, err
+			}
+			trace.Record(explain.Contribution{Step: explain.StepFragment}, before, mergedMap, trace.FileLocator(fragment.Path))
+		}
+	}
+
+	return mergedMap, nil
+}
+
+func profileName(profile interface{}) string {
+	profileMap, ok := profile.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	name, _ := profileMap["name"].(string)
+	return name
+}
@@ -0,0 +1,114 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+type fragmentsTestCase struct {
+	name      string
+	fragments map[string]string
+
+	expectedErr string
+}
+
+func TestFragments(t *testing.T) {
+	content := `version: v2beta1
+name: test
+vars:
+  REPLICAS: "2"
+images:
+  api:
+    image: acme/api
+`
+
+	testCases := []fragmentsTestCase{
+		{
+			name: "Conflicting fragments",
+			fragments: map[string]string{
+				"a.yaml": "images:\n  worker:\n    image: acme/a\n",
+				"b.yaml": "images:\n  worker:\n    image: acme/b\n",
+			},
+			expectedErr: "images.worker is defined in both",
+		},
+		{
+			name: "Fragment conflicts with config",
+			fragments: map[string]string{
+				"a.yaml": "images:\n  api:\n    image: acme/a\n",
+			},
+			expectedErr: "images.api is defined in both",
+		},
+		{
+			name: "Unsupported section",
+			fragments: map[string]string{
+				"a.yaml": "name: other\n",
+			},
+			expectedErr: "section name is not supported in fragment",
+		},
+		{
+			name: "Version mismatch",
+			fragments: map[string]string{
+				"a.yaml": "version: v1beta11\n",
+			},
+			expectedErr: "fragment mismatch v2beta1 != v1beta11",
+		},
+	}
+
+	for _, testCase := range testCases {
+		dir := t.TempDir()
+		assert.NilError(t, os.MkdirAll(filepath.Join(dir, FragmentsDir), 0755))
+		for name, fragment := range testCase.fragments {
+			assert.NilError(t, os.WriteFile(filepath.Join(dir, FragmentsDir, name), []byte(fragment), 0666))
+		}
+
+		_, err := loadTestConfig(dir, content)
+		assert.ErrorContains(t, err, testCase.expectedErr, "Unexpected error in test case %s", testCase.name)
+	}
+
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, FragmentsDir), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, FragmentsDir, "10-worker.yaml"), []byte(`images:
+  worker:
+    image: acme/worker
+deployments:
+  worker:
+    helm:
+      values:
+        replicas: ${REPLICAS}
+`), 0666))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, FragmentsDir, "20-profiles.yml"), []byte(`version: v2beta1
+profiles:
+- name: prod
+  merge:
+    images:
+      worker:
+        image: acme/worker-prod
+`), 0666))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, FragmentsDir, "README.md"), []byte("not a fragment"), 0666))
+
+	c, err := loadTestConfig(dir, content)
+	assert.NilError(t, err)
+	assert.Equal(t, c.Config().Images["api"].Image, "acme/api")
+	assert.Equal(t, c.Config().Images["worker"].Image, "acme/worker")
+	assert.Equal(t, c.Config().Deployments["worker"].Helm.Values["replicas"], "${REPLICAS}")
+
+	// profiles of fragments can be activated
+	loader := &configLoader{
+		absConfigPath: filepath.Join(dir, "devspace.yaml"),
+	}
+	c, err = loader.LoadWithParser(context.Background(), localcache.New(filepath.Join(dir, ".devspace", "cache.yaml")), nil, NewDefaultParser(), &ConfigOptions{Dry: true, Profiles: []string{"prod"}}, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, c.Config().Images["worker"].Image, "acme/worker-prod")
+
+	fragments, err := LoadFragments(filepath.Join(dir, "devspace.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(fragments), 2)
+	assert.Equal(t, filepath.Base(fragments[0].Path), "10-worker.yaml")
+	assert.Equal(t, filepath.Base(fragments[1].Path), "20-profiles.yml")
+}
@@ -6,8 +6,10 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
 	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
 	"github.com/loft-sh/devspace/pkg/util/log"
@@ -65,6 +67,8 @@ func ResolveImports(ctx context.Context, resolver variable.Resolver, basePath st
 		return nil, err
 	}
 
+	trace := explain.FromContext(ctx)
+
 	// load imports
 	for _, i := range imports.Imports {
 		if i.Enabled != nil && !*i.Enabled {
@@ -95,6 +99,7 @@ func ResolveImports(ctx context.Context, resolver variable.Resolver, basePath st
 		}
 
 		// merge sections
+		before := trace.Snapshot(mergedMap)
 		for _, section := range ImportSections {
 			sectionMap, ok := importData[section].(map[string]interface{})
 			if !ok {
@@ -127,6 +132,14 @@ func ResolveImports(ctx context.Context, resolver variable.Resolver, basePath st
 			}
 		}
 
+		if trace != nil {
+			err = trace.AddFile(configPath)
+			if err != nil {
+				return nil, err
+			}
+			trace.Record(explain.Contribution{Step: explain.StepImport, Import: importName(&i.SourceConfig)}, before, mergedMap, trace.FileLocator(configPath))
+		}
+
 		// resolve the import imports
 		if importData["imports"] != nil {
 			mergedMap["imports"] = importData["imports"]
@@ -143,3 +156,14 @@ func ResolveImports(ctx context.Context, resolver variable.Resolver, basePath st
 
 	return mergedMap, nil
 }
+
+func importName(source *latest.SourceConfig) string {
+	if source.Git != "" {
+		if source.SubPath != "" {
+			return source.Git + "/" + source.SubPath
+		}
+		return source.Git
+	}
+
+	return source.Path
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/teamcache"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/utils/pkg/command"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
 	"github.com/mitchellh/go-homedir"
@@ -34,6 +37,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/vars"
 )
@@ -184,6 +188,11 @@ func (l *configLoader) LoadWithParser(ctx context.Context, localCache localcache
 	}
 
 	c := config.NewConfig(data, rawBeforeConversion, parsedConfig, localCache, remoteCache, resolver.ResolvedVariables(), l.absConfigPath)
+	for name, module := range parsedConfig.Modules {
+		for output, value := range module.Outputs {
+			c.SetRuntimeVariable("modules."+name+"."+output, value)
+		}
+	}
 	pluginErr = plugin.ExecutePluginHookWithContext(map[string]interface{}{
 		"LOAD_PATH":     l.absConfigPath,
 		"LOADED_CONFIG": c.Config(),
@@ -303,8 +312,14 @@ func (l *configLoader) parseConfig(
 	options *ConfigOptions,
 	log log.Logger,
 ) (*latest.Config, map[string]interface{}, variable.Resolver, error) {
+	// load the shared variables of the team
+	teamCache, err := l.loadTeamCache(ctx, rawConfig, client, log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// create a new variable resolver
-	resolver, err := variable.NewResolver(localCache, &variable.PredefinedVariableOptions{
+	resolver, err := variable.NewResolver(localCache, teamCache, &variable.PredefinedVariableOptions{
 		ConfigPath: l.absConfigPath,
 		KubeClient: client,
 		Profile:    options.Profiles,
@@ -313,6 +328,22 @@ func (l *configLoader) parseConfig(
 		return nil, nil, nil, err
 	}
 
+	// record the values of the config file if the config should be explained
+	trace := explain.FromContext(ctx)
+	if trace != nil {
+		err = trace.AddFile(l.absConfigPath)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		trace.Record(explain.Contribution{Step: explain.StepFile}, nil, rawConfig, trace.FileLocator(l.absConfigPath))
+	}
+
+	// merge the fragments of the devspace.d directory
+	rawConfig, err = ResolveFragments(ctx, l.absConfigPath, rawConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// copy raw config
 	copiedRawConfig, err := ResolveImports(ctx, resolver, filepath.Dir(l.absConfigPath), rawConfig, log)
 	if err != nil {
@@ -322,6 +353,12 @@ func (l *configLoader) parseConfig(
 	// Delete imports from config
 	delete(copiedRawConfig, "imports")
 
+	// resolve modules
+	copiedRawConfig, err = ResolveModules(ctx, resolver, filepath.Dir(l.absConfigPath), copiedRawConfig, log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// prepare profiles
 	copiedRawConfig, err = prepareProfiles(ctx, copiedRawConfig, resolver)
 	if err != nil {
@@ -344,10 +381,19 @@ func (l *configLoader) parseConfig(
 	delete(copiedRawConfig, "vars")
 
 	// parse the config
+	beforeVariables := trace.Snapshot(copiedRawConfig)
 	latestConfig, rawBeforeConversion, err := parser.Parse(ctx, rawConfig, copiedRawConfig, resolver, log)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if trace != nil {
+		parsedConfig := map[string]interface{}{}
+		err = util.Convert(latestConfig, &parsedConfig)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		trace.RecordVariables(beforeVariables, parsedConfig)
+	}
 
 	// check if we do not want to change the generated config or
 	// secret vars.
@@ -364,6 +410,29 @@ func (l *configLoader) parseConfig(
 	return latestConfig, rawBeforeConversion, resolver, nil
 }
 
+// loadTeamCache loads the team variables if the config has a teamVars section and a kube client is available
+func (l *configLoader) loadTeamCache(ctx context.Context, rawConfig map[string]interface{}, client kubectl.Client, log log.Logger) (teamcache.Cache, error) {
+	teamVars, err := teamcache.ParseTeamVars(rawConfig)
+	if err != nil {
+		return nil, err
+	} else if teamVars == nil {
+		return nil, nil
+	} else if client == nil {
+		log.Debugf("Skip loading team vars from namespace %s, because there is no kube client", teamVars.Namespace)
+		return nil, nil
+	}
+
+	// an unreachable cluster should never break loading the config
+	name, _ := values.NameFrom(ctx)
+	teamCache, err := teamcache.Load(ctx, client, name, teamVars)
+	if err != nil {
+		log.Warnf("Skip loading team vars: %v", err)
+		return nil, nil
+	}
+
+	return teamCache, nil
+}
+
 func reloadVariables(resolver variable.Resolver, rawConfig map[string]interface{}, log log.Logger) error {
 	// Load defined variables again (might be changed through profiles)
 	loadedVars, err := versions.ParseVariables(rawConfig, log)
@@ -541,20 +610,26 @@ func (l *configLoader) applyProfiles(ctx context.Context, data map[string]interf
 	delete(data, "profiles")
 
 	// Apply profiles
+	trace := explain.FromContext(ctx)
 	for i := len(profiles) - 1; i >= 0; i-- {
 		// Apply replace
+		before := trace.Snapshot(data)
 		err = ApplyReplace(data, profiles[i])
 		if err != nil {
 			return nil, err
 		}
+		trace.Record(explain.Contribution{Step: explain.StepProfileReplace, Profile: profiles[i].Name}, before, data, trace.ProfileLocator(profiles[i].Name, "replace"))
 
 		// Apply merge
+		before = trace.Snapshot(data)
 		data, err = ApplyMerge(data, profiles[i])
 		if err != nil {
 			return nil, err
 		}
+		trace.Record(explain.Contribution{Step: explain.StepProfileMerge, Profile: profiles[i].Name}, before, data, trace.ProfileLocator(profiles[i].Name, "merge"))
 
 		// Apply patches
+		recordPatches(trace, data, profiles[i])
 		data, err = ApplyPatches(data, profiles[i])
 		if err != nil {
 			return nil, err
@@ -564,6 +639,25 @@ func (l *configLoader) applyProfiles(ctx context.Context, data map[string]interf
 	return data, nil
 }
 
+// recordPatches applies the patches of the profile one after another to find out which patch
+// changed which value
+func recordPatches(trace *explain.Trace, data map[string]interface{}, profile *latest.ProfileConfig) {
+	if trace == nil || profile == nil {
+		return
+	}
+
+	before := trace.Snapshot(data)
+	for i := range profile.Patches {
+		patched, err := ApplyPatchesOnObject(data, profile.Patches[:i+1])
+		if err != nil {
+			return
+		}
+
+		trace.Record(explain.Contribution{Step: explain.StepProfilePatch, Profile: profile.Name}, before, patched, trace.ProfileLocator(profile.Name, "patches", strconv.Itoa(i)))
+		before = trace.Snapshot(patched)
+	}
+}
+
 // configExistsInPath checks whether a devspace configuration exists at a certain path
 func configExistsInPath(path string) bool {
 	_, err := os.Stat(path)
@@ -592,19 +686,23 @@ func (l *configLoader) LoadRaw() (map[string]interface{}, error) {
 
 	name, ok := rawMap["name"].(string)
 	if !ok || name == "" {
-		directoryName := filepath.Base(filepath.Dir(l.absConfigPath))
-		if directoryName != "" && len(directoryName) > 2 {
-			name = encoding.Convert(directoryName)
-		} else {
-			name = "devspace"
-		}
-
-		rawMap["name"] = name
+		rawMap["name"] = DefaultName(l.absConfigPath)
 	}
 
 	return rawMap, nil
 }
 
+// DefaultName returns the name of a config that does not specify a name, which is
+// derived from the directory of the config
+func DefaultName(configPath string) string {
+	directoryName := filepath.Base(filepath.Dir(configPath))
+	if directoryName != "" && len(directoryName) > 2 {
+		return encoding.Convert(directoryName)
+	}
+
+	return "devspace"
+}
+
 // Exists checks whether the yaml file for the config exists or the configs.yaml exists
 func (l *configLoader) Exists() bool {
 	return configExistsInPath(ConfigPath(l.absConfigPath))
@@ -13,6 +13,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -246,6 +247,66 @@ func testLoad(testCase *loadTestCase, t *testing.T) {
 	assert.Equal(t, string(configAsYaml), string(expectedAsYaml), "Unexpected config in testCase %s", testCase.name)
 }
 
+func TestLoadExplain(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "devspace.yaml")
+	err := os.WriteFile(configPath, []byte(`version: v2beta1
+name: explain
+vars:
+  REPLICAS: "2"
+deployments:
+  api:
+    helm:
+      values:
+        replicas: ${REPLICAS}
+        image: nginx
+profiles:
+- name: prod
+  merge:
+    deployments:
+      api:
+        helm:
+          values:
+            image: nginx:prod
+  patches:
+  - op: replace
+    path: deployments.api.helm.values.replicas
+    value: 3
+`), 0666)
+	assert.NilError(t, err)
+
+	loader := &configLoader{absConfigPath: configPath}
+	trace := explain.NewTrace()
+	_, err = loader.Load(explain.WithTrace(context.TODO(), trace), nil, &ConfigOptions{Profiles: []string{"prod"}, Dry: true}, log.Discard)
+	assert.NilError(t, err)
+
+	image := trace.Explain("deployments.api.helm.values.image")
+	assert.Equal(t, len(image), 2)
+	assert.Equal(t, image[0].Step, explain.StepFile)
+	assert.Equal(t, image[0].Line, 10)
+	assert.Equal(t, image[1].Step, explain.StepProfileMerge)
+	assert.Equal(t, image[1].Profile, "prod")
+	assert.Equal(t, image[1].Value, "nginx:prod")
+	assert.Equal(t, image[1].Line, 18)
+
+	replicas := trace.Explain("deployments.api.helm.values.replicas")
+	assert.Equal(t, len(replicas), 2)
+	assert.Equal(t, replicas[0].Step, explain.StepFile)
+	assert.Equal(t, replicas[0].Value, "${REPLICAS}")
+	assert.Equal(t, replicas[1].Step, explain.StepProfilePatch)
+	assert.Equal(t, replicas[1].Line, 20)
+
+	trace = explain.NewTrace()
+	_, err = loader.LoadWithParser(explain.WithTrace(context.TODO(), trace), nil, nil, NewEagerParser(), &ConfigOptions{Dry: true}, log.Discard)
+	assert.NilError(t, err)
+
+	replicas = trace.Explain("deployments.api.helm.values.replicas")
+	assert.Equal(t, len(replicas), 2)
+	assert.Equal(t, replicas[1].Step, explain.StepVariable)
+	assert.DeepEqual(t, replicas[1].Variable, []string{"REPLICAS"})
+	assert.Equal(t, replicas[1].Line, 9)
+}
+
 type setDevSpaceRootTestCase struct {
 	name string
 
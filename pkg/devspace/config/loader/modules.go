@@ -0,0 +1,327 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+)
+
+// ModuleSections are the config sections of a module definition that are added with the module
+// name as prefix to the config
+var ModuleSections = []string{
+	"images",
+	"deployments",
+	"dev",
+	"pipelines",
+	"functions",
+	"commands",
+}
+
+var moduleReferenceRegEx = regexp.MustCompile(`\$\{module\.([a-zA-Z0-9_.-]+)\}`)
+
+// ResolveModules loads the module definitions of the config, fills in the module inputs and merges
+// the sections of the modules with the module name as prefix into the config
+func ResolveModules(ctx context.Context, resolver variable.Resolver, basePath string, rawData map[string]interface{}, log log.Logger) (map[string]interface{}, error) {
+	if rawData["modules"] == nil {
+		return rawData, nil
+	}
+
+	rawModules, err := versions.Get(rawData, "modules")
+	if err != nil {
+		return nil, err
+	}
+
+	version, ok := rawModules["version"].(string)
+	if !ok {
+		return nil, errors.Errorf("Version is missing in devspace.yaml")
+	}
+
+	rawModulesInterface, err := resolver.FillVariablesInclude(ctx, rawModules, true, []string{"/modules/**"})
+	if err != nil {
+		return nil, err
+	}
+
+	modulesConfig, err := versions.Parse(rawModulesInterface.(map[string]interface{}), log)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedMap := map[string]interface{}{}
+	err = util.Convert(rawData, &mergedMap)
+	if err != nil {
+		return nil, err
+	}
+
+	rawModulesMap, ok := mergedMap["modules"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("modules is not an object")
+	}
+
+	// names with a dot are reserved for the entries of the modules
+	for _, section := range ModuleSections {
+		sectionMap, _ := mergedMap[section].(map[string]interface{})
+		for key := range sectionMap {
+			if section != "functions" && strings.Contains(key, ".") {
+				return nil, fmt.Errorf("%s.%s: names with a dot are reserved for the entries of modules", section, key)
+			}
+		}
+	}
+
+	trace := explain.FromContext(ctx)
+	names := make([]string, 0, len(modulesConfig.Modules))
+	for name := range modulesConfig.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		module := modulesConfig.Modules[name]
+		if module.Enabled != nil && !*module.Enabled {
+			continue
+		} else if encoding.IsUnsafeName(name) {
+			return nil, fmt.Errorf("modules.%s has to match the following regex: %v", name, encoding.UnsafeNameRegEx.String())
+		} else if module.Path == "" && module.Git == "" {
+			return nil, fmt.Errorf("modules.%s: path or git is required", name)
+		}
+
+		configPath, err := dependencyutil.DownloadDependency(ctx, basePath, &module.SourceConfig, log)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve module %s", name)
+		}
+
+		moduleData, err := loadModule(configPath, version, module)
+		if err != nil {
+			return nil, errors.Wrapf(err, "module %s", name)
+		}
+
+		// merge the sections with the module name as prefix
+		before := trace.Snapshot(mergedMap)
+		for _, section := range ModuleSections {
+			sectionMap, ok := moduleData[section].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if mergedMap[section] == nil {
+				mergedMap[section] = map[string]interface{}{}
+			}
+			mergedSection, ok := mergedMap[section].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an object", section)
+			}
+
+			for key, value := range sectionMap {
+				if _, ok := mergedSection[name+"."+key]; ok {
+					return nil, fmt.Errorf("module %s: %s.%s.%s is already defined", name, section, name, key)
+				}
+
+				mergedSection[name+"."+key] = value
+			}
+		}
+
+		// the outputs are made available as runtime variables after the config is loaded
+		rawModule, ok := rawModulesMap[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("modules.%s is not an object", name)
+		}
+		delete(rawModule, "outputs")
+		if moduleData["outputs"] != nil {
+			rawModule["outputs"] = moduleData["outputs"]
+		}
+
+		if trace != nil {
+			err = trace.AddFile(configPath)
+			if err != nil {
+				return nil, err
+			}
+			trace.Record(explain.Contribution{Step: explain.StepModule, Module: name}, before, mergedMap, moduleLocator(trace, configPath, name))
+		}
+	}
+
+	return mergedMap, nil
+}
+
+// loadModule reads the module definition and fills in the inputs of the module
+func loadModule(configPath string, version string, module *latest.Module) (map[string]interface{}, error) {
+	fileContent, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "read module definition")
+	}
+
+	moduleData := map[string]interface{}{}
+	err = yamlutil.Unmarshal(fileContent, &moduleData)
+	if err != nil {
+		return nil, err
+	}
+
+	configVersion, ok := moduleData["version"].(string)
+	if !ok {
+		return nil, fmt.Errorf("version is missing in module definition %s", configPath)
+	} else if version != configVersion {
+		return nil, fmt.Errorf("module mismatch %s != %s. Module definition %s has different version than currently used devspace.yaml, please make sure the versions match between a module and the devspace.yaml using it", version, configVersion, configPath)
+	}
+
+	for key := range moduleData {
+		if key != "version" && key != "name" && key != "inputs" && key != "outputs" && !contains(ModuleSections, key) {
+			return nil, fmt.Errorf("section %s is not supported in module definition %s, please use one of inputs, outputs, %s", key, configPath, strings.Join(ModuleSections, ", "))
+		}
+	}
+
+	inputs, err := moduleInputs(moduleData, module)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{
+		"name": module.Name,
+	}
+	for name, value := range inputs {
+		values["inputs."+name] = value
+	}
+
+	delete(moduleData, "inputs")
+	filled, err := fillModuleReferences(moduleData, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return filled.(map[string]interface{}), nil
+}
+
+// moduleInputs validates the inputs of the module against the input definitions and
+// returns the values of all defined inputs
+func moduleInputs(moduleData map[string]interface{}, module *latest.Module) (map[string]interface{}, error) {
+	definitions := map[string]*latest.ModuleInput{}
+	if moduleData["inputs"] != nil {
+		err := util.Convert(moduleData["inputs"], &definitions)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse inputs")
+		}
+	}
+
+	for name := range module.Inputs {
+		if _, ok := definitions[name]; !ok {
+			return nil, fmt.Errorf("input %s is not defined by the module", name)
+		}
+	}
+
+	inputs := map[string]interface{}{}
+	for name, definition := range definitions {
+		if definition == nil {
+			definition = &latest.ModuleInput{}
+		}
+
+		value, ok := module.Inputs[name]
+		if !ok || value == nil {
+			if definition.Required {
+				return nil, fmt.Errorf("input %s is required", name)
+			}
+
+			value = definition.Default
+		}
+
+		value, err := variable.ConvertValue(&latest.Variable{
+			Name:   "module.inputs." + name,
+			Type:   definition.Type,
+			Enum:   definition.Enum,
+			Min:    definition.Min,
+			Max:    definition.Max,
+			Schema: definition.Schema,
+		}, value)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs[name] = value
+	}
+
+	return inputs, nil
+}
+
+// fillModuleReferences replaces ${module.name} and ${module.inputs.NAME} in all strings of the
+// module definition. If a string only consists of a single reference, the value keeps its type.
+func fillModuleReferences(data interface{}, values map[string]interface{}) (interface{}, error) {
+	switch t := data.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			filled, err := fillModuleReferences(value, values)
+			if err != nil {
+				return nil, err
+			}
+
+			t[key] = filled
+		}
+	case []interface{}:
+		for i, value := range t {
+			filled, err := fillModuleReferences(value, values)
+			if err != nil {
+				return nil, err
+			}
+
+			t[i] = filled
+		}
+	case string:
+		for _, match := range moduleReferenceRegEx.FindAllStringSubmatch(t, -1) {
+			if _, ok := values[match[1]]; !ok {
+				return nil, fmt.Errorf("unknown reference %s, please use ${module.name} or ${module.inputs.NAME}", match[0])
+			}
+		}
+
+		if match := moduleReferenceRegEx.FindStringSubmatch(t); match != nil && match[0] == t {
+			return values[match[1]], nil
+		}
+
+		return moduleReferenceRegEx.ReplaceAllStringFunc(t, func(s string) string {
+			value := values[moduleReferenceRegEx.FindStringSubmatch(s)[1]]
+			if value == nil {
+				return ""
+			}
+
+			return fmt.Sprintf("%v", value)
+		}), nil
+	}
+
+	return data, nil
+}
+
+// moduleLocator locates the prefixed config paths of a module in the module definition. The
+// entries of a module are named <module>.<key>, so the module name is stripped from the entry
+// segment (e.g. images."orders.api".image) or, if the path was split on every dot, the module
+// name segment is removed (e.g. images.orders.api.image).
+func moduleLocator(trace *explain.Trace, configPath, name string) explain.Locator {
+	locator := trace.FileLocator(configPath)
+	return func(path []string) (string, int) {
+		if len(path) > 1 && contains(ModuleSections, path[0]) {
+			if strings.HasPrefix(path[1], name+".") {
+				path = append([]string{path[0], strings.TrimPrefix(path[1], name+".")}, path[2:]...)
+			} else if path[1] == name && len(path) > 2 {
+				path = append([]string{path[0]}, path[2:]...)
+			}
+		}
+
+		return locator(path)
+	}
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,170 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+const testModuleDefinition = `version: v2beta1
+inputs:
+  registry:
+    required: true
+  port:
+    type: int
+    default: 8080
+outputs:
+  image: ${module.name}.api
+  port: ${module.inputs.port}
+images:
+  api:
+    image: ${module.inputs.registry}/${module.name}
+deployments:
+  api:
+    helm:
+      values:
+        port: ${module.inputs.port}
+pipelines:
+  deploy:
+    run: create_deployments ${module.name}.api
+`
+
+type modulesTestCase struct {
+	name   string
+	config string
+
+	expectedErr string
+}
+
+func TestModules(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "modules", "service"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "modules", "service", "devspace.yaml"), []byte(testModuleDefinition), 0666))
+
+	content := `version: v2beta1
+name: test
+vars:
+  REGISTRY: ghcr.io/acme
+modules:
+  orders:
+    path: ./modules/service
+    inputs:
+      registry: ${REGISTRY}
+      port: "9090"
+  billing:
+    path: ./modules/service
+    inputs:
+      registry: ghcr.io/other
+`
+	c, err := loadTestConfig(dir, content)
+	assert.NilError(t, err)
+
+	images := c.Config().Images
+	assert.Equal(t, len(images), 2)
+	assert.Equal(t, images["orders.api"].Image, "ghcr.io/acme/orders")
+	assert.Equal(t, images["billing.api"].Image, "ghcr.io/other/billing")
+	assert.DeepEqual(t, c.Config().Deployments["orders.api"].Helm.Values, map[string]interface{}{"port": 9090})
+	assert.DeepEqual(t, c.Config().Deployments["billing.api"].Helm.Values, map[string]interface{}{"port": 8080})
+	assert.Equal(t, c.Config().Pipelines["orders.deploy"].Run, "create_deployments orders.api")
+
+	// the contributions of a module point to the module definition
+	trace := explain.NewTrace()
+	_, err = loadTestConfigWithContext(explain.WithTrace(context.Background(), trace), dir, content)
+	assert.NilError(t, err)
+	contributions := trace.Explain(`images."orders.api".image`)
+	assert.Assert(t, len(contributions) > 0)
+	contribution := contributions[len(contributions)-1]
+	assert.Equal(t, contribution.Step, explain.StepModule)
+	assert.Equal(t, contribution.File, filepath.Join(dir, "modules", "service", "devspace.yaml"))
+	assert.Equal(t, contribution.Line, 13)
+
+	output, ok := c.GetRuntimeVariable("modules.orders.image")
+	assert.Assert(t, ok)
+	assert.Equal(t, output, "orders.api")
+	output, ok = c.GetRuntimeVariable("modules.billing.port")
+	assert.Assert(t, ok)
+	assert.Equal(t, output, 8080)
+
+	testCases := []modulesTestCase{
+		{
+			name: "missing input",
+			config: `version: v2beta1
+name: test
+modules:
+  orders:
+    path: ./modules/service
+`,
+			expectedErr: "module orders: input registry is required",
+		},
+		{
+			name: "unknown input",
+			config: `version: v2beta1
+name: test
+modules:
+  orders:
+    path: ./modules/service
+    inputs:
+      registry: ghcr.io/acme
+      other: test
+`,
+			expectedErr: "module orders: input other is not defined by the module",
+		},
+		{
+			name: "wrong input type",
+			config: `version: v2beta1
+name: test
+modules:
+  orders:
+    path: ./modules/service
+    inputs:
+      registry: ghcr.io/acme
+      port: abc
+`,
+			expectedErr: "module orders: variable ${module.inputs.port}: expected an int, but got 'abc'",
+		},
+		{
+			name: "dotted name that is not from a module",
+			config: `version: v2beta1
+name: test
+modules:
+  orders:
+    path: ./modules/service
+    inputs:
+      registry: ghcr.io/acme
+images:
+  orders.other:
+    image: ghcr.io/acme/other
+`,
+			expectedErr: "images.orders.other: names with a dot are reserved for the entries of modules",
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := loadTestConfig(dir, testCase.config)
+		assert.Error(t, err, testCase.expectedErr, testCase.name)
+	}
+}
+
+func loadTestConfig(dir, content string) (config.Config, error) {
+	return loadTestConfigWithContext(context.Background(), dir, content)
+}
+
+func loadTestConfigWithContext(ctx context.Context, dir, content string) (config.Config, error) {
+	configPath := filepath.Join(dir, "devspace.yaml")
+	err := os.WriteFile(configPath, []byte(content), 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := &configLoader{
+		absConfigPath: configPath,
+	}
+	return loader.LoadWithParser(ctx, localcache.New(filepath.Join(dir, ".devspace", "cache.yaml")), nil, NewDefaultParser(), &ConfigOptions{Dry: true}, log.Discard)
+}
@@ -116,6 +116,28 @@ func (e *eagerParser) Parse(ctx context.Context, originalRawConfig map[string]in
 	return fillAllVariablesAndParse(ctx, resolver, rawConfig, log)
 }
 
+// NewLintParser creates a parser that fills in variables like the default parser, but does
+// not validate the config, so that all validation errors can be collected afterwards
+func NewLintParser() Parser {
+	return &lintParser{}
+}
+
+type lintParser struct{}
+
+func (l *lintParser) Parse(ctx context.Context, originalRawConfig map[string]interface{}, rawConfig map[string]interface{}, resolver variable.Resolver, log log.Logger) (*latest.Config, map[string]interface{}, error) {
+	preparedConfigInterface, err := resolver.FillVariablesExclude(ctx, rawConfig, false, runtime.Locations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	latestConfig, err := versions.ParseWithoutValidation(preparedConfigInterface.(map[string]interface{}), log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return latestConfig, preparedConfigInterface.(map[string]interface{}), nil
+}
+
 func fillAllVariablesAndParse(ctx context.Context, resolver variable.Resolver, preparedConfig map[string]interface{}, log log.Logger) (*latest.Config, map[string]interface{}, error) {
 	return fillVariablesAndParse(ctx, resolver, preparedConfig, log)
 }
@@ -285,6 +285,27 @@ func ApplyPatchesOnObject(data map[string]interface{}, configPatches []*latest.P
 	return newConfig, nil
 }
 
+// PatchTargetExists checks if the path of the given patch matches anything in the data
+func PatchTargetExists(data map[string]interface{}, patchConfig *latest.PatchConfig) (bool, error) {
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		return false, err
+	}
+
+	path := patch.OpPath(patch.TransformPath(patchConfig.Path))
+	target, err := findPath(&path, &doc)
+	if err != nil {
+		return false, err
+	}
+
+	return target != nil, nil
+}
+
 func findPath(path *patch.OpPath, doc *yaml.Node) (interface{}, error) {
 	pathFinder, err := yamlpath.NewPath(string(*path))
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/teamcache"
 	"github.com/sirupsen/logrus"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -13,24 +14,36 @@ import (
 )
 
 // NewDefaultVariable creates a new variable for the sources default, all or input
-func NewDefaultVariable(name string, workingDirectory string, localCache localcache.Cache, log log.Logger) Variable {
-	return &defaultVariable{
+func NewDefaultVariable(name string, workingDirectory string, localCache localcache.Cache, teamCache teamcache.Cache, log log.Logger) *DefaultVariable {
+	return &DefaultVariable{
 		name:             name,
 		workingDirectory: workingDirectory,
 		localCache:       localCache,
+		teamCache:        teamCache,
 		log:              log,
 	}
 }
 
-type defaultVariable struct {
+// DefaultVariable loads a variable from the environment, the local cache, the team cache, the default
+// value or asks the user
+type DefaultVariable struct {
 	name             string
 	workingDirectory string
 	localCache       localcache.Cache
+	teamCache        teamcache.Cache
 	log              log.Logger
+
+	source Source
+}
+
+// Source returns where the value of the variable was loaded from
+func (d *DefaultVariable) Source() Source {
+	return d.source
 }
 
-func (d *defaultVariable) Load(ctx context.Context, definition *latest.Variable) (interface{}, error) {
+func (d *DefaultVariable) Load(ctx context.Context, definition *latest.Variable) (interface{}, error) {
 	if definition.Command != "" || len(definition.Commands) > 0 {
+		d.source = SourceCommand
 		return NewCommandVariable(d.name, d.workingDirectory).Load(ctx, definition)
 	}
 
@@ -39,18 +52,27 @@ func (d *defaultVariable) Load(ctx context.Context, definition *latest.Variable)
 
 	// Did we find it in the environment variables?
 	if definition.Source != latest.VariableSourceInput && value != "" {
+		d.source = SourceEnv
 		return valueByType(value, definition.Default)
 	}
 
-	// Remote cache takes precedence over local cache
+	// Local cache takes precedence over the team cache
 	if !definition.NoCache {
-		if value, ok := d.localCache.GetVar(d.name); !definition.NoCache && ok {
+		if value, ok := d.localCache.GetVar(d.name); ok {
+			d.source = SourceCache
+			return valueByType(value, definition.Default)
+		}
+	}
+	if d.teamCache != nil {
+		if value, ok := d.teamCache.GetVar(d.name); ok {
+			d.source = SourceTeam
 			return valueByType(value, definition.Default)
 		}
 	}
 
 	// is logger silent
 	if d.log == log.Discard || d.log.GetLevel() < logrus.InfoLevel {
+		d.source = SourceDefault
 		if definition.Default != nil {
 			return definition.Default, nil
 		}
@@ -65,6 +87,7 @@ func (d *defaultVariable) Load(ctx context.Context, definition *latest.Variable)
 	}
 
 	// Now ask the question
+	d.source = SourceInput
 	if !definition.NoCache {
 		d.localCache.SetVar(d.name, value)
 	}
@@ -0,0 +1,141 @@
+package variable
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/teamcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/survey"
+	"github.com/sirupsen/logrus"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestVariablePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	client := &kubectltesting.Client{
+		Client: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "devspace-team-vars-test",
+				Namespace: "team",
+			},
+			Data: map[string][]byte{
+				"FLAG":     []byte("team"),
+				"ENV":      []byte("team"),
+				"CACHE":    []byte("team"),
+				"TEAM":     []byte("team"),
+				"PORT":     []byte("9090"),
+				"NO_CACHE": []byte("team"),
+			},
+		}),
+	}
+	teamCache, err := teamcache.Load(context.TODO(), client, "test", &latest.TeamVars{Namespace: "team"})
+	assert.NilError(t, err)
+
+	localCache := localcache.New(filepath.Join(dir, ".devspace", "cache.yaml"))
+	localCache.SetVar("FLAG", "cache")
+	localCache.SetVar("ENV", "cache")
+	localCache.SetVar("CACHE", "cache")
+	localCache.SetVar("NO_CACHE", "cache")
+	t.Setenv("FLAG", "env")
+	t.Setenv("ENV", "env")
+
+	resolver, err := NewResolver(localCache, teamCache, &PredefinedVariableOptions{
+		ConfigPath: filepath.Join(dir, "devspace.yaml"),
+	}, []string{"FLAG=flag"}, log.Discard)
+	assert.NilError(t, err)
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"FLAG":     {Name: "FLAG", Default: "default"},
+		"ENV":      {Name: "ENV", Default: "default"},
+		"CACHE":    {Name: "CACHE", Default: "default"},
+		"TEAM":     {Name: "TEAM", Default: "default"},
+		"PORT":     {Name: "PORT", Default: 8080},
+		"DEFAULT":  {Name: "DEFAULT", Default: "default"},
+		"NO_CACHE": {Name: "NO_CACHE", Default: "default", NoCache: true},
+	})
+
+	_, err = resolver.FillVariables(context.TODO(), map[string]interface{}{}, false)
+	assert.NilError(t, err)
+
+	expected := map[string]struct {
+		value  interface{}
+		source Source
+	}{
+		"FLAG":     {"flag", SourceFlag},
+		"ENV":      {"env", SourceEnv},
+		"CACHE":    {"cache", SourceCache},
+		"TEAM":     {"team", SourceTeam},
+		"PORT":     {9090, SourceTeam},
+		"DEFAULT":  {"default", SourceDefault},
+		"NO_CACHE": {"team", SourceTeam},
+	}
+	for name, e := range expected {
+		assert.Equal(t, resolver.ResolvedVariables()[name], e.value, "value of %s", name)
+		assert.Equal(t, resolver.Sources()[name], e.source, "source of %s", name)
+	}
+}
+
+func TestNonInteractiveVariables(t *testing.T) {
+	survey.SetNonInteractive(true)
+	defer survey.SetNonInteractive(false)
+
+	dir := t.TempDir()
+	t.Setenv("FROM_ENV", "env")
+	resolver, err := NewResolver(localcache.New(filepath.Join(dir, ".devspace", "cache.yaml")), nil, &PredefinedVariableOptions{
+		ConfigPath: filepath.Join(dir, "devspace.yaml"),
+	}, []string{"FROM_FLAG=flag"}, log.NewStreamLogger(io.Discard, io.Discard, logrus.InfoLevel))
+	assert.NilError(t, err)
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"FROM_FLAG":    {Name: "FROM_FLAG"},
+		"FROM_ENV":     {Name: "FROM_ENV"},
+		"WITH_DEFAULT": {Name: "WITH_DEFAULT", Default: "default"},
+		"MISSING":      {Name: "MISSING"},
+		"SECRET":       {Name: "SECRET", Source: latest.VariableSourceInput, Question: "Enter the secret"},
+	})
+
+	_, err = resolver.FillVariables(context.TODO(), "${FROM_FLAG} ${FROM_ENV} ${WITH_DEFAULT} ${MISSING} ${SECRET}", true)
+	assert.Error(t, err, `cannot ask the following questions in non-interactive mode:
+  - Enter the secret (use --var SECRET=VALUE)
+  - Please enter a value for MISSING (use --var MISSING=VALUE or the MISSING environment variable)`)
+
+	t.Setenv("MISSING", "env")
+	_, err = resolver.FillVariables(context.TODO(), "${MISSING} ${SECRET}", true)
+	assert.Error(t, err, "cannot ask question 'Enter the secret' in non-interactive mode, please use --var SECRET=VALUE")
+
+	resolver, err = NewResolver(localcache.New(filepath.Join(dir, ".devspace", "cache.yaml")), nil, &PredefinedVariableOptions{
+		ConfigPath: filepath.Join(dir, "devspace.yaml"),
+	}, []string{"SECRET=secret"}, log.NewStreamLogger(io.Discard, io.Discard, logrus.InfoLevel))
+	assert.NilError(t, err)
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"WITH_DEFAULT": {Name: "WITH_DEFAULT", Default: "default"},
+		"SECRET":       {Name: "SECRET", Source: latest.VariableSourceInput},
+	})
+	value, err := resolver.FillVariables(context.TODO(), "${WITH_DEFAULT} ${SECRET}", true)
+	assert.NilError(t, err)
+	assert.Equal(t, value, "default secret")
+
+	// without the non-interactive mode, questions fail because there is no terminal
+	survey.SetNonInteractive(false)
+	resolver, err = NewResolver(localcache.New(filepath.Join(dir, ".devspace", "cache.yaml")), nil, &PredefinedVariableOptions{
+		ConfigPath: filepath.Join(dir, "devspace.yaml"),
+	}, nil, log.NewStreamLogger(io.Discard, io.Discard, logrus.InfoLevel))
+	assert.NilError(t, err)
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"SECRET": {Name: "SECRET", Source: latest.VariableSourceInput, Question: "Enter the secret"},
+		"TOKEN":  {Name: "TOKEN", Source: latest.VariableSourceInput, Question: "Enter the token"},
+	})
+	_, err = resolver.FillVariables(context.TODO(), "${SECRET}", true)
+	assert.Error(t, err, "cannot ask question 'Enter the secret' because currently you're not using devspace in a terminal and default value is also not provided")
+	_, err = resolver.FillVariables(context.TODO(), "${SECRET} ${TOKEN}", true)
+	assert.Error(t, err, `cannot ask the following questions because currently you're not using devspace in a terminal and default values are also not provided:
+  - Enter the secret (use --var SECRET=VALUE)
+  - Enter the token (use --var TOKEN=VALUE)`)
+}
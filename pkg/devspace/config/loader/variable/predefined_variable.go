@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -147,6 +148,17 @@ func IsPredefinedVariable(name string) bool {
 	return ok
 }
 
+// PredefinedVariableNames returns the sorted names of all predefined variables
+func PredefinedVariableNames() []string {
+	names := make([]string, 0, len(predefinedVars))
+	for name := range predefinedVars {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
 func AddPredefinedVars(plugins []plugin.Metadata) {
 	for _, p := range plugins {
 		pluginName := p.Name
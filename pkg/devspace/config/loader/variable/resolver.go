@@ -6,48 +6,71 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/secret"
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/teamcache"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/graph"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl/walk"
 	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/survey"
 	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
 	"github.com/pkg/errors"
 )
 
 var AlwaysResolvePredefinedVars = []string{"DEVSPACE_NAME", "DEVSPACE_EXECUTABLE", "DEVSPACE_KUBECTL_EXECUTABLE", "DEVSPACE_TMPDIR", "DEVSPACE_VERSION", "DEVSPACE_RANDOM", "DEVSPACE_PROFILE", "DEVSPACE_PROFILES", "DEVSPACE_USER_HOME", "DEVSPACE_TIMESTAMP", "devspace.context", "DEVSPACE_CONTEXT", "devspace.namespace", "DEVSPACE_NAMESPACE", "DEVSPACE_SPACE"}
 
-// NewResolver creates a new resolver that caches resolved variables in memory and in the provided cache
-func NewResolver(localCache localcache.Cache, predefinedVariableOptions *PredefinedVariableOptions, flags []string, log log.Logger) (Resolver, error) {
+// NewResolver creates a new resolver that caches resolved variables in memory and in the provided cache. If
+// a team cache is provided, its values are used for variables that are not found in the local cache.
+func NewResolver(localCache localcache.Cache, teamCache teamcache.Cache, predefinedVariableOptions *PredefinedVariableOptions, flags []string, log log.Logger) (Resolver, error) {
 	memoryCache := map[string]interface{}{}
 	err := MergeVarsWithFlags(memoryCache, flags)
 	if err != nil {
 		return nil, err
 	}
 
+	sources := map[string]Source{}
+	for name := range memoryCache {
+		sources[name] = SourceFlag
+	}
+
 	return &resolver{
 		memoryCache: memoryCache,
+		sources:     sources,
 		localCache:  localCache,
+		teamCache:   teamCache,
 		options:     predefinedVariableOptions,
 		log:         log,
 	}, nil
 }
 
 func MergeVarsWithFlags(vars map[string]interface{}, flags []string) error {
+	lastName := ""
+	rawValues := map[string]string{}
 	for _, cmdVar := range flags {
 		idx := strings.Index(cmdVar, "=")
 		if idx == -1 {
-			return errors.Errorf("wrong --var format: %s, expected 'key=val'", cmdVar)
+			// the --var flag splits values at commas, so values without a key belong to the previous
+			// variable (e.g. --var LIST=a,b)
+			if lastName == "" {
+				return errors.Errorf("wrong --var format: %s, expected 'key=val'", cmdVar)
+			}
+
+			rawValues[lastName] += "," + cmdVar
+			vars[lastName] = convertStringValue(rawValues[lastName])
+			continue
 		}
 
 		name := strings.TrimSpace(cmdVar[:idx])
-		value := convertStringValue(strings.TrimSpace(cmdVar[idx+1:]))
-		vars[name] = value
+		rawValues[name] = strings.TrimSpace(cmdVar[idx+1:])
+		vars[name] = convertStringValue(rawValues[name])
+		lastName = name
 	}
 
 	return nil
@@ -56,8 +79,10 @@ func MergeVarsWithFlags(vars map[string]interface{}, flags []string) error {
 type resolver struct {
 	vars        map[string]*latest.Variable
 	memoryCache map[string]interface{}
+	sources     map[string]Source
 
 	localCache localcache.Cache
+	teamCache  teamcache.Cache
 	options    *PredefinedVariableOptions
 	log        log.Logger
 }
@@ -106,6 +131,10 @@ func (r *resolver) ResolvedVariables() map[string]interface{} {
 	return r.memoryCache
 }
 
+func (r *resolver) Sources() map[string]Source {
+	return r.sources
+}
+
 func (r *resolver) replaceString(ctx context.Context, str string) (interface{}, error) {
 	return varspkg.ParseString(str, func(v string) (interface{}, error) {
 		val, err := r.resolve(ctx, v, nil)
@@ -340,13 +369,25 @@ func (r *resolver) findAndFillVariables(ctx context.Context, haystack interface{
 		}
 	}
 
-	// resolve used defined variables
+	// resolve used defined variables and collect all questions that
+	// cannot be asked, so that they are reported at once
+	missingInputs := &survey.MissingInputError{}
 	for _, v := range varsUsed {
 		_, err := r.resolve(ctx, v.Name, v)
 		if err != nil {
+			if missingInputs.Add(err) {
+				continue
+			}
+
 			return nil, err
 		}
 	}
+	if len(missingInputs.Inputs) > 0 {
+		sort.Slice(missingInputs.Inputs, func(i, j int) bool {
+			return missingInputs.Inputs[i].Question < missingInputs.Inputs[j].Question
+		})
+		return nil, missingInputs
+	}
 
 	return r.fillVariables(ctx, haystack, exclude, include)
 }
@@ -357,6 +398,17 @@ func (r *resolver) resolve(ctx context.Context, name string, definition *latest.
 	// check if in vars already
 	v, ok := r.memoryCache[name]
 	if ok {
+		// values from --var flags are in the cache from the beginning, so make sure they have the correct type
+		if IsTyped(definition) {
+			v, err := ConvertValue(definition, v)
+			if err != nil {
+				return nil, err
+			}
+
+			r.memoryCache[name] = v
+			return v, nil
+		}
+
 		return v, nil
 	}
 
@@ -369,6 +421,7 @@ func (r *resolver) resolve(ctx context.Context, name string, definition *latest.
 		}
 
 		r.memoryCache[name] = value
+		r.sources[name] = SourcePredefined
 		return value, nil
 	}
 
@@ -384,13 +437,20 @@ func (r *resolver) resolve(ctx context.Context, name string, definition *latest.
 	}
 
 	// fill the variable if not found
-	value, err := r.fillVariable(ctx, name, definition)
+	value, source, err := r.fillVariable(ctx, name, definition)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert the value to the variable type
+	value, err = ConvertValue(definition, value)
 	if err != nil {
 		return nil, err
 	}
 
 	// set variable so that we don't ask again
 	r.memoryCache[name] = value
+	r.sources[name] = source
 	return value, nil
 }
 
@@ -555,31 +615,47 @@ func (r *resolver) resolveDefaultValue(ctx context.Context, definition *latest.V
 	return r.resolveDefinitionString(ctx, defaultString, definition)
 }
 
-func (r *resolver) fillVariable(ctx context.Context, name string, definition *latest.Variable) (interface{}, error) {
+func (r *resolver) fillVariable(ctx context.Context, name string, definition *latest.Variable) (interface{}, Source, error) {
 	// is runtime variable
 	if strings.HasPrefix(name, "runtime.") {
-		return nil, fmt.Errorf("cannot resolve %s in this config area as this config region is loaded on startup. You can only use runtime variables in the following locations: \n  %s", name, strings.Join(runtime.Locations, "\n  "))
+		return nil, "", fmt.Errorf("cannot resolve %s in this config area as this config region is loaded on startup. You can only use runtime variables in the following locations: \n  %s", name, strings.Join(runtime.Locations, "\n  "))
 	}
 
 	// fill variable without definition
 	if definition == nil {
-		return NewUndefinedVariable(name, r.localCache, r.log).Load(ctx, definition)
+		variable := NewUndefinedVariable(name, r.localCache, r.teamCache, r.log)
+		value, err := variable.Load(ctx, definition)
+		return value, variable.Source(), err
 	}
 
 	// trim space from variable definition
 	definition.Name = strings.TrimSpace(definition.Name)
 
 	// fill variable by source
+	if definition.Secret != nil && definition.Source == latest.VariableSourceDefault {
+		definition.Source = latest.VariableSourceSecret
+	}
 	switch definition.Source {
 	case latest.VariableSourceEnv:
-		return NewEnvVariable(name).Load(ctx, definition)
+		value, err := NewEnvVariable(name).Load(ctx, definition)
+		return value, SourceEnv, err
 	case latest.VariableSourceDefault, latest.VariableSourceInput, latest.VariableSourceAll:
-		return NewDefaultVariable(name, filepath.Dir(r.options.ConfigPath), r.localCache, r.log).Load(ctx, definition)
+		variable := NewDefaultVariable(name, filepath.Dir(r.options.ConfigPath), r.localCache, r.teamCache, r.log)
+		value, err := variable.Load(ctx, definition)
+		return value, variable.Source(), err
 	case latest.VariableSourceNone:
-		return NewNoneVariable(name).Load(ctx, definition)
+		value, err := NewNoneVariable(name).Load(ctx, definition)
+		return value, SourceValue, err
 	case latest.VariableSourceCommand:
-		return NewCommandVariable(name, filepath.Dir(r.options.ConfigPath)).Load(ctx, definition)
+		value, err := NewCommandVariable(name, filepath.Dir(r.options.ConfigPath)).Load(ctx, definition)
+		return value, SourceCommand, err
+	case latest.VariableSourceSecret:
+		value, err := NewSecretVariable(name, &secret.Options{
+			WorkingDir: filepath.Dir(r.options.ConfigPath),
+			KubeClient: r.options.KubeClient,
+		}, r.log).Load(ctx, definition)
+		return value, SourceSecret, err
 	default:
-		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env' or 'none'", name)
+		return nil, "", errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env', 'command', 'secret' or 'none'", name)
 	}
 }
@@ -41,6 +41,17 @@ var Locations = []string{
 	"/imports/**",
 }
 
+// PipelineOutputKey returns the runtime variable an output of a pipeline is saved in
+func PipelineOutputKey(pipeline, output string) string {
+	return "pipelines." + pipeline + ".outputs." + output
+}
+
+// OutputKey returns the runtime variable that holds the output that was set last by any
+// pipeline of the config. This is used to return outputs of dependencies.
+func OutputKey(output string) string {
+	return "outputs." + output
+}
+
 // NewRuntimeVariable creates a new variable that is loaded during runtime
 func NewRuntimeVariable(name string, config config.Config, dependencies []types.Dependency) *runtimeVariable {
 	return &runtimeVariable{
@@ -105,12 +116,16 @@ func (e *runtimeVariable) Load() (bool, interface{}, error) {
 		imageName := runtimeVar
 		onlyImage := false
 		onlyTag := false
-		if strings.HasSuffix(runtimeVar, ".tag") {
-			imageName = strings.TrimSuffix(runtimeVar, ".tag")
-			onlyTag = true
-		} else if strings.HasSuffix(runtimeVar, ".image") {
-			imageName = strings.TrimSuffix(runtimeVar, ".image")
-			onlyImage = true
+		// image names of modules contain a dot, e.g. orders.image, so the suffix is only
+		// stripped if there is no image with the full name
+		if c.Config().Images[runtimeVar] == nil {
+			if strings.HasSuffix(runtimeVar, ".tag") {
+				imageName = strings.TrimSuffix(runtimeVar, ".tag")
+				onlyTag = true
+			} else if strings.HasSuffix(runtimeVar, ".image") {
+				imageName = strings.TrimSuffix(runtimeVar, ".image")
+				onlyImage = true
+			}
 		}
 
 		shouldRebuild, image, err := GetImage(c, imageName, onlyImage, onlyTag)
@@ -121,9 +136,36 @@ func (e *runtimeVariable) Load() (bool, interface{}, error) {
 		return shouldRebuild, image, nil
 	}
 
+	// give a hint why a pipeline output is missing
+	if strings.HasPrefix(runtimeVar, "pipelines.") || strings.HasPrefix(runtimeVar, "outputs.") {
+		return false, nil, outputNotFoundError(e.name, runtimeVar, c)
+	}
+
 	return false, nil, fmt.Errorf("couldn't find runtime variable %s", e.name)
 }
 
+func outputNotFoundError(name, runtimeVar string, c config.Config) error {
+	if strings.HasPrefix(runtimeVar, "outputs.") {
+		return fmt.Errorf("couldn't find runtime variable %s, make sure the dependency pipeline ran and called set_output %s", name, strings.TrimPrefix(runtimeVar, "outputs."))
+	}
+
+	splitted := strings.Split(strings.TrimPrefix(runtimeVar, "pipelines."), ".")
+	if len(splitted) != 3 || splitted[1] != "outputs" {
+		return fmt.Errorf("unexpected runtime variable %s, need format runtime.pipelines.NAME.outputs.OUTPUT", name)
+	}
+
+	pipelineName, output := splitted[0], splitted[2]
+	if c.Config() != nil && c.Config().Pipelines[pipelineName] != nil {
+		for _, pipelineOutput := range c.Config().Pipelines[pipelineName].Outputs {
+			if pipelineOutput.Name == output {
+				return fmt.Errorf("couldn't find runtime variable %s, make sure the pipeline %s ran and called set_output %s", name, pipelineName, output)
+			}
+		}
+	}
+
+	return fmt.Errorf("couldn't find runtime variable %s, because pipeline %s has no output %s", name, pipelineName, output)
+}
+
 func GetImage(c config.Config, imageName string, onlyImage, onlyTag bool) (bool, string, error) {
 	// search for image name in cache
 	imageCache, ok := c.LocalCache().GetImageCache(imageName)
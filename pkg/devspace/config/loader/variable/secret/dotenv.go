@@ -0,0 +1,36 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joho/godotenv"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// NewDotEnvProvider creates a new provider that reads values from dotenv files
+func NewDotEnvProvider() Provider {
+	return &dotEnvProvider{}
+}
+
+type dotEnvProvider struct{}
+
+func (d *dotEnvProvider) Get(ctx context.Context, options *Options, secret *latest.VariableSecret, key string) (string, error) {
+	path := secret.Path
+	if path == "" {
+		path = ".env"
+	}
+
+	values, err := godotenv.Read(resolvePath(options, path))
+	if err != nil {
+		return "", errors.Wrapf(err, "read %s", path)
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("couldn't find key %s in %s", key, path)
+	}
+
+	return value, nil
+}
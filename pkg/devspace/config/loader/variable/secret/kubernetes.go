@@ -0,0 +1,48 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewKubernetesProvider creates a new provider that reads keys from Kubernetes secrets
+func NewKubernetesProvider() Provider {
+	return &kubernetesProvider{}
+}
+
+type kubernetesProvider struct{}
+
+func (k *kubernetesProvider) Get(ctx context.Context, options *Options, secret *latest.VariableSecret, key string) (string, error) {
+	if secret.Name == "" {
+		return "", fmt.Errorf("secret.name is required for provider %s", latest.VariableSecretProviderKubernetes)
+	} else if options.KubeClient == nil {
+		return "", fmt.Errorf("cannot read secret %s, because no kube context is available", secret.Name)
+	}
+
+	namespace := secret.Namespace
+	if namespace == "" {
+		namespace = options.KubeClient.Namespace()
+	}
+
+	kubeSecret, err := options.KubeClient.KubeClient().CoreV1().Secrets(namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", fmt.Errorf("couldn't find secret %s in namespace %s", secret.Name, namespace)
+		}
+
+		return "", errors.Wrapf(err, "get secret %s", secret.Name)
+	}
+
+	if value, ok := kubeSecret.Data[key]; ok {
+		return string(value), nil
+	} else if value, ok := kubeSecret.StringData[key]; ok {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("couldn't find key %s in secret %s/%s", key, namespace, secret.Name)
+}
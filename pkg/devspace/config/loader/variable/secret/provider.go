@@ -0,0 +1,71 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+)
+
+// Options are the options passed to a provider to load a secret
+type Options struct {
+	// WorkingDir is the directory of the devspace.yaml, relative paths are resolved against it
+	WorkingDir string
+
+	// KubeClient is the kube client of the current context, can be nil
+	KubeClient kubectl.Client
+}
+
+// Provider loads secret values
+type Provider interface {
+	// Get returns the value of the secret key
+	Get(ctx context.Context, options *Options, secret *latest.VariableSecret, key string) (string, error)
+}
+
+var (
+	providersMutex sync.Mutex
+	providers      = map[latest.VariableSecretProvider]Provider{
+		latest.VariableSecretProviderSOPS:       NewSOPSProvider(),
+		latest.VariableSecretProviderKubernetes: NewKubernetesProvider(),
+		latest.VariableSecretProviderDotEnv:     NewDotEnvProvider(),
+	}
+)
+
+// Register registers a new secret provider or replaces an existing one
+func Register(name latest.VariableSecretProvider, provider Provider) {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+
+	providers[name] = provider
+}
+
+// GetProvider returns the secret provider with the given name
+func GetProvider(name latest.VariableSecretProvider) (Provider, error) {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+
+	provider, ok := providers[name]
+	if !ok {
+		names := []string{}
+		for name := range providers {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown secret provider '%s', please choose one of %s", name, strings.Join(names, ", "))
+	}
+
+	return provider, nil
+}
+
+func resolvePath(options *Options, path string) string {
+	if filepath.IsAbs(path) || options.WorkingDir == "" {
+		return path
+	}
+
+	return filepath.Join(options.WorkingDir, path)
+}
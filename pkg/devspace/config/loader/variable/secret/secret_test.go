@@ -0,0 +1,95 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDotEnvProvider(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "secrets.env"), []byte("API_TOKEN=my-token\n# comment\nOTHER=\"quoted value\"\n"), 0600)
+	assert.NilError(t, err)
+
+	provider, err := GetProvider(latest.VariableSecretProviderDotEnv)
+	assert.NilError(t, err)
+
+	options := &Options{WorkingDir: dir}
+	secret := &latest.VariableSecret{Provider: latest.VariableSecretProviderDotEnv, Path: "secrets.env"}
+	value, err := provider.Get(context.TODO(), options, secret, "API_TOKEN")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "my-token")
+
+	value, err = provider.Get(context.TODO(), options, secret, "OTHER")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "quoted value")
+
+	_, err = provider.Get(context.TODO(), options, secret, "MISSING")
+	assert.Error(t, err, "couldn't find key MISSING in secrets.env")
+}
+
+func TestKubernetesProvider(t *testing.T) {
+	client := &kubectltesting.Client{
+		Client: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: "test",
+			},
+			Data: map[string][]byte{
+				"password": []byte("hunter22"),
+			},
+		}),
+	}
+
+	provider, err := GetProvider(latest.VariableSecretProviderKubernetes)
+	assert.NilError(t, err)
+
+	secret := &latest.VariableSecret{Provider: latest.VariableSecretProviderKubernetes, Name: "db", Namespace: "test"}
+	value, err := provider.Get(context.TODO(), &Options{KubeClient: client}, secret, "password")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "hunter22")
+
+	_, err = provider.Get(context.TODO(), &Options{KubeClient: client}, secret, "user")
+	assert.Error(t, err, "couldn't find key user in secret test/db")
+
+	_, err = provider.Get(context.TODO(), &Options{}, secret, "password")
+	assert.Error(t, err, "cannot read secret db, because no kube context is available")
+}
+
+func TestLookupKey(t *testing.T) {
+	values := map[string]interface{}{
+		"flat.key": "flat",
+		"db": map[string]interface{}{
+			"password": "nested",
+			"port":     float64(5432),
+		},
+	}
+
+	value, ok := lookupKey(values, "flat.key")
+	assert.Assert(t, ok)
+	assert.Equal(t, value, "flat")
+
+	value, ok = lookupKey(values, "db.password")
+	assert.Assert(t, ok)
+	assert.Equal(t, value, "nested")
+
+	value, ok = lookupKey(values, "db.port")
+	assert.Assert(t, ok)
+	assert.Equal(t, value, "5432")
+
+	_, ok = lookupKey(values, "db.user")
+	assert.Assert(t, !ok)
+}
+
+func TestGetProvider(t *testing.T) {
+	_, err := GetProvider("vault")
+	assert.Error(t, err, "unknown secret provider 'vault', please choose one of dotenv, kubernetes, sops")
+}
@@ -0,0 +1,116 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/utils/pkg/command"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/expand"
+)
+
+// NewSOPSProvider creates a new provider that decrypts SOPS encrypted files with the sops binary.
+// Keys (e.g. age keys via SOPS_AGE_KEY_FILE) are configured as usual for sops.
+func NewSOPSProvider() Provider {
+	return &sopsProvider{
+		command: "sops",
+		files:   map[string]map[string]interface{}{},
+	}
+}
+
+type sopsProvider struct {
+	command string
+
+	m     sync.Mutex
+	files map[string]map[string]interface{}
+}
+
+func (s *sopsProvider) Get(ctx context.Context, options *Options, secret *latest.VariableSecret, key string) (string, error) {
+	if secret.Path == "" {
+		return "", fmt.Errorf("secret.path is required for provider %s", latest.VariableSecretProviderSOPS)
+	}
+
+	values, err := s.decrypt(ctx, resolvePath(options, secret.Path))
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := lookupKey(values, key)
+	if !ok {
+		return "", fmt.Errorf("couldn't find key %s in %s", key, secret.Path)
+	}
+
+	return value, nil
+}
+
+// decrypt decrypts the file only once per run and keeps the plaintext in memory
+func (s *sopsProvider) decrypt(ctx context.Context, path string) (map[string]interface{}, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if values, ok := s.files[path]; ok {
+		return values, nil
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	err := command.Command(ctx, "", expand.ListEnviron(os.Environ()...), stdout, stderr, nil, s.command, "--decrypt", "--output-type", "json", path)
+	if err != nil {
+		if stderr.Len() > 0 {
+			return nil, errors.Errorf("decrypt %s: %s", path, strings.TrimSpace(stderr.String()))
+		}
+
+		return nil, errors.Wrapf(err, "decrypt %s (is sops installed?)", path)
+	}
+
+	values := map[string]interface{}{}
+	err = json.Unmarshal(stdout.Bytes(), &values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse decrypted %s", path)
+	}
+
+	s.files[path] = values
+	return values, nil
+}
+
+// lookupKey returns the value of a possibly nested key separated by dots
+func lookupKey(values map[string]interface{}, key string) (string, bool) {
+	if value, ok := values[key]; ok {
+		return valueToString(value)
+	}
+
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+
+	child, ok := values[parts[0]].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	return lookupKey(child, parts[1])
+}
+
+func valueToString(value interface{}) (string, bool) {
+	switch t := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return t, true
+	case map[string]interface{}, []interface{}:
+		out, err := json.Marshal(t)
+		if err != nil {
+			return "", false
+		}
+		return string(out), true
+	default:
+		return fmt.Sprintf("%v", t), true
+	}
+}
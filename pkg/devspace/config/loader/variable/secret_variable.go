@@ -0,0 +1,52 @@
+package variable
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/secret"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// NewSecretVariable creates a new variable that is loaded from a secret provider. Secret
+// values are never written to the cache and are redacted from the log output.
+func NewSecretVariable(name string, options *secret.Options, log log.Logger) Variable {
+	return &secretVariable{
+		name:    name,
+		options: options,
+		log:     log,
+	}
+}
+
+type secretVariable struct {
+	name    string
+	options *secret.Options
+	log     log.Logger
+}
+
+func (s *secretVariable) Load(ctx context.Context, definition *latest.Variable) (interface{}, error) {
+	if definition.Secret == nil {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no secret is specified", s.name, latest.VariableSourceSecret)
+	}
+
+	provider, err := secret.GetProvider(definition.Secret.Provider)
+	if err != nil {
+		return nil, errors.Wrapf(err, "variable '%s'", s.name)
+	}
+
+	key := definition.Secret.Key
+	if key == "" {
+		key = s.name
+	}
+
+	value, err := provider.Get(ctx, s.options, definition.Secret, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "load secret variable '%s'", s.name)
+	}
+
+	if !log.RegisterSecret(value) {
+		s.log.Warnf("Value of secret variable '%s' is too short to be redacted from the log output", s.name)
+	}
+	return value, nil
+}
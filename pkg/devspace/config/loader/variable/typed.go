@@ -0,0 +1,357 @@
+package variable
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// IsTyped returns if the variable definition declares a type or validation that has to be applied on the
+// resolved value
+func IsTyped(definition *latest.Variable) bool {
+	return definition != nil && (definition.Type != "" || len(definition.Enum) > 0 || definition.Min != nil || definition.Max != nil || len(definition.Schema) > 0)
+}
+
+// ConvertValue converts the value to the type of the variable definition and validates it against the
+// enum, min, max and schema of the definition
+func ConvertValue(definition *latest.Variable, value interface{}) (interface{}, error) {
+	if !IsTyped(definition) {
+		return value, nil
+	}
+
+	converted, err := convertType(definition.Type, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "variable ${%s}", definition.Name)
+	}
+
+	err = validateTypedValue(definition, converted)
+	if err != nil {
+		return nil, errors.Wrapf(err, "variable ${%s}", definition.Name)
+	}
+
+	return converted, nil
+}
+
+func convertType(varType latest.VariableType, value interface{}) (interface{}, error) {
+	switch varType {
+	case "":
+		return value, nil
+	case latest.VariableTypeString:
+		switch t := value.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, fmt.Errorf("expected a string, but got %s", describe(value))
+		case nil:
+			return "", nil
+		case string:
+			return t, nil
+		default:
+			return fmt.Sprintf("%v", t), nil
+		}
+	case latest.VariableTypeInt:
+		switch t := value.(type) {
+		case int:
+			return t, nil
+		case int64:
+			return int(t), nil
+		case float64:
+			if t != math.Trunc(t) {
+				return nil, fmt.Errorf("expected an int, but got %v", t)
+			}
+			return int(t), nil
+		case string:
+			i, err := strconv.Atoi(strings.TrimSpace(t))
+			if err != nil {
+				return nil, fmt.Errorf("expected an int, but got '%s'", t)
+			}
+			return i, nil
+		}
+	case latest.VariableTypeBool:
+		switch t := value.(type) {
+		case bool:
+			return t, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(t))
+			if err != nil {
+				return nil, fmt.Errorf("expected a bool, but got '%s'", t)
+			}
+			return b, nil
+		}
+	case latest.VariableTypeList:
+		switch t := value.(type) {
+		case []interface{}:
+			return t, nil
+		case string:
+			return parseList(t)
+		}
+	case latest.VariableTypeMap:
+		switch t := value.(type) {
+		case map[string]interface{}:
+			return t, nil
+		case map[interface{}]interface{}:
+			return normalizeMap(t), nil
+		case string:
+			parsed := map[string]interface{}{}
+			err := yaml.Unmarshal([]byte(t), &parsed)
+			if err != nil {
+				return nil, fmt.Errorf("expected a map in yaml or json format, but got '%s'", t)
+			}
+			return parsed, nil
+		}
+	case latest.VariableTypeDuration:
+		str := fmt.Sprintf("%v", value)
+		if _, ok := value.(string); !ok && !isNumber(value) {
+			return nil, fmt.Errorf("expected a duration, but got %s", describe(value))
+		} else if _, err := parseDuration(value); err != nil {
+			return nil, fmt.Errorf("expected a duration (e.g. 10s or 5m), but got '%s'", str)
+		}
+		return value, nil
+	case latest.VariableTypeSemver:
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a semantic version, but got %s", describe(value))
+		} else if _, err := semver.NewVersion(str); err != nil {
+			return nil, fmt.Errorf("expected a semantic version (e.g. 1.2.3), but got '%s'", str)
+		}
+		return str, nil
+	default:
+		return nil, fmt.Errorf("unknown type %s", varType)
+	}
+
+	return nil, fmt.Errorf("expected %s %s, but got %s", article(varType), varType, describe(value))
+}
+
+// parseList parses json or yaml lists and falls back to comma separated values
+func parseList(value string) ([]interface{}, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return []interface{}{}, nil
+	}
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "- ") {
+		list := []interface{}{}
+		err := yaml.Unmarshal([]byte(trimmed), &list)
+		if err != nil {
+			return nil, fmt.Errorf("expected a list in yaml or json format, but got '%s'", value)
+		}
+		return list, nil
+	}
+
+	list := []interface{}{}
+	for _, item := range strings.Split(trimmed, ",") {
+		list = append(list, convertStringValue(strings.TrimSpace(item)))
+	}
+	return list, nil
+}
+
+func normalizeMap(m map[interface{}]interface{}) map[string]interface{} {
+	retMap := map[string]interface{}{}
+	for k, v := range m {
+		retMap[fmt.Sprintf("%v", k)] = v
+	}
+	return retMap
+}
+
+func validateTypedValue(definition *latest.Variable, value interface{}) error {
+	if len(definition.Enum) > 0 {
+		found := false
+		allowed := []string{}
+		for _, enumValue := range definition.Enum {
+			converted, err := convertType(definition.Type, enumValue)
+			if err == nil && reflect.DeepEqual(converted, value) {
+				found = true
+				break
+			}
+
+			allowed = append(allowed, fmt.Sprintf("%v", enumValue))
+		}
+		if !found {
+			return fmt.Errorf("value %v is not allowed, please use one of: %s", value, strings.Join(allowed, ", "))
+		}
+	}
+
+	if definition.Min != nil {
+		cmp, err := compare(definition.Type, value, definition.Min)
+		if err != nil {
+			return errors.Wrap(err, "min")
+		} else if cmp < 0 {
+			return fmt.Errorf("%s is less than the minimum %v", describeLimit(definition.Type, value), definition.Min)
+		}
+	}
+	if definition.Max != nil {
+		cmp, err := compare(definition.Type, value, definition.Max)
+		if err != nil {
+			return errors.Wrap(err, "max")
+		} else if cmp > 0 {
+			return fmt.Errorf("%s is greater than the maximum %v", describeLimit(definition.Type, value), definition.Max)
+		}
+	}
+
+	if len(definition.Schema) > 0 {
+		// make sure all numbers and maps look like json
+		out, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(definition.Schema), gojsonschema.NewBytesLoader(out))
+		if err != nil {
+			return errors.Wrap(err, "schema")
+		} else if !result.Valid() {
+			messages := []string{}
+			for _, resultErr := range result.Errors() {
+				messages = append(messages, resultErr.String())
+			}
+			return fmt.Errorf("value doesn't match schema: %s", strings.Join(messages, "; "))
+		}
+	}
+
+	return nil
+}
+
+// compare compares the value with the limit and returns -1, 0 or 1
+func compare(varType latest.VariableType, value interface{}, limit interface{}) (int, error) {
+	switch varType {
+	case latest.VariableTypeDuration:
+		valueDuration, err := parseDuration(value)
+		if err != nil {
+			return 0, err
+		}
+		limitDuration, err := parseDuration(limit)
+		if err != nil {
+			return 0, fmt.Errorf("%v is not a valid duration", limit)
+		}
+		return compareFloat(float64(valueDuration), float64(limitDuration)), nil
+	case latest.VariableTypeSemver:
+		valueVersion, err := semver.NewVersion(fmt.Sprintf("%v", value))
+		if err != nil {
+			return 0, err
+		}
+		limitVersion, err := semver.NewVersion(fmt.Sprintf("%v", limit))
+		if err != nil {
+			return 0, fmt.Errorf("%v is not a valid semantic version", limit)
+		}
+		return valueVersion.Compare(limitVersion), nil
+	}
+
+	limitNumber, err := toFloat(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	switch t := value.(type) {
+	case string:
+		return compareFloat(float64(len(t)), limitNumber), nil
+	case []interface{}:
+		return compareFloat(float64(len(t)), limitNumber), nil
+	case map[string]interface{}:
+		return compareFloat(float64(len(t)), limitNumber), nil
+	}
+
+	valueNumber, err := toFloat(value)
+	if err != nil {
+		return 0, err
+	}
+	return compareFloat(valueNumber, limitNumber), nil
+}
+
+func compareFloat(a, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// parseDuration parses go durations (e.g. 1m30s) and treats plain numbers as seconds
+func parseDuration(value interface{}) (time.Duration, error) {
+	if isNumber(value) {
+		seconds, err := toFloat(value)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	str := strings.TrimSpace(fmt.Sprintf("%v", value))
+	if seconds, err := strconv.ParseFloat(str, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(str)
+}
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int64, float64:
+		return true
+	}
+	return false
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch t := value.(type) {
+	case int:
+		return float64(t), nil
+	case int64:
+		return float64(t), nil
+	case float64:
+		return t, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s is not a number", t)
+		}
+		return f, nil
+	}
+
+	return 0, fmt.Errorf("%v is not a number", value)
+}
+
+func describeLimit(varType latest.VariableType, value interface{}) string {
+	switch t := value.(type) {
+	case string:
+		if varType == latest.VariableTypeDuration || varType == latest.VariableTypeSemver {
+			return t
+		}
+		return fmt.Sprintf("length %d", len(t))
+	case []interface{}:
+		return fmt.Sprintf("length %d", len(t))
+	case map[string]interface{}:
+		return fmt.Sprintf("length %d", len(t))
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "an empty value"
+	case map[string]interface{}, map[interface{}]interface{}:
+		return "a map"
+	case []interface{}:
+		return "a list"
+	case bool:
+		return fmt.Sprintf("bool %v", value)
+	case int, int64, float64:
+		return fmt.Sprintf("number %v", value)
+	}
+
+	return fmt.Sprintf("'%v'", value)
+}
+
+func article(varType latest.VariableType) string {
+	if varType == latest.VariableTypeInt {
+		return "an"
+	}
+	return "a"
+}
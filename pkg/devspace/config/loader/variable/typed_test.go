@@ -0,0 +1,198 @@
+package variable
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+type convertValueTestCase struct {
+	name       string
+	definition *latest.Variable
+	value      interface{}
+
+	expectedValue interface{}
+	expectedErr   string
+}
+
+func TestConvertValue(t *testing.T) {
+	testCases := []convertValueTestCase{
+		{
+			name:          "untyped",
+			definition:    &latest.Variable{Name: "VAR"},
+			value:         "abc",
+			expectedValue: "abc",
+		},
+		{
+			name:          "int from string",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeInt, Min: 1, Max: 5},
+			value:         "3",
+			expectedValue: 3,
+		},
+		{
+			name:        "int too large",
+			definition:  &latest.Variable{Name: "VAR", Type: latest.VariableTypeInt, Min: 1, Max: 5},
+			value:       6,
+			expectedErr: "variable ${VAR}: 6 is greater than the maximum 5",
+		},
+		{
+			name:        "invalid int",
+			definition:  &latest.Variable{Name: "VAR", Type: latest.VariableTypeInt},
+			value:       "abc",
+			expectedErr: "variable ${VAR}: expected an int, but got 'abc'",
+		},
+		{
+			name:          "string from int",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeString},
+			value:         12,
+			expectedValue: "12",
+		},
+		{
+			name:        "string too short",
+			definition:  &latest.Variable{Name: "VAR", Type: latest.VariableTypeString, Min: 3},
+			value:       "ab",
+			expectedErr: "variable ${VAR}: length 2 is less than the minimum 3",
+		},
+		{
+			name:          "bool from string",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeBool},
+			value:         "true",
+			expectedValue: true,
+		},
+		{
+			name:          "enum",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeString, Enum: []interface{}{"dev", "prod"}},
+			value:         "prod",
+			expectedValue: "prod",
+		},
+		{
+			name:        "not in enum",
+			definition:  &latest.Variable{Name: "VAR", Enum: []interface{}{"dev", "prod"}},
+			value:       "staging",
+			expectedErr: "variable ${VAR}: value staging is not allowed, please use one of: dev, prod",
+		},
+		{
+			name:          "list from comma separated values",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeList},
+			value:         "a, b,3",
+			expectedValue: []interface{}{"a", "b", 3},
+		},
+		{
+			name:          "list from json",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeList, Max: 2},
+			value:         `["a", "b"]`,
+			expectedValue: []interface{}{"a", "b"},
+		},
+		{
+			name:          "map from json",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeMap},
+			value:         `{"cpu": "100m"}`,
+			expectedValue: map[string]interface{}{"cpu": "100m"},
+		},
+		{
+			name:        "map from list",
+			definition:  &latest.Variable{Name: "VAR", Type: latest.VariableTypeMap},
+			value:       []interface{}{"a"},
+			expectedErr: "variable ${VAR}: expected a map, but got a list",
+		},
+		{
+			name: "map with schema",
+			definition: &latest.Variable{Name: "VAR", Type: latest.VariableTypeMap, Schema: map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"cpu"},
+				"properties": map[string]interface{}{
+					"cpu": map[string]interface{}{"type": "string"},
+				},
+			}},
+			value:       map[string]interface{}{"memory": "1Gi"},
+			expectedErr: "variable ${VAR}: value doesn't match schema: (root): cpu is required",
+		},
+		{
+			name:          "duration",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeDuration, Min: "1s", Max: "1m"},
+			value:         "30s",
+			expectedValue: "30s",
+		},
+		{
+			name:        "duration too long",
+			definition:  &latest.Variable{Name: "VAR", Type: latest.VariableTypeDuration, Max: "1m"},
+			value:       "2m",
+			expectedErr: "variable ${VAR}: 2m is greater than the maximum 1m",
+		},
+		{
+			name:          "semver",
+			definition:    &latest.Variable{Name: "VAR", Type: latest.VariableTypeSemver, Min: "1.2.0"},
+			value:         "1.10.0",
+			expectedValue: "1.10.0",
+		},
+		{
+			name:        "semver too old",
+			definition:  &latest.Variable{Name: "VAR", Type: latest.VariableTypeSemver, Min: "1.2.0"},
+			value:       "1.1.9",
+			expectedErr: "variable ${VAR}: 1.1.9 is less than the minimum 1.2.0",
+		},
+		{
+			name:        "invalid semver",
+			definition:  &latest.Variable{Name: "VAR", Type: latest.VariableTypeSemver},
+			value:       "latest",
+			expectedErr: "variable ${VAR}: expected a semantic version (e.g. 1.2.3), but got 'latest'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		value, err := ConvertValue(testCase.definition, testCase.value)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, testCase.name)
+			continue
+		}
+
+		assert.NilError(t, err, testCase.name)
+		assert.DeepEqual(t, value, testCase.expectedValue)
+	}
+}
+
+func TestResolveTypedVariables(t *testing.T) {
+	dir := t.TempDir()
+	resolver, err := NewResolver(localcache.New(filepath.Join(dir, ".devspace", "cache.yaml")), nil, &PredefinedVariableOptions{
+		ConfigPath: filepath.Join(dir, "devspace.yaml"),
+	}, []string{"HOSTS=a.com", "b.com", "REPLICAS=2"}, log.Discard)
+	assert.NilError(t, err)
+
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"HOSTS":    {Name: "HOSTS", Type: latest.VariableTypeList},
+		"REPLICAS": {Name: "REPLICAS", Type: latest.VariableTypeInt, Max: 3},
+		"RESOURCES": {Name: "RESOURCES", Type: latest.VariableTypeMap, Default: map[string]interface{}{
+			"cpu": "100m",
+		}},
+	})
+
+	out, err := resolver.FillVariables(context.TODO(), map[string]interface{}{
+		"hosts":     "${HOSTS}",
+		"replicas":  "${REPLICAS}",
+		"resources": "${RESOURCES}",
+	}, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, out, map[string]interface{}{
+		"hosts":     []interface{}{"a.com", "b.com"},
+		"replicas":  2,
+		"resources": map[string]interface{}{"cpu": "100m"},
+	})
+
+	resolver, err = NewResolver(localcache.New(filepath.Join(dir, ".devspace", "cache.yaml")), nil, &PredefinedVariableOptions{
+		ConfigPath: filepath.Join(dir, "devspace.yaml"),
+	}, []string{"REPLICAS=5"}, log.Discard)
+	assert.NilError(t, err)
+	resolver.UpdateVars(map[string]*latest.Variable{
+		"REPLICAS": {Name: "REPLICAS", Type: latest.VariableTypeInt, Max: 3},
+	})
+
+	_, err = resolver.FillVariables(context.TODO(), map[string]interface{}{
+		"replicas": "${REPLICAS}",
+	}, true)
+	assert.Error(t, err, "variable ${REPLICAS}: 5 is greater than the maximum 3")
+}
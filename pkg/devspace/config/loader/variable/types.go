@@ -7,6 +7,23 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 )
 
+// Source describes where the value of a variable was loaded from
+type Source string
+
+// List of values that a variable source can take
+const (
+	SourceFlag       Source = "flag"
+	SourceEnv        Source = "env"
+	SourceCache      Source = "cache"
+	SourceTeam       Source = "team"
+	SourceDefault    Source = "default"
+	SourceValue      Source = "value"
+	SourceInput      Source = "input"
+	SourceCommand    Source = "command"
+	SourceSecret     Source = "secret"
+	SourcePredefined Source = "predefined"
+)
+
 // Variable defines an interface to load a variable
 type Variable interface {
 	Load(ctx context.Context, definition *latest.Variable) (interface{}, error)
@@ -43,4 +60,7 @@ type Resolver interface {
 
 	// ResolvedVariables returns the internal memory cache of the resolver with all resolved variables
 	ResolvedVariables() map[string]interface{}
+
+	// Sources returns where the values of the resolved variables were loaded from
+	Sources() map[string]Source
 }
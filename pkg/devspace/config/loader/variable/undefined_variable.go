@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/teamcache"
 	"github.com/sirupsen/logrus"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -15,44 +16,68 @@ import (
 )
 
 // NewUndefinedVariable creates a new variable that is loaded without definition
-func NewUndefinedVariable(name string, localCache localcache.Cache, log log.Logger) Variable {
-	return &undefinedVariable{
+func NewUndefinedVariable(name string, localCache localcache.Cache, teamCache teamcache.Cache, log log.Logger) *UndefinedVariable {
+	return &UndefinedVariable{
 		name:       name,
 		localCache: localCache,
+		teamCache:  teamCache,
 		log:        log,
 	}
 }
 
-type undefinedVariable struct {
+// UndefinedVariable loads a variable without definition from the environment, the local cache, the
+// team cache or asks the user
+type UndefinedVariable struct {
 	name       string
 	localCache localcache.Cache
+	teamCache  teamcache.Cache
 	log        log.Logger
+
+	source Source
+}
+
+// Source returns where the value of the variable was loaded from
+func (u *UndefinedVariable) Source() Source {
+	return u.source
 }
 
-func (u *undefinedVariable) Load(ctx context.Context, _ *latest.Variable) (interface{}, error) {
+func (u *UndefinedVariable) Load(ctx context.Context, _ *latest.Variable) (interface{}, error) {
 	// Is in environment?
 	if os.Getenv(u.name) != "" {
+		u.source = SourceEnv
 		return convertStringValue(os.Getenv(u.name)), nil
 	}
 
 	// Is in generated config?
 	if v, ok := u.localCache.GetVar(u.name); ok {
+		u.source = SourceCache
 		return convertStringValue(v), nil
 	}
 
+	// Is in team cache?
+	if u.teamCache != nil {
+		if v, ok := u.teamCache.GetVar(u.name); ok {
+			u.source = SourceTeam
+			return convertStringValue(v), nil
+		}
+	}
+
 	// is logger silent
 	if u.log == log.Discard || u.log.GetLevel() < logrus.InfoLevel {
+		u.source = SourceDefault
 		return "", nil
 	}
 
 	// Ask for variable
 	val, err := askQuestion(&latest.Variable{
+		Name:     u.name,
 		Question: "Please enter a value for " + u.name,
 	}, u.log)
 	if err != nil {
 		return "", err
 	}
 
+	u.source = SourceInput
 	u.localCache.SetVar(u.name, val)
 	return convertStringValue(val), nil
 }
@@ -99,13 +124,26 @@ func getParams(variable *latest.Variable) *survey.QuestionOptions {
 			params.IsPassword = true
 		}
 
+		// tell the user how to provide the value if the question cannot be asked
+		params.NonInteractiveHint = "--var " + variable.Name + "=VALUE"
+		if variable.Source != latest.VariableSourceInput {
+			params.NonInteractiveHint += " or the " + variable.Name + " environment variable"
+		}
+
 		if variable.Default != nil && variable.Default != "" {
 			params.DefaultValue = fmt.Sprintf("%v", variable.Default)
 		}
 		if variable.Default != nil {
 			params.DefaultValueSet = true
 		}
-		if len(variable.Options) > 0 {
+		if len(variable.Options) == 0 && len(variable.Enum) > 0 {
+			for _, enumValue := range variable.Enum {
+				params.Options = append(params.Options, fmt.Sprintf("%v", enumValue))
+			}
+			if variable.Default == nil {
+				params.DefaultValue = params.Options[0]
+			}
+		} else if len(variable.Options) > 0 {
 			params.Options = variable.Options
 			if variable.Default == nil {
 				params.DefaultValue = params.Options[0]
@@ -117,6 +155,12 @@ func getParams(variable *latest.Variable) *survey.QuestionOptions {
 				params.ValidationMessage = variable.ValidationMessage
 			}
 		}
+		if IsTyped(variable) {
+			params.ValidationFunc = func(value string) error {
+				_, err := ConvertValue(variable, value)
+				return err
+			}
+		}
 	}
 	return params
 }
@@ -34,6 +34,9 @@ type Cache interface {
 	Save() error
 }
 
+// saveMutex serializes writing the cache files
+var saveMutex sync.Mutex
+
 // LocalCache specifies the runtime cache
 type LocalCache struct {
 	Vars          map[string]string `yaml:"vars,omitempty"`
@@ -238,6 +241,11 @@ func (l *LocalCache) Save() error {
 		}
 	}
 
+	// several caches of the same file might be saved at the same time, e.g. the caches of a
+	// dependency in the combinations of a pipeline matrix
+	saveMutex.Lock()
+	defer saveMutex.Unlock()
+
 	err = os.MkdirAll(filepath.Dir(l.cachePath), 0755)
 	if err != nil {
 		return err
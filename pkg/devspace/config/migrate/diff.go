@@ -0,0 +1,132 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+)
+
+type operation int
+
+const (
+	opEqual operation = iota
+	opDelete
+	opInsert
+)
+
+type diffLine struct {
+	op   operation
+	text string
+}
+
+// UnifiedDiff returns the unified diff between a and b with the given number of context lines
+func UnifiedDiff(path, a, b string, context int) string {
+	lines := diffLines(a, b)
+	changes := []int{}
+	for i, line := range lines {
+		if line.op != opEqual {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", path, path)
+	for i := 0; i < len(changes); {
+		// changes that are close to each other are combined into a single hunk
+		j := i
+		for j+1 < len(changes) && changes[j+1]-changes[j] <= 2*context+1 {
+			j++
+		}
+
+		writeHunk(out, lines, max(changes[i]-context, 0), min(changes[j]+context+1, len(lines)))
+		i = j + 1
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, lines []diffLine, start, end int) {
+	oldStart, newStart := 1, 1
+	for _, line := range lines[:start] {
+		if line.op != opInsert {
+			oldStart++
+		}
+		if line.op != opDelete {
+			newStart++
+		}
+	}
+
+	oldLines, newLines := 0, 0
+	body := &strings.Builder{}
+	for _, line := range lines[start:end] {
+		switch line.op {
+		case opEqual:
+			oldLines++
+			newLines++
+			body.WriteString(" " + line.text + "\n")
+		case opDelete:
+			oldLines++
+			body.WriteString("-" + line.text + "\n")
+		case opInsert:
+			newLines++
+			body.WriteString("+" + line.text + "\n")
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n%s", oldStart, oldLines, newStart, newLines, body.String())
+}
+
+// diffLines computes a line based diff of a and b from their longest common subsequence
+func diffLines(a, b string) []diffLine {
+	linesA, linesB := splitLines(a), splitLines(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of linesA[i:] and linesB[j:]
+	lcs := make([][]int, len(linesA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(linesB)+1)
+	}
+	for i := len(linesA) - 1; i >= 0; i-- {
+		for j := len(linesB) - 1; j >= 0; j-- {
+			if linesA[i] == linesB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(linesA) && j < len(linesB) {
+		switch {
+		case linesA[i] == linesB[j]:
+			lines = append(lines, diffLine{op: opEqual, text: linesA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{op: opDelete, text: linesA[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{op: opInsert, text: linesB[j]})
+			j++
+		}
+	}
+	for ; i < len(linesA); i++ {
+		lines = append(lines, diffLine{op: opDelete, text: linesA[i]})
+	}
+	for ; j < len(linesB); j++ {
+		lines = append(lines, diffLine{op: opInsert, text: linesB[j]})
+	}
+
+	return lines
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
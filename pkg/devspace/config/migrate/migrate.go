@@ -0,0 +1,311 @@
+package migrate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Result is the migration of a single config file
+type Result struct {
+	// Path is the path of the config file
+	Path string
+
+	// FromVersion is the version of the config before the migration
+	FromVersion string
+
+	// Original is the content of the config file before the migration
+	Original []byte
+
+	// Migrated is the content of the config file after the migration
+	Migrated []byte
+
+	// Changes are the fields whose semantics changed or that are not supported
+	// anymore in the latest version
+	Changes []string
+
+	// Config is the migrated config
+	Config *latest.Config
+}
+
+// Changed returns if the migration changed the config file
+func (r *Result) Changed() bool {
+	return !bytes.Equal(r.Original, r.Migrated)
+}
+
+// Diff returns the unified diff between the original and the migrated config file
+func (r *Result) Diff() string {
+	return UnifiedDiff(r.Path, string(r.Original), string(r.Migrated), 3)
+}
+
+// Write writes the migrated config file
+func (r *Result) Write() error {
+	stat, err := os.Stat(r.Path)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.Path, r.Migrated, stat.Mode())
+}
+
+// File migrates the config file at the given path to the latest version
+func File(path string) (*Result, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Migrate(content, loader.DefaultName(absPath))
+	if err != nil {
+		return nil, errors.Wrapf(err, "migrate %s", path)
+	}
+
+	result.Path = path
+	return result, nil
+}
+
+// Migrate migrates the config to the latest version. Comments and the order of keys are preserved
+// for all fields that still exist in the latest version. Configs without a name get the given name,
+// because it is required since v2beta1.
+func Migrate(content []byte, name string) (*Result, error) {
+	result := &Result{
+		Original: content,
+		Migrated: content,
+	}
+
+	data := map[string]interface{}{}
+	err := yamlutil.Unmarshal(content, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	version, ok := data["version"].(string)
+	if !ok {
+		return nil, errors.New("version is missing")
+	}
+	result.FromVersion = version
+	if data["name"] == nil || data["name"] == "" {
+		data["name"] = name
+	}
+
+	// the warnings of the upgrade functions describe the fields whose semantics changed
+	changes := &bytes.Buffer{}
+	logger := log.NewStreamLoggerWithFormat(changes, changes, logrus.WarnLevel, log.RawFormat)
+	result.Config, err = versions.UpgradeToLatest(data, logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(changes.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !contains(result.Changes, line) {
+			result.Changes = append(result.Changes, line)
+		}
+	}
+	if version == latest.Version {
+		return result, nil
+	}
+
+	// encode the upgraded config to a node tree and merge it into the original one
+	upgraded := &yaml.Node{}
+	out, err := yaml.Marshal(result.Config)
+	if err != nil {
+		return nil, err
+	}
+	err = yaml.Unmarshal(out, upgraded)
+	if err != nil {
+		return nil, err
+	}
+
+	original := &yaml.Node{}
+	err = yaml.Unmarshal(content, original)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := mergeNodes(original, upgraded)
+	if merged.Kind == yaml.DocumentNode && len(merged.Content) == 1 {
+		moveAfter(merged.Content[0], "name", "version")
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	err = encoder.Encode(merged)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	result.Migrated = buffer.Bytes()
+	return result, nil
+}
+
+// mergeNodes returns the upgraded node, but reuses the original nodes where possible, so
+// that their comments, style and key order are kept
+func mergeNodes(original, upgraded *yaml.Node) *yaml.Node {
+	if original == nil {
+		return upgraded
+	} else if original.Kind == yaml.SequenceNode && upgraded.Kind == yaml.MappingNode {
+		// lists of named objects were converted to maps keyed by name in v2beta1
+		for _, item := range original.Content {
+			name := findValue(item, "name")
+			if name == nil {
+				continue
+			}
+
+			for i := 0; i+1 < len(upgraded.Content); i += 2 {
+				if upgraded.Content[i].Value == name.Value {
+					upgraded.Content[i].HeadComment = item.HeadComment
+					upgraded.Content[i+1] = mergeNodes(item, upgraded.Content[i+1])
+				}
+			}
+		}
+
+		upgraded.HeadComment = original.HeadComment
+		upgraded.LineComment = original.LineComment
+		upgraded.FootComment = original.FootComment
+		return upgraded
+	} else if original.Kind != upgraded.Kind {
+		upgraded.HeadComment = original.HeadComment
+		upgraded.LineComment = original.LineComment
+		upgraded.FootComment = original.FootComment
+		return upgraded
+	}
+
+	switch original.Kind {
+	case yaml.DocumentNode:
+		if len(original.Content) == 1 && len(upgraded.Content) == 1 {
+			original.Content[0] = mergeNodes(original.Content[0], upgraded.Content[0])
+		} else {
+			original.Content = upgraded.Content
+		}
+	case yaml.MappingNode:
+		content := []*yaml.Node{}
+		used := map[string]bool{}
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			key := original.Content[i]
+			value := findValue(upgraded, key.Value)
+			if value == nil {
+				continue
+			}
+
+			used[key.Value] = true
+			content = append(content, key, mergeNodes(original.Content[i+1], value))
+		}
+		for i := 0; i+1 < len(upgraded.Content); i += 2 {
+			if !used[upgraded.Content[i].Value] {
+				content = append(content, upgraded.Content[i], upgraded.Content[i+1])
+			}
+		}
+
+		original.Content = content
+	case yaml.SequenceNode:
+		content := []*yaml.Node{}
+		for i, value := range upgraded.Content {
+			if i < len(original.Content) {
+				value = mergeNodes(original.Content[i], value)
+			}
+
+			content = append(content, value)
+		}
+
+		original.Content = content
+	case yaml.ScalarNode:
+		if original.Value != upgraded.Value || original.ShortTag() != upgraded.ShortTag() {
+			original.Value = upgraded.Value
+			original.Tag = upgraded.Tag
+			original.Style = upgraded.Style
+		}
+	case yaml.AliasNode:
+		return upgraded
+	}
+
+	return original
+}
+
+func findValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// moveAfter moves the key of the mapping directly after the other key
+func moveAfter(mapping *yaml.Node, key, after string) {
+	if mapping.Kind != yaml.MappingNode {
+		return
+	}
+
+	var entry []*yaml.Node
+	content := []*yaml.Node{}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			entry = mapping.Content[i : i+2]
+			continue
+		}
+
+		content = append(content, mapping.Content[i], mapping.Content[i+1])
+	}
+	if entry == nil {
+		return
+	}
+
+	mapping.Content = []*yaml.Node{}
+	for i := 0; i+1 < len(content); i += 2 {
+		mapping.Content = append(mapping.Content, content[i], content[i+1])
+		if content[i].Value == after {
+			mapping.Content = append(mapping.Content, entry...)
+			entry = nil
+		}
+	}
+	if entry != nil {
+		mapping.Content = append(mapping.Content, entry...)
+	}
+}
+
+// ConfigPath returns the path of the config file for a local import or dependency path
+func ConfigPath(basePath, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(basePath, path)
+	}
+
+	if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+		return filepath.Join(path, "devspace.yaml")
+	}
+
+	return path
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
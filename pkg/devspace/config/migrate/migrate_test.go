@@ -0,0 +1,76 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	original := `version: v1beta11
+# images of the project
+images:
+  app:
+    image: acme/app # the main image
+deployments:
+- name: app
+  # helm deployment
+  helm:
+    values:
+      containers:
+      - image: acme/app
+dev:
+  sync:
+  - imageSelector: acme/app
+    onDownload:
+      execLocal:
+        command: echo
+`
+
+	result, err := Migrate([]byte(original), "my-project")
+	assert.NilError(t, err)
+	assert.Equal(t, result.FromVersion, "v1beta11")
+	assert.Equal(t, result.Changed(), true)
+	assert.Equal(t, result.Config.Name, "my-project")
+
+	migrated := string(result.Migrated)
+	assert.Assert(t, strings.HasPrefix(migrated, "version: "+latest.Version+"\nname: my-project\n# images of the project\nimages:\n  app:\n    image: acme/app # the main image\n"), migrated)
+	assert.Assert(t, strings.Contains(migrated, "deployments:\n  app:\n    name: app\n    # helm deployment\n    helm:\n"), migrated)
+	assert.Assert(t, !strings.Contains(migrated, "onDownload"), migrated)
+
+	assert.Equal(t, len(result.Changes), 1)
+	assert.Assert(t, strings.Contains(result.Changes[0], "onDownload"))
+
+	// migrating the migrated config again does not change anything
+	again, err := Migrate(result.Migrated, "my-project")
+	assert.NilError(t, err)
+	assert.Equal(t, again.Changed(), false)
+}
+
+func TestMigrateLatest(t *testing.T) {
+	original := "version: " + latest.Version + "\nname: test # the name\n"
+	result, err := Migrate([]byte(original), "other")
+	assert.NilError(t, err)
+	assert.Equal(t, result.Changed(), false)
+	assert.Equal(t, result.Config.Name, "test")
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	b := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+
+	assert.Equal(t, UnifiedDiff("devspace.yaml", a, a, 1), "")
+	assert.Equal(t, UnifiedDiff("devspace.yaml", a, b, 1), `--- devspace.yaml
++++ devspace.yaml
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+@@ -10,1 +10,2 @@
+ j
++k
+`)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
 	"github.com/loft-sh/devspace/pkg/devspace/env"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/lock"
 	"github.com/loft-sh/devspace/pkg/util/encryption"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/pkg/errors"
@@ -80,6 +81,27 @@ type DevPodCache struct {
 
 	// TargetName is the parent name of the original parent
 	TargetName string `yaml:"parentName,omitempty"`
+
+	// Intercept holds the original state of an intercepted service
+	Intercept *InterceptCache `yaml:"intercept,omitempty"`
+}
+
+// InterceptCache holds the information needed to restore an intercepted service
+type InterceptCache struct {
+	// Service is the name of the intercepted service
+	Service string `yaml:"service,omitempty"`
+
+	// Namespace is the namespace of the intercepted service
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Pod is the pod that was labeled to receive the intercepted traffic
+	Pod string `yaml:"pod,omitempty"`
+
+	// Selector is the original selector of the service
+	Selector map[string]string `yaml:"selector,omitempty"`
+
+	// TargetPorts are the original target ports of the service by service port
+	TargetPorts map[int32]string `yaml:"targetPorts,omitempty"`
 }
 
 // DeploymentCache holds the information about a specific deployment
@@ -315,6 +337,12 @@ func (l *RemoteCache) Save(ctx context.Context, client kubectl.Client) error {
 		namespace = client.Namespace()
 	}
 
+	// never overwrite the cache while another devspace run holds the namespace lock
+	err = lock.Verify(ctx, client.KubeClient(), namespace)
+	if err != nil {
+		return errors.Wrap(err, "save remote cache")
+	}
+
 	waitErr := wait.PollUntilContextTimeout(context.TODO(), time.Second, time.Second*10, true, func(_ context.Context) (done bool, err error) {
 		secret, err := client.KubeClient().CoreV1().Secrets(namespace).Get(ctx, l.secretName, metav1.GetOptions{})
 		if err != nil {
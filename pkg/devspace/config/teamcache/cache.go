@@ -0,0 +1,205 @@
+package teamcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	perrors "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	SecretType = "devspace.sh/team-vars"
+)
+
+// Cache is the variable store that is shared by a team
+type Cache interface {
+	GetVar(varName string) (string, bool)
+	SetVar(varName, value string)
+	ListVars() map[string]string
+
+	// Namespace returns the namespace of the secret that holds the variables
+	Namespace() string
+
+	// Name returns the name of the secret that holds the variables
+	Name() string
+
+	// Save persists the changed variables to the secret
+	Save(ctx context.Context, client kubectl.Client) error
+}
+
+// ParseTeamVars returns the teamVars section of the raw config or nil if there is none
+func ParseTeamVars(rawConfig map[string]interface{}) (*latest.TeamVars, error) {
+	if rawConfig["teamVars"] == nil {
+		return nil, nil
+	}
+
+	teamVars := &latest.TeamVars{}
+	err := util.Convert(rawConfig["teamVars"], teamVars)
+	if err != nil {
+		return nil, perrors.Wrap(err, "parse teamVars")
+	} else if teamVars.Namespace == "" {
+		return nil, perrors.New("teamVars.namespace is required")
+	}
+
+	return teamVars, nil
+}
+
+// Load loads the team variables of the config with the given name. If the secret does not exist
+// or cannot be accessed, an empty cache is returned.
+func Load(ctx context.Context, client kubectl.Client, configName string, teamVars *latest.TeamVars) (Cache, error) {
+	cache := &teamCache{
+		vars:      map[string]string{},
+		changed:   map[string]string{},
+		name:      teamVars.Name,
+		namespace: teamVars.Namespace,
+	}
+	if cache.name == "" {
+		cache.name = encoding.SafeConcatName("devspace", "team", "vars", configName)
+	}
+
+	secret, err := client.KubeClient().CoreV1().Secrets(cache.namespace).Get(ctx, cache.name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) && !kerrors.IsForbidden(err) && !errors.Is(err, syscall.ECONNREFUSED) {
+			return nil, perrors.Wrapf(err, "get team vars secret %s/%s", cache.namespace, cache.name)
+		}
+
+		return cache, nil
+	}
+
+	for name, value := range secret.Data {
+		cache.vars[name] = string(value)
+	}
+
+	return cache, nil
+}
+
+type teamCache struct {
+	vars      map[string]string
+	changed   map[string]string
+	name      string
+	namespace string
+
+	accessMutex sync.Mutex
+}
+
+func (t *teamCache) GetVar(varName string) (string, bool) {
+	t.accessMutex.Lock()
+	defer t.accessMutex.Unlock()
+
+	value, ok := t.vars[varName]
+	return value, ok
+}
+
+func (t *teamCache) SetVar(varName, value string) {
+	t.accessMutex.Lock()
+	defer t.accessMutex.Unlock()
+
+	t.vars[varName] = value
+	t.changed[varName] = value
+}
+
+func (t *teamCache) ListVars() map[string]string {
+	t.accessMutex.Lock()
+	defer t.accessMutex.Unlock()
+
+	vars := map[string]string{}
+	for name, value := range t.vars {
+		vars[name] = value
+	}
+
+	return vars
+}
+
+func (t *teamCache) Namespace() string {
+	return t.namespace
+}
+
+func (t *teamCache) Name() string {
+	return t.name
+}
+
+func (t *teamCache) Save(ctx context.Context, client kubectl.Client) error {
+	t.accessMutex.Lock()
+	defer t.accessMutex.Unlock()
+	if len(t.changed) == 0 {
+		return nil
+	}
+
+	// only apply the changed variables on top of the current secret, so that
+	// variables another team member saved in the meantime are kept
+	waitErr := wait.PollUntilContextTimeout(ctx, time.Second, time.Second*10, true, func(_ context.Context) (bool, error) {
+		secret, err := client.KubeClient().CoreV1().Secrets(t.namespace).Get(ctx, t.name, metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return false, perrors.Wrapf(err, "get team vars secret %s/%s", t.namespace, t.name)
+			}
+
+			err = kubectl.EnsureNamespace(ctx, client, t.namespace, log.Discard)
+			if err != nil {
+				return false, err
+			}
+
+			data := map[string][]byte{}
+			for name, value := range t.changed {
+				data[name] = []byte(value)
+			}
+
+			_, err = client.KubeClient().CoreV1().Secrets(t.namespace).Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      t.name,
+					Namespace: t.namespace,
+					Labels: map[string]string{
+						"owner": "devspace",
+					},
+				},
+				Type: SecretType,
+				Data: data,
+			}, metav1.CreateOptions{})
+			if err != nil {
+				if kerrors.IsAlreadyExists(err) {
+					return false, nil
+				}
+
+				return false, perrors.Wrapf(err, "create team vars secret %s/%s", t.namespace, t.name)
+			}
+
+			return true, nil
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for name, value := range t.changed {
+			secret.Data[name] = []byte(value)
+		}
+
+		_, err = client.KubeClient().CoreV1().Secrets(t.namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		if err != nil {
+			if kerrors.IsConflict(err) {
+				return false, nil
+			}
+
+			return false, perrors.Wrapf(err, "update team vars secret %s/%s", t.namespace, t.name)
+		}
+
+		return true, nil
+	})
+	if waitErr != nil {
+		return waitErr
+	}
+
+	t.changed = map[string]string{}
+	return nil
+}
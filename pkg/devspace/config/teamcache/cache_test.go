@@ -0,0 +1,68 @@
+package teamcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCache(t *testing.T) {
+	client := &kubectltesting.Client{
+		Client: fake.NewSimpleClientset(),
+	}
+
+	teamVars, err := ParseTeamVars(map[string]interface{}{
+		"teamVars": map[string]interface{}{
+			"namespace": "team",
+		},
+	})
+	assert.NilError(t, err)
+
+	// a missing secret results in an empty cache
+	cache, err := Load(context.TODO(), client, "project", teamVars)
+	assert.NilError(t, err)
+	assert.Equal(t, cache.Name(), "devspace-team-vars-project")
+	assert.Equal(t, len(cache.ListVars()), 0)
+
+	cache.SetVar("REGISTRY", "ghcr.io/acme")
+	assert.NilError(t, cache.Save(context.TODO(), client))
+
+	secret, err := client.KubeClient().CoreV1().Secrets("team").Get(context.TODO(), "devspace-team-vars-project", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(secret.Type), SecretType)
+	assert.Equal(t, string(secret.Data["REGISTRY"]), "ghcr.io/acme")
+
+	// saving again updates the secret
+	cache, err = Load(context.TODO(), client, "project", teamVars)
+	assert.NilError(t, err)
+	value, ok := cache.GetVar("REGISTRY")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, value, "ghcr.io/acme")
+
+	cache.SetVar("DOMAIN", "acme.dev")
+	assert.NilError(t, cache.Save(context.TODO(), client))
+	cache, err = Load(context.TODO(), client, "project", &latest.TeamVars{Namespace: "team"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, cache.ListVars(), map[string]string{"REGISTRY": "ghcr.io/acme", "DOMAIN": "acme.dev"})
+
+	// saving only applies the changed variables, so concurrent changes are kept
+	first, err := Load(context.TODO(), client, "project", teamVars)
+	assert.NilError(t, err)
+	second, err := Load(context.TODO(), client, "project", teamVars)
+	assert.NilError(t, err)
+	first.SetVar("DOMAIN", "acme.io")
+	second.SetVar("TOKEN", "abc")
+	assert.NilError(t, first.Save(context.TODO(), client))
+	assert.NilError(t, second.Save(context.TODO(), client))
+	cache, err = Load(context.TODO(), client, "project", teamVars)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, cache.ListVars(), map[string]string{"REGISTRY": "ghcr.io/acme", "DOMAIN": "acme.io", "TOKEN": "abc"})
+
+	_, err = ParseTeamVars(map[string]interface{}{"teamVars": map[string]interface{}{"name": "vars"}})
+	assert.Error(t, err, "teamVars.namespace is required")
+}
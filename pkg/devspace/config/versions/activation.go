@@ -0,0 +1,327 @@
+package versions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine"
+	"github.com/loft-sh/devspace/pkg/util/git"
+	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// ActivationResult describes if and why a profile is activated automatically
+type ActivationResult struct {
+	Profile string
+	Active  bool
+
+	// Reasons are the conditions that activated the profile or, if the profile
+	// is not active, the conditions that prevented the activation
+	Reasons []string
+}
+
+type activationRecorderKey struct{}
+
+// ActivationRecorder collects the activation results of the profiles while a config is loaded
+type ActivationRecorder struct {
+	Results []*ActivationResult
+}
+
+// WithActivationRecorder returns a context that records the profile activation results into the recorder
+func WithActivationRecorder(ctx context.Context, recorder *ActivationRecorder) context.Context {
+	return context.WithValue(ctx, activationRecorderKey{}, recorder)
+}
+
+// EvaluateProfileActivations evaluates the activation rules of all profiles in the config
+func EvaluateProfileActivations(ctx context.Context, basePath string, data map[string]interface{}, resolver variable.Resolver, log log.Logger) ([]*ActivationResult, error) {
+	results := []*ActivationResult{}
+
+	// Check if there are profiles
+	if data["profiles"] == nil {
+		return results, nil
+	}
+
+	// get the profiles and parse them
+	profilesData, err := Get(data, "profiles")
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := Parse(profilesData, log)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluator := &activationEvaluator{
+		basePath: basePath,
+		resolver: resolver,
+	}
+	for _, profileConfig := range profiles.Profiles {
+		result := &ActivationResult{Profile: profileConfig.Name}
+		for _, activation := range profileConfig.Activation {
+			active, reasons, err := evaluator.evaluate(ctx, activation)
+			if err != nil {
+				return nil, errors.Wrapf(err, "profile %s", profileConfig.Name)
+			}
+
+			if active {
+				result.Active = true
+				result.Reasons = reasons
+				break
+			}
+
+			result.Reasons = append(result.Reasons, reasons...)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func getActivatedProfiles(ctx context.Context, basePath string, data map[string]interface{}, resolver variable.Resolver, log log.Logger) ([]string, error) {
+	results, err := EvaluateProfileActivations(ctx, basePath, data, resolver, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if recorder, ok := ctx.Value(activationRecorderKey{}).(*ActivationRecorder); ok && recorder != nil {
+		recorder.Results = results
+	}
+
+	// Select which profiles are activated
+	activatedProfiles := []string{}
+	for _, result := range results {
+		if result.Active {
+			log.Debugf("profile %s was automatically activated, because %s", result.Profile, strings.Join(result.Reasons, " and "))
+			activatedProfiles = append(activatedProfiles, result.Profile)
+		}
+	}
+
+	return activatedProfiles, nil
+}
+
+// activationEvaluator evaluates activations and caches the environment information
+// that is shared between them
+type activationEvaluator struct {
+	basePath string
+	resolver variable.Resolver
+
+	kubeContext *string
+	namespace   *string
+	gitBranch   *string
+}
+
+// condition is a single check of an activation
+type condition func(ctx context.Context) (bool, string, error)
+
+// evaluate checks the conditions of the activation in order and stops as soon as the
+// result is clear. It returns the reasons that led to the result.
+func (e *activationEvaluator) evaluate(ctx context.Context, activation *latest.ProfileActivation) (bool, []string, error) {
+	matchAny := activation.Match == latest.ProfileActivationMatchAny
+	conditions := e.conditions(activation)
+	if len(conditions) == 0 {
+		return !matchAny, nil, nil
+	}
+
+	reasons := []string{}
+	for _, condition := range conditions {
+		matched, reason, err := condition(ctx)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if matched == matchAny {
+			return matched, []string{reason}, nil
+		}
+		reasons = append(reasons, reason)
+	}
+	if matchAny {
+		return false, reasons, nil
+	}
+
+	return true, reasons, nil
+}
+
+// conditions returns the conditions of the activation, cheap ones first
+func (e *activationEvaluator) conditions(activation *latest.ProfileActivation) []condition {
+	conditions := []condition{}
+	for _, name := range sortedKeys(activation.Environment) {
+		name, pattern := name, activation.Environment[name]
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			matched, reason, err := matchCondition("env "+name, os.Getenv(name), pattern)
+			return matched, reason, errors.Wrap(err, "error activating profile with env")
+		})
+	}
+	if activation.OS != "" {
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			return matchCondition("os", runtime.GOOS, activation.OS)
+		})
+	}
+	if activation.Arch != "" {
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			return matchCondition("arch", runtime.GOARCH, activation.Arch)
+		})
+	}
+	for _, file := range activation.Files {
+		file := file
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			return e.fileExists(file)
+		})
+	}
+	if activation.GitBranch != "" {
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			return matchCondition("git branch", e.getGitBranch(), activation.GitBranch)
+		})
+	}
+	if activation.KubeContext != "" {
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			kubeContext, err := e.getKubeContext(ctx)
+			if err != nil {
+				return false, "", err
+			}
+
+			return matchCondition("kube context", kubeContext, activation.KubeContext)
+		})
+	}
+	if activation.Namespace != "" {
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			namespace, err := e.getNamespace(ctx)
+			if err != nil {
+				return false, "", err
+			}
+
+			return matchCondition("namespace", namespace, activation.Namespace)
+		})
+	}
+	for _, name := range sortedKeys(activation.Vars) {
+		name, pattern := name, activation.Vars[name]
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			value, err := resolveVariableValue(ctx, name, e.resolver)
+			if err != nil {
+				return false, "", errors.Wrap(err, "error activating profile with vars")
+			}
+
+			matched, reason, err := matchCondition("var "+name, value, pattern)
+			return matched, reason, errors.Wrap(err, "error activating profile with vars")
+		})
+	}
+	if activation.Expression != "" {
+		conditions = append(conditions, func(ctx context.Context) (bool, string, error) {
+			return e.evaluateExpression(ctx, activation.Expression)
+		})
+	}
+
+	return conditions
+}
+
+func matchCondition(subject, value, pattern string) (bool, string, error) {
+	match, err := regexp.MatchString(sanitizeMatchExpression(pattern), value)
+	if err != nil {
+		return false, "", err
+	} else if match {
+		return true, fmt.Sprintf("%s %q matches %s", subject, value, pattern), nil
+	}
+
+	return false, fmt.Sprintf("%s %q doesn't match %s", subject, value, pattern), nil
+}
+
+func (e *activationEvaluator) fileExists(pattern string) (bool, string, error) {
+	path := pattern
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(e.basePath, path)
+	}
+
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return false, "", errors.Wrapf(err, "error activating profile with file %s", pattern)
+	} else if len(matches) == 0 {
+		return false, fmt.Sprintf("file %s doesn't exist", pattern), nil
+	}
+
+	return true, fmt.Sprintf("file %s exists", pattern), nil
+}
+
+func (e *activationEvaluator) evaluateExpression(ctx context.Context, expr string) (bool, string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	envVars := append([]string{expression.DevSpaceSkipPreloadEnv + "=true"}, os.Environ()...)
+	err := engine.ExecuteSimpleShellCommand(ctx, e.basePath, expand.ListEnviron(envVars...), stdout, stderr, nil, expr)
+	if err != nil {
+		if _, ok := interp.IsExitStatus(err); ok {
+			return false, fmt.Sprintf("expression '%s' failed", expr), nil
+		}
+
+		return false, "", fmt.Errorf("error activating profile with expression %s: %v (stdout: %s, stderr: %s)", expr, err, stdout.String(), stderr.String())
+	}
+
+	return true, fmt.Sprintf("expression '%s' succeeded", expr), nil
+}
+
+func (e *activationEvaluator) getGitBranch() string {
+	if e.gitBranch == nil {
+		// configs outside of a git repository have no branch
+		branch, _ := git.GetBranch(e.basePath)
+		e.gitBranch = &branch
+	}
+
+	return *e.gitBranch
+}
+
+// getKubeContext returns the kube context DevSpace uses or the current context of the kube config
+// if no kube client was created yet
+func (e *activationEvaluator) getKubeContext(ctx context.Context) (string, error) {
+	if e.kubeContext == nil {
+		kubeContext, err := resolveVariableValue(ctx, "DEVSPACE_CONTEXT", e.resolver)
+		if err != nil {
+			return "", err
+		}
+		if kubeContext == "" {
+			kubeContext, _ = kubeconfig.NewLoader().GetCurrentContext()
+		}
+
+		e.kubeContext = &kubeContext
+	}
+
+	return *e.kubeContext, nil
+}
+
+// getNamespace returns the namespace DevSpace uses or the namespace of the current context of the kube
+// config if no kube client was created yet
+func (e *activationEvaluator) getNamespace(ctx context.Context) (string, error) {
+	if e.namespace == nil {
+		namespace, err := resolveVariableValue(ctx, "DEVSPACE_NAMESPACE", e.resolver)
+		if err != nil {
+			return "", err
+		}
+		if namespace == "" {
+			namespace, _, _ = kubeconfig.NewLoader().NewConfig().Namespace()
+		}
+
+		e.namespace = &namespace
+	}
+
+	return *e.namespace, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
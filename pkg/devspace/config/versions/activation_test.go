@@ -0,0 +1,95 @@
+package versions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type activationTestCase struct {
+	name       string
+	activation *latest.ProfileActivation
+
+	expectedActive  bool
+	expectedReasons []string
+}
+
+func TestEvaluateActivation(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(""), 0666))
+
+	testCases := []activationTestCase{
+		{
+			name:           "empty",
+			activation:     &latest.ProfileActivation{},
+			expectedActive: true,
+		},
+		{
+			name: "all match",
+			activation: &latest.ProfileActivation{
+				OS:    runtime.GOOS,
+				Files: []string{"Docker*"},
+			},
+			expectedActive: true,
+			expectedReasons: []string{
+				"os \"" + runtime.GOOS + "\" matches " + runtime.GOOS,
+				"file Docker* exists",
+			},
+		},
+		{
+			name: "all stops at first mismatch",
+			activation: &latest.ProfileActivation{
+				Files:      []string{"missing.yaml"},
+				Expression: "exit 1",
+			},
+			expectedReasons: []string{"file missing.yaml doesn't exist"},
+		},
+		{
+			name: "any stops at first match",
+			activation: &latest.ProfileActivation{
+				Files:      []string{"missing.yaml"},
+				Expression: "test -f Dockerfile",
+				Match:      latest.ProfileActivationMatchAny,
+			},
+			expectedActive:  true,
+			expectedReasons: []string{"expression 'test -f Dockerfile' succeeded"},
+		},
+		{
+			name: "any without match",
+			activation: &latest.ProfileActivation{
+				Arch:       "not-an-arch",
+				Expression: "exit 1",
+				Match:      latest.ProfileActivationMatchAny,
+			},
+			expectedReasons: []string{
+				"arch \"" + runtime.GOARCH + "\" doesn't match not-an-arch",
+				"expression 'exit 1' failed",
+			},
+		},
+		{
+			name: "empty any",
+			activation: &latest.ProfileActivation{
+				Match: latest.ProfileActivationMatchAny,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		evaluator := &activationEvaluator{basePath: dir}
+		active, reasons, err := evaluator.evaluate(context.Background(), testCase.activation)
+		assert.NilError(t, err, testCase.name)
+		assert.Equal(t, active, testCase.expectedActive, testCase.name)
+		if testCase.expectedReasons == nil {
+			testCase.expectedReasons = []string{}
+		}
+		if reasons == nil {
+			reasons = []string{}
+		}
+		assert.DeepEqual(t, reasons, testCase.expectedReasons)
+	}
+}
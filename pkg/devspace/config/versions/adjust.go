@@ -54,6 +54,17 @@ func adjustConfig(config *latest.Config) error {
 		}
 		config.Dev = newObjs
 	}
+	if config.Workspaces != nil {
+		newObjs := map[string]*latest.Workspace{}
+		for name, workspace := range config.Workspaces {
+			if workspace == nil {
+				continue
+			}
+			workspace.Name = name
+			newObjs[name] = workspace
+		}
+		config.Workspaces = newObjs
+	}
 	if config.Pipelines != nil {
 		newObjs := map[string]*latest.Pipeline{}
 		for name, pipeline := range config.Pipelines {
@@ -76,6 +87,17 @@ func adjustConfig(config *latest.Config) error {
 		}
 		config.Dependencies = newObjs
 	}
+	if config.Modules != nil {
+		newObjs := map[string]*latest.Module{}
+		for name, module := range config.Modules {
+			if module == nil {
+				continue
+			}
+			module.Name = name
+			newObjs[name] = module
+		}
+		config.Modules = newObjs
+	}
 	if config.Images != nil {
 		newObjs := map[string]*latest.Image{}
 		for k, v := range config.Images {
@@ -56,6 +56,11 @@ type Config struct {
 	// into multiple files and reuse those through git, a remote url or common local path.
 	Imports []Import `yaml:"imports,omitempty" json:"imports,omitempty"`
 
+	// Modules instantiate reusable config units from a local path or git repository with the given inputs. The images,
+	// deployments, dev configs, pipelines, functions and commands of a module are added with the module name as prefix,
+	// e.g. the image api of the module orders can be referenced as orders.api.
+	Modules map[string]*Module `yaml:"modules,omitempty" json:"modules,omitempty"`
+
 	// Functions are POSIX functions that can be used within pipelines. Those functions can also be imported by
 	// imports.
 	Functions map[string]string `yaml:"functions,omitempty" json:"functions,omitempty"`
@@ -89,9 +94,20 @@ type Config struct {
 	// - restart the container on file changes
 	Dev map[string]*DevPod `yaml:"dev,omitempty" json:"dev,omitempty"`
 
+	// Workspaces are named persistent volumes that are scoped to the current user. Workspaces survive pod replacements
+	// as well as devspace purge and can be mounted by multiple dev configurations, even across projects. They are useful
+	// to persist caches such as the Go module cache or the shell history and can be managed via devspace workspace.
+	// The current user is the local user name plus an id of the machine, set DEVSPACE_WORKSPACE_USER to override it.
+	Workspaces map[string]*Workspace `yaml:"workspaces,omitempty" json:"workspaces,omitempty"`
+
 	// Vars are config variables that can be used inside other config sections to replace certain values dynamically
 	Vars map[string]*Variable `yaml:"vars,omitempty" json:"vars,omitempty"`
 
+	// TeamVars configures a variable store that is shared by the whole team. Its values are used for variables
+	// that are neither set via flag, environment variable nor the personal cache of a developer and can be
+	// changed via devspace set var --scope team.
+	TeamVars *TeamVars `yaml:"teamVars,omitempty" json:"teamVars,omitempty"`
+
 	// Commands are custom commands that can be executed via 'devspace run COMMAND'. These commands are run within a pseudo bash
 	// that also allows executing special commands such as run_watch or is_equal.
 	Commands map[string]*CommandConfig `yaml:"commands,omitempty" json:"commands,omitempty"`
@@ -133,6 +149,52 @@ type Import struct {
 	SourceConfig `yaml:",inline" json:",inline"`
 }
 
+// Module instantiates a module with the given inputs
+type Module struct {
+	// Name of the module, will be filled automatically
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// Enabled specifies if the module should be enabled
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// SourceConfig defines where the module definition is located
+	SourceConfig `yaml:",inline" json:",inline"`
+
+	// Inputs are the values for the inputs that are defined by the module
+	Inputs map[string]interface{} `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+
+	// Outputs are the resolved outputs of the module, will be filled automatically. They can be used
+	// in pipelines via ${runtime.modules.NAME.OUTPUT}
+	Outputs map[string]interface{} `yaml:"outputs,omitempty" json:"outputs,omitempty" jsonschema:"-"`
+}
+
+// ModuleInput defines an input of a module in the module definition
+type ModuleInput struct {
+	// Description describes the input
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Required makes sure the input is set by every module that uses the definition
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+
+	// Default is the value that is used if the input is not set
+	Default interface{} `yaml:"default,omitempty" json:"default,omitempty"`
+
+	// Type is the type of the input value, see the type of vars for the possible values
+	Type VariableType `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Enum are the allowed values of the input
+	Enum []interface{} `yaml:"enum,omitempty" json:"enum,omitempty"`
+
+	// Min is the minimum of the input value, see the min of vars for how it is interpreted
+	Min interface{} `yaml:"min,omitempty" json:"min,omitempty"`
+
+	// Max is the maximum of the input value, see the max of vars for how it is interpreted
+	Max interface{} `yaml:"max,omitempty" json:"max,omitempty"`
+
+	// Schema is a JSON schema the input value has to match
+	Schema map[string]interface{} `yaml:"schema,omitempty" json:"schema,omitempty"`
+}
+
 // Pipeline defines what DevSpace should do. A pipeline consists of one or more
 // jobs that are run in parallel and can depend on each other. Each job consists
 // of one or more conditional steps that are executed in order.
@@ -150,6 +212,36 @@ type Pipeline struct {
 	// ContinueOnError will not fail the whole job and pipeline if
 	// a call within the step fails.
 	ContinueOnError bool `yaml:"continueOnError,omitempty" json:"continueOnError,omitempty"`
+
+	// Outputs are the values this pipeline returns to the pipeline or project that runs it. They
+	// are set via set_output and can be used via ${runtime.pipelines.NAME.outputs.OUTPUT} or, if the
+	// pipeline is run as dependency, via ${runtime.dependencies.NAME.outputs.OUTPUT}
+	Outputs []PipelineOutput `yaml:"outputs,omitempty" json:"outputs,omitempty"`
+
+	// Matrix runs the pipeline once per combination of kube context and namespace if
+	// the pipeline is started via devspace deploy, devspace run-pipeline etc.
+	Matrix *PipelineMatrix `yaml:"matrix,omitempty" json:"matrix,omitempty"`
+}
+
+// PipelineMatrix defines the kube contexts and namespaces a pipeline is run in
+type PipelineMatrix struct {
+	// Contexts are the kube contexts to run the pipeline in. Defaults to the current kube context
+	Contexts []string `yaml:"contexts,omitempty" json:"contexts,omitempty"`
+
+	// Namespaces are the namespaces to run the pipeline in. Defaults to the default namespace
+	Namespaces []string `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
+
+	// MaxConcurrent is the maximum number of combinations run in parallel (0 for infinite)
+	MaxConcurrent int `yaml:"maxConcurrent,omitempty" json:"maxConcurrent,omitempty"`
+}
+
+// PipelineOutput defines a value a pipeline returns
+type PipelineOutput struct {
+	// Name is the name of the output
+	Name string `yaml:"name" json:"name" jsonschema:"required"`
+
+	// Description describes the value of the output
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 }
 
 // PipelineFlag defines an extra pipeline flag
@@ -906,6 +998,11 @@ type DevPod struct {
 	LabelSelector map[string]string `yaml:"labelSelector,omitempty" json:"labelSelector,omitempty" jsonschema_extras:"group=selector"`
 	// Namespace where to select the pod
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty" jsonschema_extras:"group=selector"`
+	// Replicas is the amount of replicas DevSpace should keep for the dev pod. If greater than 1,
+	// DevSpace will replace the pod, upload file changes to all replicas and only download changes
+	// from the primary replica. Port forwarding uses the primary replica, DevSpace asks which replica
+	// terminal, attach and ssh should connect to. Replicas only select pods of the replaced workload.
+	Replicas int `yaml:"replicas,omitempty" json:"replicas,omitempty" jsonschema_extras:"group=selector"`
 
 	// DevContainer can either be defined inline if the pod only has a single container or
 	// containers can be used to define configurations for multiple containers in the same
@@ -925,6 +1022,10 @@ type DevPod struct {
 	// Open defines urls that should be opened as soon as they are reachable
 	Open []*OpenConfig `yaml:"open,omitempty" json:"open,omitempty" jsonschema_extras:"group=workflows_background,group_name=Background Dev Workflows"`
 
+	// Intercept temporarily routes the traffic of a Kubernetes service to a port on the local computer
+	// through the selected pod. DevSpace will restore the service as soon as dev mode is stopped.
+	Intercept *Intercept `yaml:"intercept,omitempty" json:"intercept,omitempty" jsonschema_extras:"group=ports"`
+
 	Containers map[string]*DevContainer `yaml:"containers,omitempty" json:"containers,omitempty" jsonschema_extras:"group=selector"`
 }
 
@@ -960,6 +1061,9 @@ type DevContainer struct {
 	Sync []*SyncConfig `yaml:"sync,omitempty" json:"sync,omitempty" jsonschema_extras:"group=sync,group_name=File Sync"`
 	// PersistPaths allows you to persist certain paths within this container with a persistent volume claim
 	PersistPaths []PersistentPath `yaml:"persistPaths,omitempty" json:"persistPaths,omitempty"`
+	// Workspaces mounts named workspaces into this container. In contrast to persistPaths, workspaces are
+	// shared between dev configurations and are not deleted if the pod replacement is reverted.
+	Workspaces []*WorkspaceMount `yaml:"workspaces,omitempty" json:"workspaces,omitempty"`
 
 	// Terminal allows you to tell DevSpace to open a terminal with screen support to this container
 	Terminal *Terminal `yaml:"terminal,omitempty" json:"terminal,omitempty" jsonschema_extras:"group=workflows,group_name=Foreground Dev Workflows"`
@@ -974,8 +1078,47 @@ type DevContainer struct {
 	// RestartHelper holds restart helper specific configuration. The restart helper is used to delay starting of
 	// the container and restarting it and is injected via an annotation in the replaced pod.
 	RestartHelper *RestartHelper `yaml:"restartHelper,omitempty" json:"restartHelper,omitempty" jsonschema_extras:"group=workflows_background"`
+	// Debug starts the container command through a remote debugger, forwards the debugger port
+	// and generates matching IDE launch configurations
+	Debug *Debug `yaml:"debug,omitempty" json:"debug,omitempty" jsonschema_extras:"group=workflows_background"`
 }
 
+// Debug holds the remote debugger configuration for a dev container
+type Debug struct {
+	// Enabled can be used to disable the debugger without removing the configuration
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	// Preset is the language specific debugger DevSpace should use. For go the debugger is delve (dlv), for
+	// python debugpy, for node the node inspector and for java the JDWP agent. The debugger itself has to
+	// be available in the dev image.
+	Preset DebugPreset `yaml:"preset" json:"preset" jsonschema:"required,enum=go,enum=python,enum=node,enum=java"`
+	// Port is the port the debugger listens on inside the container. Defaults to 2345 for go, 5678 for
+	// python, 9229 for node and 5005 for java
+	Port int `yaml:"port,omitempty" json:"port,omitempty"`
+	// LocalPort is the local port the debugger port is forwarded to. Defaults to port
+	LocalPort int `yaml:"localPort,omitempty" json:"localPort,omitempty"`
+	// Wait tells the debugger to halt the program until a debug client is attached
+	Wait bool `yaml:"wait,omitempty" json:"wait,omitempty"`
+	// LaunchConfigs are the IDE launch configurations DevSpace should generate. Defaults to vscode
+	LaunchConfigs []DebugLaunchConfig `yaml:"launchConfigs,omitempty" json:"launchConfigs,omitempty" jsonschema:"enum=vscode,enum=jetbrains,enum=none"`
+}
+
+type DebugPreset string
+
+const (
+	DebugPresetGo     DebugPreset = "go"
+	DebugPresetPython DebugPreset = "python"
+	DebugPresetNode   DebugPreset = "node"
+	DebugPresetJava   DebugPreset = "java"
+)
+
+type DebugLaunchConfig string
+
+const (
+	DebugLaunchConfigVSCode    DebugLaunchConfig = "vscode"
+	DebugLaunchConfigJetBrains DebugLaunchConfig = "jetbrains"
+	DebugLaunchConfigNone      DebugLaunchConfig = "none"
+)
+
 type RestartHelper struct {
 	// Path defines the path to the restart helper that might be used if certain config
 	// options are enabled
@@ -1084,6 +1227,30 @@ type PersistentPath struct {
 	InitContainer *PersistentPathInitContainer `yaml:"initContainer,omitempty" json:"initContainer,omitempty"`
 }
 
+// Workspace defines a named persistent volume that can be shared between dev configurations
+type Workspace struct {
+	// Name of the workspace, will be filled automatically
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Size is the size of the created persistent volume in Kubernetes size notation like 5Gi. Defaults to 10Gi
+	Size string `yaml:"size,omitempty" json:"size,omitempty"`
+	// StorageClassName is the storage type DevSpace should use for this persistent volume
+	StorageClassName string `yaml:"storageClassName,omitempty" json:"storageClassName,omitempty"`
+	// AccessModes are the access modes DevSpace should use for the persistent volume. Defaults to ReadWriteOnce
+	AccessModes []string `yaml:"accessModes,omitempty" json:"accessModes,omitempty"`
+}
+
+// WorkspaceMount defines where a workspace should be mounted within a container
+type WorkspaceMount struct {
+	// Name is the name of the workspace to mount
+	Name string `yaml:"name" json:"name" jsonschema:"required"`
+	// Path is the container path where the workspace should be mounted
+	Path string `yaml:"path" json:"path" jsonschema:"required"`
+	// SubPath is the path within the workspace that should be mounted. Defaults to the root of the workspace
+	SubPath string `yaml:"subPath,omitempty" json:"subPath,omitempty"`
+	// ReadOnly mounts the workspace read only
+	ReadOnly bool `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+}
+
 // PersistentPathInitContainer defines additional options for the persistent path init container
 type PersistentPathInitContainer struct {
 	// Resources are the resources used by the persistent path init container
@@ -1104,6 +1271,35 @@ type PortMapping struct {
 	BindAddress string `yaml:"bindAddress,omitempty" json:"bindAddress,omitempty"`
 }
 
+// Intercept defines how the traffic of a service should be routed to the local computer
+type Intercept struct {
+	// Service is the name of the Kubernetes service whose traffic should be intercepted. The service
+	// needs to be in the same namespace as the selected pod.
+	Service string `yaml:"service" json:"service" jsonschema:"required"`
+
+	// Container is the container of the selected pod that should receive the intercepted traffic.
+	// Defaults to the container of the dev configuration.
+	Container string `yaml:"container,omitempty" json:"container,omitempty"`
+
+	// Ports are the service ports that should be intercepted
+	Ports []*InterceptPort `yaml:"ports" json:"ports" jsonschema:"required"`
+
+	// Headers restricts the interception to HTTP requests that contain all of the given headers.
+	// All other requests will still be served by the selected pod. If empty, all traffic is intercepted.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// InterceptPort defines a single service port that should be intercepted
+type InterceptPort struct {
+	// Port is a port mapping in the form localPort:servicePort. If only port is specified,
+	// local and service port are the same.
+	Port string `yaml:"port" json:"port"`
+
+	// ContainerPort is the port DevSpace will listen on inside the container for the intercepted
+	// traffic. Defaults to 15800 plus the index of this port.
+	ContainerPort int `yaml:"containerPort,omitempty" json:"containerPort,omitempty"`
+}
+
 // OpenConfig defines what to open after services have been started
 type OpenConfig struct {
 	// URL is the url to open in the browser after it is available
@@ -1542,13 +1738,13 @@ type Variable struct {
 	Name string `yaml:"name,omitempty" json:"name,omitempty"`
 
 	// Value is a shortcut for using source: none and default: my-value
-	Value interface{} `yaml:"value,omitempty" json:"value,omitempty" jsonschema:"oneof_type=string;integer;boolean" jsonschema_extras:"group=static,group_name=Static Value"`
+	Value interface{} `yaml:"value,omitempty" json:"value,omitempty" jsonschema:"oneof_type=string;integer;boolean;array;object" jsonschema_extras:"group=static,group_name=Static Value"`
 
 	// Question can be used to define a custom question if the variable was not yet used
 	Question string `yaml:"question,omitempty" json:"question,omitempty" jsonschema_extras:"group=question,group_name=Value From Input (Question)"`
 
 	// Default is the default value the variable should have if not set by the user
-	Default interface{} `yaml:"default,omitempty" json:"default,omitempty" jsonschema:"oneof_type=string;integer;boolean" jsonschema_extras:"group=question"`
+	Default interface{} `yaml:"default,omitempty" json:"default,omitempty" jsonschema:"oneof_type=string;integer;boolean;array;object" jsonschema_extras:"group=question"`
 
 	// Options are options that can be selected when the variable question is asked
 	Options []string `yaml:"options,omitempty" json:"options,omitempty" jsonschema_extras:"group=question"`
@@ -1565,6 +1761,27 @@ type Variable struct {
 	// NoCache can be used to prompt the user on every run for this variable
 	NoCache bool `yaml:"noCache,omitempty" json:"noCache,omitempty" jsonschema_extras:"group=question"`
 
+	// Type is the type of the variable value. Values from flags, environment variables, commands and questions
+	// are converted to this type and loading the config fails if that is not possible. List and map values are
+	// inserted as real structures if the variable is the only content of a config value.
+	Type VariableType `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"enum=string,enum=int,enum=bool,enum=list,enum=map,enum=duration,enum=semver" jsonschema_extras:"group=validation,group_name=Type & Validation"`
+
+	// Enum are the allowed values of the variable. If the variable is asked as question and no options
+	// are defined, the enum values are used as options.
+	Enum []interface{} `yaml:"enum,omitempty" json:"enum,omitempty" jsonschema_extras:"group=validation"`
+
+	// Min is the minimum of the variable value. For int variables this is the minimum number, for duration
+	// and semver variables the minimum duration (e.g. 10s) or version and for string, list and map variables
+	// the minimum length.
+	Min interface{} `yaml:"min,omitempty" json:"min,omitempty" jsonschema:"oneof_type=string;number" jsonschema_extras:"group=validation"`
+
+	// Max is the maximum of the variable value. See min for how it is interpreted for the different types.
+	Max interface{} `yaml:"max,omitempty" json:"max,omitempty" jsonschema:"oneof_type=string;number" jsonschema_extras:"group=validation"`
+
+	// Schema is a JSON schema the variable value has to match. This is mostly useful to validate the
+	// structure of list and map variables.
+	Schema map[string]interface{} `yaml:"schema,omitempty" json:"schema,omitempty" jsonschema_extras:"group=validation"`
+
 	// Command is the command how to retrieve the variable. If args is omitted, command is parsed as a shell
 	// command.
 	Command string `yaml:"command,omitempty" json:"command,omitempty" jsonschema_extras:"group=execution,group_name=Value From Command"`
@@ -1579,10 +1796,42 @@ type Variable struct {
 	// AlwaysResolve makes sure this variable will always be resolved and not only if it is used somewhere. Defaults to false.
 	AlwaysResolve *bool `yaml:"alwaysResolve,omitempty" json:"alwaysResolve,omitempty"`
 
+	// Secret loads the variable from a secret provider. Secret values are never cached and are redacted
+	// from the log output.
+	Secret *VariableSecret `yaml:"secret,omitempty" json:"secret,omitempty" jsonschema_extras:"group=secret,group_name=Value From Secret"`
+
 	// Source defines where the variable should be taken from
-	Source VariableSource `yaml:"source,omitempty" json:"source,omitempty" jsonschema:"enum=all,enum=env,enum=input,enum=command,enum=none"`
+	Source VariableSource `yaml:"source,omitempty" json:"source,omitempty" jsonschema:"enum=all,enum=env,enum=input,enum=command,enum=secret,enum=none"`
+}
+
+// VariableSecret defines where a secret variable should be loaded from
+type VariableSecret struct {
+	// Provider is the secret provider to use. sops decrypts a SOPS (e.g. age) encrypted yaml or json file with
+	// the sops binary, kubernetes reads a key from a Kubernetes secret and dotenv reads a dotenv file.
+	Provider VariableSecretProvider `yaml:"provider" json:"provider" jsonschema:"required,enum=sops,enum=kubernetes,enum=dotenv"`
+
+	// Path is the path to the encrypted file or dotenv file relative to the devspace.yaml
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// Name is the name of the Kubernetes secret
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// Namespace is the namespace of the Kubernetes secret. Defaults to the current namespace
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Key is the key of the value within the file or secret. Nested keys in sops files can be separated
+	// by dots. Defaults to the variable name
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
 }
 
+type VariableSecretProvider string
+
+const (
+	VariableSecretProviderSOPS       VariableSecretProvider = "sops"
+	VariableSecretProviderKubernetes VariableSecretProvider = "kubernetes"
+	VariableSecretProviderDotEnv     VariableSecretProvider = "dotenv"
+)
+
 func (v *Variable) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// try string next
 	varString := ""
@@ -1624,6 +1873,31 @@ type VariableCommand struct {
 	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
 }
 
+// VariableType is the type of a variable value
+type VariableType string
+
+// List of values that type can take
+const (
+	VariableTypeString   VariableType = "string"
+	VariableTypeInt      VariableType = "int"
+	VariableTypeBool     VariableType = "bool"
+	VariableTypeList     VariableType = "list"
+	VariableTypeMap      VariableType = "map"
+	VariableTypeDuration VariableType = "duration"
+	VariableTypeSemver   VariableType = "semver"
+)
+
+// TeamVars defines where the shared variables of a team are stored
+type TeamVars struct {
+	// Namespace is the namespace of the Kubernetes secret that holds the team variables. Variables cannot
+	// be used here, because this option is needed to resolve variables.
+	Namespace string `yaml:"namespace" json:"namespace" jsonschema:"required"`
+
+	// Name is the name of the Kubernetes secret that holds the team variables. Defaults to
+	// devspace-team-vars-NAME where NAME is the name of the config
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+}
+
 // VariableSource is type of a variable source
 type VariableSource string
 
@@ -1634,6 +1908,7 @@ const (
 	VariableSourceEnv     VariableSource = "env"
 	VariableSourceInput   VariableSource = "input"
 	VariableSourceCommand VariableSource = "command"
+	VariableSourceSecret  VariableSource = "secret"
 	VariableSourceNone    VariableSource = "none"
 )
 
@@ -1724,8 +1999,43 @@ type ProfileActivation struct {
 	// Vars defines key/value pairs where the key is the name of the variable and the value is a regular expression used to match the variable's value.
 	// When multiple keys are specified, they must all evaluate to true to activate the profile.
 	Vars map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+
+	// KubeContext is a regular expression that is matched against the name of the current kube context, e.g. staging-.*
+	KubeContext string `yaml:"kubeContext,omitempty" json:"kubeContext,omitempty"`
+
+	// Namespace is a regular expression that is matched against the current namespace
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// GitBranch is a regular expression that is matched against the git branch of the repository the config is in
+	GitBranch string `yaml:"gitBranch,omitempty" json:"gitBranch,omitempty"`
+
+	// Files are paths or glob patterns relative to the config that all need to exist
+	Files []string `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// OS is a regular expression that is matched against the operating system DevSpace runs on (e.g. linux, darwin or windows)
+	OS string `yaml:"os,omitempty" json:"os,omitempty"`
+
+	// Arch is a regular expression that is matched against the cpu architecture DevSpace runs on (e.g. amd64 or arm64)
+	Arch string `yaml:"arch,omitempty" json:"arch,omitempty"`
+
+	// Expression is a shell expression that is executed with the DevSpace pipeline engine in the config directory. The
+	// condition is true if the expression exits with code 0, e.g. is_equal $USER admin or test -f .env
+	Expression string `yaml:"expression,omitempty" json:"expression,omitempty"`
+
+	// Match defines if all (default) or any of the conditions of this activation need to be true to activate the profile.
+	// If a profile has multiple activations, the profile is activated as soon as one of them is true.
+	Match ProfileActivationMatch `yaml:"match,omitempty" json:"match,omitempty" jsonschema:"enum=all,enum=any"`
 }
 
+// ProfileActivationMatch defines how the conditions of a profile activation are combined
+type ProfileActivationMatch string
+
+// List of values that ProfileActivationMatch can take
+const (
+	ProfileActivationMatchAll ProfileActivationMatch = "all"
+	ProfileActivationMatchAny ProfileActivationMatch = "any"
+)
+
 // PatchTarget describes a config patch and how it should be applied
 type PatchTarget struct {
 	// Target describes where to apply a config patch
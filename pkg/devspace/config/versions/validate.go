@@ -3,12 +3,15 @@ package versions
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"unicode"
 
 	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
 	"gopkg.in/yaml.v3"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	jsonyaml "sigs.k8s.io/yaml"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -36,67 +39,65 @@ func ValidContainerArch(arch latest.ContainerArchitecture) bool {
 		arch == latest.ContainerArchitectureArm64
 }
 
-func Validate(config *latest.Config) error {
-	if config.Name == "" {
-		return fmt.Errorf("you need to specify a name for your devspace.yaml")
-	}
-	if encoding.IsUnsafeName(config.Name) {
-		return fmt.Errorf("name has to match the following regex: %v", encoding.UnsafeNameRegEx.String())
-	}
-
-	err := validateRequire(config)
-	if err != nil {
-		return err
-	}
-
-	err = validateVars(config.Vars)
-	if err != nil {
-		return err
-	}
-
-	err = validatePipelines(config)
-	if err != nil {
-		return err
-	}
-
-	err = validateImages(config)
-	if err != nil {
-		return err
-	}
+func ValidDebugPreset(preset latest.DebugPreset) bool {
+	return preset == latest.DebugPresetGo ||
+		preset == latest.DebugPresetPython ||
+		preset == latest.DebugPresetNode ||
+		preset == latest.DebugPresetJava
+}
 
-	err = validateDev(config)
-	if err != nil {
-		return err
-	}
+// pipelineOutputNameRegEx restricts output names to names that can be used as shell variables
+var pipelineOutputNameRegEx = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validators are all checks that are run by Validate in order, each of them returns all
+// errors it found
+var validators = []func(config *latest.Config) []error{
+	validateName,
+	validateRequire,
+	func(config *latest.Config) []error { return validateVars(config.Vars) },
+	validateTeamVars,
+	validatePipelines,
+	validateImages,
+	validateDev,
+	validateWorkspaces,
+	validateHooks,
+	validateDeployments,
+	validatePullSecrets,
+	validateCommands,
+	validateDependencies,
+	func(config *latest.Config) []error { return validateFunctions(config.Functions) },
+	validateProfiles,
+}
 
-	err = validateHooks(config)
-	if err != nil {
-		return err
+// Validate validates the config and returns the first error found
+func Validate(config *latest.Config) error {
+	for _, validator := range validators {
+		errs := validator(config)
+		if len(errs) > 0 {
+			return errs[0]
+		}
 	}
 
-	err = validateDeployments(config)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	err = validatePullSecrets(config)
-	if err != nil {
-		return err
+// ValidateAll runs all validations and returns every error found instead of stopping
+// at the first one
+func ValidateAll(config *latest.Config) []error {
+	errs := []error{}
+	for _, validator := range validators {
+		errs = append(errs, validator(config)...)
 	}
 
-	err = validateCommands(config)
-	if err != nil {
-		return err
-	}
+	return errs
+}
 
-	err = validateDependencies(config)
-	if err != nil {
-		return err
+func validateName(config *latest.Config) []error {
+	if config.Name == "" {
+		return []error{fmt.Errorf("you need to specify a name for your devspace.yaml")}
 	}
-
-	err = validateFunctions(config.Functions)
-	if err != nil {
-		return err
+	if encoding.IsUnsafeName(config.Name) {
+		return []error{fmt.Errorf("name has to match the following regex: %v", encoding.UnsafeNameRegEx.String())}
 	}
 
 	return nil
@@ -115,94 +116,225 @@ func isReservedFunctionName(name string) bool {
 	return false
 }
 
-func validateFunctions(functions map[string]string) error {
+func validateFunctions(functions map[string]string) []error {
+	errs := []error{}
 	for name := range functions {
 		if isReservedFunctionName(name) {
-			return fmt.Errorf("you cannot use '%s' as a function name as its an internally used special function. Please choose another name", name)
+			errs = append(errs, fmt.Errorf("you cannot use '%s' as a function name as its an internally used special function. Please choose another name", name))
 		}
 	}
 
-	return nil
+	return errs
+}
+
+func validateProfiles(config *latest.Config) []error {
+	errs := []error{}
+	for i, profile := range config.Profiles {
+		for j, activation := range profile.Activation {
+			if activation.Match != "" && activation.Match != latest.ProfileActivationMatchAll && activation.Match != latest.ProfileActivationMatchAny {
+				errs = append(errs, fmt.Errorf("profiles[%d].activation[%d].match %s is invalid. Please choose one of all or any", i, j, activation.Match))
+			}
+
+			patterns := map[string]string{
+				"kubeContext": activation.KubeContext,
+				"namespace":   activation.Namespace,
+				"gitBranch":   activation.GitBranch,
+				"os":          activation.OS,
+				"arch":        activation.Arch,
+			}
+			for name, pattern := range activation.Environment {
+				patterns["env."+name] = pattern
+			}
+			for name, pattern := range activation.Vars {
+				patterns["vars."+name] = pattern
+			}
+			for _, field := range sortedKeys(patterns) {
+				if patterns[field] == "" {
+					continue
+				}
+
+				_, err := regexp.Compile(sanitizeMatchExpression(patterns[field]))
+				if err != nil {
+					errs = append(errs, errors.Wrapf(err, "profiles[%d].activation[%d].%s is not a valid regular expression", i, j, field))
+				}
+			}
+		}
+	}
+
+	return errs
 }
 
-func validateVars(vars map[string]*latest.Variable) error {
+func validateVars(vars map[string]*latest.Variable) []error {
+	errs := []error{}
 	for i, v := range vars {
 		if encoding.IsUnsafeUpperName(v.Name) {
-			return fmt.Errorf("vars.%s has to match the following regex: %v", i, encoding.UnsafeUpperNameRegEx.String())
+			errs = append(errs, fmt.Errorf("vars.%s has to match the following regex: %v", i, encoding.UnsafeUpperNameRegEx.String()))
+		}
+		if v.Source == latest.VariableSourceSecret && v.Secret == nil {
+			errs = append(errs, fmt.Errorf("vars.%s.secret is required if source is %s", i, latest.VariableSourceSecret))
+		}
+		if v.Secret != nil {
+			if v.Source != latest.VariableSourceDefault && v.Source != latest.VariableSourceSecret {
+				errs = append(errs, fmt.Errorf("vars.%s.secret cannot be used with source %s", i, v.Source))
+			}
+			switch v.Secret.Provider {
+			case latest.VariableSecretProviderSOPS:
+				if v.Secret.Path == "" {
+					errs = append(errs, fmt.Errorf("vars.%s.secret.path is required for provider %s", i, v.Secret.Provider))
+				}
+			case latest.VariableSecretProviderKubernetes:
+				if v.Secret.Name == "" {
+					errs = append(errs, fmt.Errorf("vars.%s.secret.name is required for provider %s", i, v.Secret.Provider))
+				}
+			case latest.VariableSecretProviderDotEnv:
+			case "":
+				errs = append(errs, fmt.Errorf("vars.%s.secret.provider is required", i))
+			}
+		}
+		if v.Type != "" && !ValidVariableType(v.Type) {
+			errs = append(errs, fmt.Errorf("vars.%s.type %s is invalid. Please choose one of string, int, bool, list, map, duration or semver", i, v.Type))
+		}
+		if v.Type == latest.VariableTypeBool && (v.Min != nil || v.Max != nil) {
+			errs = append(errs, fmt.Errorf("vars.%s.min and vars.%s.max cannot be used with type %s", i, i, v.Type))
+		}
+		if len(v.Schema) > 0 {
+			_, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(v.Schema))
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "vars.%s.schema is invalid", i))
+			}
 		}
 	}
 
+	return errs
+}
+
+func ValidVariableType(varType latest.VariableType) bool {
+	return varType == latest.VariableTypeString ||
+		varType == latest.VariableTypeInt ||
+		varType == latest.VariableTypeBool ||
+		varType == latest.VariableTypeList ||
+		varType == latest.VariableTypeMap ||
+		varType == latest.VariableTypeDuration ||
+		varType == latest.VariableTypeSemver
+}
+
+func validateTeamVars(config *latest.Config) []error {
+	if config.TeamVars == nil {
+		return nil
+	} else if config.TeamVars.Namespace == "" {
+		return []error{fmt.Errorf("teamVars.namespace is required")}
+	} else if strings.Contains(config.TeamVars.Namespace+config.TeamVars.Name, "${") {
+		return []error{fmt.Errorf("teamVars cannot use variables, because it is needed to resolve variables")}
+	}
+
 	return nil
 }
 
-func validateRequire(config *latest.Config) error {
+func validateRequire(config *latest.Config) []error {
+	errs := []error{}
 	for index, plugin := range config.Require.Plugins {
 		if plugin.Name == "" {
-			return errors.Errorf("require.plugins[%d].name is required", index)
+			errs = append(errs, errors.Errorf("require.plugins[%d].name is required", index))
 		}
 		if plugin.Version == "" {
-			return errors.Errorf("require.plugins[%d].version is required", index)
+			errs = append(errs, errors.Errorf("require.plugins[%d].version is required", index))
 		}
 	}
 
 	for index, command := range config.Require.Commands {
 		if command.Name == "" {
-			return errors.Errorf("require.commands[%d].name is required", index)
+			errs = append(errs, errors.Errorf("require.commands[%d].name is required", index))
 		}
 		if command.Version == "" {
-			return errors.Errorf("require.commands[%d].version is required", index)
+			errs = append(errs, errors.Errorf("require.commands[%d].version is required", index))
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func validatePipelines(config *latest.Config) error {
-	for name := range config.Pipelines {
-		if encoding.IsUnsafeName(name) {
-			return fmt.Errorf("pipelines.%s has to match the following regex: %v", name, encoding.UnsafeNameRegEx.String())
+// isUnsafeModuleName checks the name with isUnsafe. Names that were added by a module are
+// prefixed with the module name, e.g. orders.api, this form is only allowed for the enabled
+// modules of the config.
+func isUnsafeModuleName(config *latest.Config, name string, isUnsafe func(string) bool) bool {
+	if module, name, ok := strings.Cut(name, "."); ok {
+		moduleConfig, ok := config.Modules[module]
+		if !ok || (moduleConfig != nil && moduleConfig.Enabled != nil && !*moduleConfig.Enabled) {
+			return true
 		}
+
+		return isUnsafe(name)
 	}
 
-	return nil
+	return isUnsafe(name)
+}
+
+func validatePipelines(config *latest.Config) []error {
+	errs := []error{}
+	for name, pipeline := range config.Pipelines {
+		if isUnsafeModuleName(config, name, encoding.IsUnsafeName) {
+			errs = append(errs, fmt.Errorf("pipelines.%s has to match the following regex: %v", name, encoding.UnsafeNameRegEx.String()))
+		}
+		if pipeline == nil {
+			continue
+		}
+
+		outputs := map[string]bool{}
+		for index, output := range pipeline.Outputs {
+			if !pipelineOutputNameRegEx.MatchString(output.Name) {
+				errs = append(errs, fmt.Errorf("pipelines.%s.outputs[%d].name has to match the following regex: %v", name, index, pipelineOutputNameRegEx.String()))
+			} else if outputs[output.Name] {
+				errs = append(errs, fmt.Errorf("pipelines.%s.outputs[%d].name: output %s is defined twice", name, index, output.Name))
+			}
+			outputs[output.Name] = true
+		}
+
+		if pipeline.Matrix != nil && pipeline.Matrix.MaxConcurrent < 0 {
+			errs = append(errs, fmt.Errorf("pipelines.%s.matrix.maxConcurrent must be greater or equal 0", name))
+		}
+	}
+
+	return errs
 }
 
-func validateDependencies(config *latest.Config) error {
+func validateDependencies(config *latest.Config) []error {
+	errs := []error{}
 	for name, dep := range config.Dependencies {
 		if encoding.IsUnsafeName(name) {
-			return fmt.Errorf("dependencies.%s has to match the following regex: %v", name, encoding.UnsafeNameRegEx.String())
+			errs = append(errs, fmt.Errorf("dependencies.%s has to match the following regex: %v", name, encoding.UnsafeNameRegEx.String()))
 		}
 		if dep.Source == nil {
-			return errors.Errorf("dependencies.%s.source is required", name)
-		}
-		if dep.Source.Git == "" && dep.Source.Path == "" {
-			return errors.Errorf("dependencies.%s.git or dependencies[%s].path is required", name, name)
+			errs = append(errs, errors.Errorf("dependencies.%s.source is required", name))
+		} else if dep.Source.Git == "" && dep.Source.Path == "" {
+			errs = append(errs, errors.Errorf("dependencies.%s.git or dependencies[%s].path is required", name, name))
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func validateCommands(config *latest.Config) error {
+func validateCommands(config *latest.Config) []error {
+	errs := []error{}
 	for key, command := range config.Commands {
-		if encoding.IsUnsafeCommandName(command.Name) {
-			return fmt.Errorf("commands.%s has to match the following regex: %v", command.Name, encoding.UnsafeNameRegEx.String())
+		if isUnsafeModuleName(config, command.Name, encoding.IsUnsafeCommandName) {
+			errs = append(errs, fmt.Errorf("commands.%s has to match the following regex: %v", command.Name, encoding.UnsafeNameRegEx.String()))
 		}
 		if command.Command == "" {
-			return errors.Errorf("commands.%s.command is required", key)
+			errs = append(errs, errors.Errorf("commands.%s.command is required", key))
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func validateHooks(config *latest.Config) error {
+func validateHooks(config *latest.Config) []error {
+	errs := []error{}
 	for index, hookConfig := range config.Hooks {
 		if len(hookConfig.Events) == 0 {
-			return errors.Errorf("hooks[%d].events is required", index)
+			errs = append(errs, errors.Errorf("hooks[%d].events is required", index))
 		}
 		if hookConfig.Command == "" && hookConfig.Upload == nil && hookConfig.Download == nil && hookConfig.Logs == nil && hookConfig.Wait == nil {
-			return errors.Errorf("hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].download or hooks[%d].upload is required", index, index, index, index, index)
+			errs = append(errs, errors.Errorf("hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].download or hooks[%d].upload is required", index, index, index, index, index))
 		}
 		enabled := 0
 		if hookConfig.Command != "" {
@@ -221,73 +353,74 @@ func validateHooks(config *latest.Config) error {
 			enabled++
 		}
 		if enabled > 1 {
-			return errors.Errorf("you can only use one of hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].upload and hooks[%d].download per hook", index, index, index, index, index)
+			errs = append(errs, errors.Errorf("you can only use one of hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].upload and hooks[%d].download per hook", index, index, index, index, index))
 		}
 		if hookConfig.Upload != nil && hookConfig.Container == nil {
-			return errors.Errorf("hooks[%d].container is required if hooks[%d].upload is used", index, index)
+			errs = append(errs, errors.Errorf("hooks[%d].container is required if hooks[%d].upload is used", index, index))
 		}
 		if hookConfig.Download != nil && hookConfig.Container == nil {
-			return errors.Errorf("hooks[%d].container is required if hooks[%d].download is used", index, index)
+			errs = append(errs, errors.Errorf("hooks[%d].container is required if hooks[%d].download is used", index, index))
 		}
 		if hookConfig.Logs != nil && hookConfig.Container == nil {
-			return errors.Errorf("hooks[%d].container is required if hooks[%d].logs is used", index, index)
+			errs = append(errs, errors.Errorf("hooks[%d].container is required if hooks[%d].logs is used", index, index))
 		}
 		if hookConfig.Wait != nil && hookConfig.Container == nil {
-			return errors.Errorf("hooks[%d].container is required if hooks[%d].wait is used", index, index)
+			errs = append(errs, errors.Errorf("hooks[%d].container is required if hooks[%d].wait is used", index, index))
 		}
 		if hookConfig.Wait != nil && !hookConfig.Wait.Running && hookConfig.Wait.TerminatedWithCode == nil {
-			return errors.Errorf("hooks[%d].wait.running or hooks[%d].wait.terminatedWithCode is required if hooks[%d].wait is used", index, index, index)
+			errs = append(errs, errors.Errorf("hooks[%d].wait.running or hooks[%d].wait.terminatedWithCode is required if hooks[%d].wait is used", index, index, index))
 		}
 		if hookConfig.Container != nil {
 			if hookConfig.Container.ContainerName != "" && len(hookConfig.Container.LabelSelector) == 0 {
-				return errors.Errorf("hooks[%d].container.containerName is defined but hooks[%d].container.labelSelector is not defined", index, index)
+				errs = append(errs, errors.Errorf("hooks[%d].container.containerName is defined but hooks[%d].container.labelSelector is not defined", index, index))
 			}
 			if len(hookConfig.Container.LabelSelector) == 0 && hookConfig.Container.ImageSelector == "" {
-				return errors.Errorf("hooks[%d].container.labelSelector and hooks[%d].container.imageSelector are not defined", index, index)
+				errs = append(errs, errors.Errorf("hooks[%d].container.labelSelector and hooks[%d].container.imageSelector are not defined", index, index))
 			}
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func validateDeployments(config *latest.Config) error {
+func validateDeployments(config *latest.Config) []error {
+	errs := []error{}
 	for index, deployConfig := range config.Deployments {
-		if encoding.IsUnsafeName(deployConfig.Name) {
-			return fmt.Errorf("deployments.%s has to match the following regex: %v", index, encoding.UnsafeNameRegEx.String())
+		if isUnsafeModuleName(config, deployConfig.Name, encoding.IsUnsafeName) {
+			errs = append(errs, fmt.Errorf("deployments.%s has to match the following regex: %v", index, encoding.UnsafeNameRegEx.String()))
 		}
 		if deployConfig.Helm == nil && deployConfig.Kubectl == nil {
-			return errors.Errorf("Please specify either helm or kubectl as deployment type in deployment %s", deployConfig.Name)
+			errs = append(errs, errors.Errorf("Please specify either helm or kubectl as deployment type in deployment %s", deployConfig.Name))
 		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests == nil && deployConfig.Kubectl.InlineManifest == "" {
-			return errors.Errorf("deployments[%s].kubectl.manifests or deployments[%s].kubectl.InlineManifest is required", index, index)
+			errs = append(errs, errors.Errorf("deployments[%s].kubectl.manifests or deployments[%s].kubectl.InlineManifest is required", index, index))
 		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests != nil && deployConfig.Kubectl.InlineManifest != "" {
-			return errors.Errorf("deployments[%s].kubectl.manifests and deployments[%s].kubectl.inlineManifest cannot be used together", index, index)
+			errs = append(errs, errors.Errorf("deployments[%s].kubectl.manifests and deployments[%s].kubectl.inlineManifest cannot be used together", index, index))
 		}
 		if deployConfig.Kubectl != nil && deployConfig.Helm != nil {
-			return errors.Errorf("deployments[%s].kubectl and deployments[%s].helm cannot be used together", index, index)
+			errs = append(errs, errors.Errorf("deployments[%s].kubectl and deployments[%s].helm cannot be used together", index, index))
 		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Patches != nil {
 			for patch := range deployConfig.Kubectl.Patches {
 				if deployConfig.Kubectl.Patches[patch].Target.Name == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].target.name is required", index, patch)
+					errs = append(errs, errors.Errorf("deployments[%s].kubectl.patches[%d].target.name is required", index, patch))
 				}
 				if deployConfig.Kubectl.Patches[patch].Operation == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].op is required", index, patch)
+					errs = append(errs, errors.Errorf("deployments[%s].kubectl.patches[%d].op is required", index, patch))
 				}
 				if deployConfig.Kubectl.Patches[patch].Path == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].path is required", index, patch)
+					errs = append(errs, errors.Errorf("deployments[%s].kubectl.patches[%d].path is required", index, patch))
 				}
 				if deployConfig.Kubectl.Patches[patch].Operation != "remove" &&
 					deployConfig.Kubectl.Patches[patch].Value == nil {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].value is required", index, patch)
+					errs = append(errs, errors.Errorf("deployments[%s].kubectl.patches[%d].value is required", index, patch))
 				}
 			}
 		}
 	}
 
-	return nil
+	return errs
 }
 
 func ValidateComponentConfig(deployConfig *latest.DeploymentConfig, overwriteValues map[string]interface{}) error {
@@ -307,71 +440,76 @@ func ValidateComponentConfig(deployConfig *latest.DeploymentConfig, overwriteVal
 	return nil
 }
 
-func validatePullSecrets(config *latest.Config) error {
+func validatePullSecrets(config *latest.Config) []error {
+	errs := []error{}
 	for _, ps := range config.PullSecrets {
 		if encoding.IsUnsafeName(ps.Name) {
-			return fmt.Errorf("pullSecrets.%s has to match the following regex: %v", ps.Name, encoding.UnsafeNameRegEx.String())
+			errs = append(errs, fmt.Errorf("pullSecrets.%s has to match the following regex: %v", ps.Name, encoding.UnsafeNameRegEx.String()))
 		}
 		if ps.Registry == "" {
-			return fmt.Errorf("pullSecrets.%s.registry is required", ps.Name)
+			errs = append(errs, fmt.Errorf("pullSecrets.%s.registry is required", ps.Name))
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func validateImages(config *latest.Config) error {
+func validateImages(config *latest.Config) []error {
+	errs := []error{}
 	// images lists all the image names in order to check for duplicates
 	images := map[string]bool{}
 	for imageConfigName, imageConf := range config.Images {
-		if encoding.IsUnsafeName(imageConfigName) {
-			return fmt.Errorf("images.%s has to match the following regex: %v", imageConfigName, encoding.UnsafeNameRegEx.String())
+		if isUnsafeModuleName(config, imageConfigName, encoding.IsUnsafeName) {
+			errs = append(errs, fmt.Errorf("images.%s has to match the following regex: %v", imageConfigName, encoding.UnsafeNameRegEx.String()))
 		}
 		if imageConf == nil {
-			return errors.Errorf("images.%s is empty and should at least contain an image name", imageConfigName)
+			errs = append(errs, errors.Errorf("images.%s is empty and should at least contain an image name", imageConfigName))
+			continue
 		}
 		if imageConf.Image == "" {
-			return errors.Errorf("images.%s.image is required", imageConfigName)
+			errs = append(errs, errors.Errorf("images.%s.image is required", imageConfigName))
 		}
 		if _, tag, _ := dockerfile.GetStrippedDockerImageName(imageConf.Image); tag != "" {
-			return errors.Errorf("images.%s.image '%s' can not have tag '%s'", imageConfigName, imageConf.Image, tag)
+			errs = append(errs, errors.Errorf("images.%s.image '%s' can not have tag '%s'", imageConfigName, imageConf.Image, tag))
 		}
 		if imageConf.Custom != nil && imageConf.Custom.Command == "" && len(imageConf.Custom.Commands) == 0 {
-			return errors.Errorf("images.%s.build.custom.command or images.%s.build.custom.commands is required", imageConfigName, imageConfigName)
+			errs = append(errs, errors.Errorf("images.%s.build.custom.command or images.%s.build.custom.commands is required", imageConfigName, imageConfigName))
 		}
-		if images[imageConf.Image] {
-			return errors.Errorf("multiple image definitions with the same image name are not allowed")
+		if imageConf.Image != "" && images[imageConf.Image] {
+			errs = append(errs, errors.Errorf("multiple image definitions with the same image name are not allowed"))
 		}
 		if imageConf.RebuildStrategy != "" && imageConf.RebuildStrategy != latest.RebuildStrategyDefault && imageConf.RebuildStrategy != latest.RebuildStrategyAlways && imageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges {
-			return errors.Errorf("images.%s.rebuildStrategy %s is invalid. Please choose one of %v", imageConfigName, string(imageConf.RebuildStrategy), []latest.RebuildStrategy{latest.RebuildStrategyAlways, latest.RebuildStrategyIgnoreContextChanges})
+			errs = append(errs, errors.Errorf("images.%s.rebuildStrategy %s is invalid. Please choose one of %v", imageConfigName, string(imageConf.RebuildStrategy), []latest.RebuildStrategy{latest.RebuildStrategyAlways, latest.RebuildStrategyIgnoreContextChanges}))
 		}
 		if imageConf.Kaniko != nil && imageConf.Kaniko.EnvFrom != nil {
 			for _, v := range imageConf.Kaniko.EnvFrom {
 				o, err := yaml.Marshal(v)
 				if err != nil {
-					return errors.Errorf("images.%s.build.kaniko.envFrom is invalid: %v", imageConfigName, err)
+					errs = append(errs, errors.Errorf("images.%s.build.kaniko.envFrom is invalid: %v", imageConfigName, err))
+					continue
 				}
 
 				err = jsonyaml.Unmarshal(o, &k8sv1.EnvVarSource{})
 				if err != nil {
-					return errors.Errorf("images.%s.build.kaniko.envFrom is invalid: %v", imageConfigName, err)
+					errs = append(errs, errors.Errorf("images.%s.build.kaniko.envFrom is invalid: %v", imageConfigName, err))
 				}
 			}
 		}
 		images[imageConf.Image] = true
 	}
 
-	return nil
+	return errs
 }
 
-func validateDev(config *latest.Config) error {
+func validateDev(config *latest.Config) []error {
+	errs := []error{}
 	for devPodName, devPod := range config.Dev {
 		devPodName = strings.TrimSpace(devPodName)
-		if encoding.IsUnsafeName(devPodName) {
-			return fmt.Errorf("dev.%s has to match the following regex: %v", devPodName, encoding.UnsafeNameRegEx.String())
+		if isUnsafeModuleName(config, devPodName, encoding.IsUnsafeName) {
+			errs = append(errs, fmt.Errorf("dev.%s has to match the following regex: %v", devPodName, encoding.UnsafeNameRegEx.String()))
 		}
 		if len(devPod.LabelSelector) == 0 && devPod.ImageSelector == "" {
-			return errors.Errorf("dev.%s: image selector and label selector are nil", devPodName)
+			errs = append(errs, errors.Errorf("dev.%s: image selector and label selector are nil", devPodName))
 		}
 
 		definedSelectors := 0
@@ -382,88 +520,204 @@ func validateDev(config *latest.Config) error {
 			definedSelectors++
 		}
 		if definedSelectors > 1 {
-			return errors.Errorf("dev.%s: image selector and label selector cannot be used together", devPodName)
+			errs = append(errs, errors.Errorf("dev.%s: image selector and label selector cannot be used together", devPodName))
+		}
+		if devPod.Replicas < 0 {
+			errs = append(errs, errors.Errorf("dev.%s.replicas cannot be negative", devPodName))
 		}
+		errs = append(errs, validateReplicas(config, devPodName, devPod)...)
+		errs = append(errs, validateDevContainer(fmt.Sprintf("dev.%s", devPodName), &devPod.DevContainer, devPod, false)...)
+		errs = append(errs, validateIntercept(fmt.Sprintf("dev.%s.intercept", devPodName), devPod.Intercept)...)
+		for i, c := range devPod.Containers {
+			errs = append(errs, validateDevContainer(fmt.Sprintf("dev.%s.containers[%s]", devPodName, i), c, devPod, true)...)
+		}
+	}
 
-		err := validateDevContainer(fmt.Sprintf("dev.%s", devPodName), &devPod.DevContainer, devPod, false)
-		if err != nil {
-			return err
+	return errs
+}
+
+// validateReplicas makes sure that replicas don't need to mount the same ReadWriteOnce volume
+func validateReplicas(config *latest.Config, devPodName string, devPod *latest.DevPod) []error {
+	if devPod.Replicas <= 1 {
+		return nil
+	}
+
+	errs := []error{}
+	containers := []*latest.DevContainer{&devPod.DevContainer}
+	for _, container := range devPod.Containers {
+		containers = append(containers, container)
+	}
+	for _, container := range containers {
+		if len(container.PersistPaths) > 0 {
+			var accessModes []string
+			if devPod.PersistenceOptions != nil {
+				accessModes = devPod.PersistenceOptions.AccessModes
+			}
+			if isReadWriteOnce(accessModes) {
+				errs = append(errs, errors.Errorf("dev.%s.replicas cannot be used together with persistPaths on a ReadWriteOnce volume, please set dev.%s.persistenceOptions.accessModes to ReadWriteMany", devPodName, devPodName))
+			}
 		}
-		if len(devPod.Containers) > 0 {
-			for i, c := range devPod.Containers {
-				err := validateDevContainer(fmt.Sprintf("dev.%s.containers[%s]", devPodName, i), c, devPod, true)
-				if err != nil {
-					return err
-				}
+
+		for _, mount := range container.Workspaces {
+			workspace, ok := config.Workspaces[mount.Name]
+			if ok && isReadWriteOnce(workspace.AccessModes) {
+				errs = append(errs, errors.Errorf("dev.%s.replicas cannot be used together with workspace %s on a ReadWriteOnce volume, please set workspaces.%s.accessModes to ReadWriteMany", devPodName, mount.Name, mount.Name))
 			}
 		}
 	}
 
-	return nil
+	return errs
+}
+
+// isReadWriteOnce returns true if the access modes don't allow multiple pods to mount the volume
+func isReadWriteOnce(accessModes []string) bool {
+	for _, accessMode := range accessModes {
+		if accessMode == "ReadWriteMany" || accessMode == "ReadOnlyMany" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateWorkspaces(config *latest.Config) []error {
+	errs := []error{}
+	for name, workspace := range config.Workspaces {
+		if encoding.IsUnsafeName(name) {
+			errs = append(errs, fmt.Errorf("workspaces.%s has to match the following regex: %v", name, encoding.UnsafeNameRegEx.String()))
+		}
+		if workspace.Size != "" {
+			_, err := resource.ParseQuantity(workspace.Size)
+			if err != nil {
+				errs = append(errs, errors.Errorf("workspaces.%s.size is not a valid quantity '%s': %v", name, workspace.Size, err))
+			}
+		}
+	}
+
+	validateMounts := func(path string, mounts []*latest.WorkspaceMount) {
+		for index, mount := range mounts {
+			if mount.Name == "" {
+				errs = append(errs, errors.Errorf("%s.workspaces[%d].name is required", path, index))
+			}
+			if mount.Path == "" {
+				errs = append(errs, errors.Errorf("%s.workspaces[%d].path is required", path, index))
+			}
+			if _, ok := config.Workspaces[mount.Name]; mount.Name != "" && !ok {
+				errs = append(errs, errors.Errorf("%s.workspaces[%d].name: workspace %s is not defined in workspaces", path, index, mount.Name))
+			}
+		}
+	}
+	for devPodName, devPod := range config.Dev {
+		validateMounts(fmt.Sprintf("dev.%s", devPodName), devPod.Workspaces)
+		for containerName, devContainer := range devPod.Containers {
+			validateMounts(fmt.Sprintf("dev.%s.containers[%s]", devPodName, containerName), devContainer.Workspaces)
+		}
+	}
+
+	return errs
 }
 
-func validateDevContainer(path string, devContainer *latest.DevContainer, devPod *latest.DevPod, nameRequired bool) error {
+func validateIntercept(path string, intercept *latest.Intercept) []error {
+	if intercept == nil {
+		return nil
+	}
+
+	errs := []error{}
+	if intercept.Service == "" {
+		errs = append(errs, errors.Errorf("%s.service is required", path))
+	}
+	if len(intercept.Ports) == 0 {
+		errs = append(errs, errors.Errorf("%s.ports is required", path))
+	}
+	for index, port := range intercept.Ports {
+		if port.Port == "" {
+			errs = append(errs, errors.Errorf("%s.ports[%d].port is required", path, index))
+		}
+		if port.ContainerPort < 0 || port.ContainerPort > 65535 {
+			errs = append(errs, errors.Errorf("%s.ports[%d].containerPort is not a valid port", path, index))
+		}
+	}
+
+	return errs
+}
+
+func validateDevContainer(path string, devContainer *latest.DevContainer, devPod *latest.DevPod, nameRequired bool) []error {
+	errs := []error{}
 	if nameRequired && devContainer.Container == "" {
-		return errors.Errorf("%s.container is required", path)
+		errs = append(errs, errors.Errorf("%s.container is required", path))
 	}
 
 	if !ValidContainerArch(devContainer.Arch) {
-		return errors.Errorf("%s.arch is not valid '%s'", path, devContainer.Arch)
+		errs = append(errs, errors.Errorf("%s.arch is not valid '%s'", path, devContainer.Arch))
 	}
 
 	// check if there are values from devContainers that are overwriting values from devPod
-	err := validatePodContainerDuplicates(path, devContainer, devPod)
-	if err != nil {
-		return err
-	}
+	errs = append(errs, validatePodContainerDuplicates(path, devContainer, devPod)...)
 
 	for index, sync := range devContainer.Sync {
 		// Validate initial sync strategy
 		if !ValidInitialSyncStrategy(sync.InitialSync) {
-			return errors.Errorf("%s.sync[%d].initialSync is not valid '%s'", path, index, sync.InitialSync)
+			errs = append(errs, errors.Errorf("%s.sync[%d].initialSync is not valid '%s'", path, index, sync.InitialSync))
 		}
 		if sync.OnUpload != nil {
 			for j, e := range sync.OnUpload.Exec {
 				if e.Command == "" {
-					return errors.Errorf("%s.sync[%d].exec[%d].command is required", path, index, j)
+					errs = append(errs, errors.Errorf("%s.sync[%d].exec[%d].command is required", path, index, j))
 				}
 			}
 		}
 		for j, p := range sync.ExcludePaths {
 			if p == "" {
-				return errors.Errorf("%s.sync[%d].excludePaths[%d] is empty. This can happen if you use !path without quotes like this: '!path'", path, index, j)
+				errs = append(errs, errors.Errorf("%s.sync[%d].excludePaths[%d] is empty. This can happen if you use !path without quotes like this: '!path'", path, index, j))
 			}
 		}
 		for j, p := range sync.UploadExcludePaths {
 			if p == "" {
-				return errors.Errorf("%s.sync[%d].uploadExcludePaths[%d] is empty. This can happen if you use !path without quotes like this: '!path'", path, index, j)
+				errs = append(errs, errors.Errorf("%s.sync[%d].uploadExcludePaths[%d] is empty. This can happen if you use !path without quotes like this: '!path'", path, index, j))
 			}
 		}
 		for j, p := range sync.DownloadExcludePaths {
 			if p == "" {
-				return errors.Errorf("%s.sync[%d].downloadExcludePaths[%d] is empty. This can happen if you use !path without quotes like this: '!path'", path, index, j)
+				errs = append(errs, errors.Errorf("%s.sync[%d].downloadExcludePaths[%d] is empty. This can happen if you use !path without quotes like this: '!path'", path, index, j))
 			}
 		}
 	}
 	for index, port := range devContainer.ReversePorts {
 		if port.Port == "" {
-			return errors.Errorf("%s.reversePorts[%d].port is required", path, index)
+			errs = append(errs, errors.Errorf("%s.reversePorts[%d].port is required", path, index))
 		}
 	}
 	for j, p := range devContainer.PersistPaths {
 		if p.Path == "" {
-			return errors.Errorf("%s.persistPaths[%d].path is required", path, j)
+			errs = append(errs, errors.Errorf("%s.persistPaths[%d].path is required", path, j))
+		}
+	}
+	if devContainer.Debug != nil {
+		if !ValidDebugPreset(devContainer.Debug.Preset) {
+			errs = append(errs, errors.Errorf("%s.debug.preset is not valid '%s', please use one of go, python, node or java", path, devContainer.Debug.Preset))
+		}
+		if devContainer.Debug.Port < 0 || devContainer.Debug.Port > 65535 {
+			errs = append(errs, errors.Errorf("%s.debug.port is not a valid port '%d'", path, devContainer.Debug.Port))
+		}
+		if devContainer.Debug.LocalPort < 0 || devContainer.Debug.LocalPort > 65535 {
+			errs = append(errs, errors.Errorf("%s.debug.localPort is not a valid port '%d'", path, devContainer.Debug.LocalPort))
+		}
+		for j, launchConfig := range devContainer.Debug.LaunchConfigs {
+			if launchConfig != latest.DebugLaunchConfigVSCode && launchConfig != latest.DebugLaunchConfigJetBrains && launchConfig != latest.DebugLaunchConfigNone {
+				errs = append(errs, errors.Errorf("%s.debug.launchConfigs[%d] is not valid '%s', please use one of vscode, jetbrains or none", path, j, launchConfig))
+			}
 		}
 	}
 
-	return nil
+	return errs
 }
 
-func validatePodContainerDuplicates(path string, devContainer *latest.DevContainer, devPod *latest.DevPod) error {
+func validatePodContainerDuplicates(path string, devContainer *latest.DevContainer, devPod *latest.DevPod) []error {
 	if devContainer.Container == "" {
 		return nil
 	}
 
+	errs := []error{}
 	// Extract list of fields from DevContainer struct
 	fields := reflect.VisibleFields(reflect.TypeOf(struct{ latest.DevContainer }{}))
 
@@ -485,9 +739,9 @@ func validatePodContainerDuplicates(path string, devContainer *latest.DevContain
 			pathFields = pathFields[:len(pathFields)-1]
 			sourcepath := strings.Join(pathFields, ".")
 
-			return errors.Errorf("%s.%s will be overwritten by %s, please specify %s.%s instead", sourcepath, fieldName, path, path, fieldName)
+			errs = append(errs, errors.Errorf("%s.%s will be overwritten by %s, please specify %s.%s instead", sourcepath, fieldName, path, path, fieldName))
 		}
 	}
 
-	return nil
+	return errs
 }
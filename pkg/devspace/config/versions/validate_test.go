@@ -16,7 +16,7 @@ func TestValidateImageName(t *testing.T) {
 			},
 		},
 	}
-	err := validateImages(config)
+	err := firstError(validateImages(config))
 	assert.NilError(t, err)
 
 	config = &latest.Config{
@@ -26,8 +26,23 @@ func TestValidateImageName(t *testing.T) {
 			},
 		},
 	}
-	err = validateImages(config)
+	err = firstError(validateImages(config))
 	assert.Error(t, err, "images.default.image 'localhost:5000/node:latest' can not have tag 'latest'")
+
+	// the module.name form is only allowed for modules of the config
+	config = &latest.Config{
+		Images: map[string]*latest.Image{
+			"orders.api": {
+				Image: "localhost:5000/node",
+			},
+		},
+	}
+	err = firstError(validateImages(config))
+	assert.ErrorContains(t, err, "images.orders.api has to match the following regex")
+
+	config.Modules = map[string]*latest.Module{"orders": {}}
+	err = firstError(validateImages(config))
+	assert.NilError(t, err)
 }
 
 func TestValidateHooks(t *testing.T) {
@@ -48,7 +63,7 @@ func TestValidateHooks(t *testing.T) {
 		},
 	}
 
-	err := validateHooks(config)
+	err := firstError(validateHooks(config))
 	assert.NilError(t, err)
 
 	config = &latest.Config{
@@ -65,7 +80,7 @@ func TestValidateHooks(t *testing.T) {
 		},
 	}
 
-	err = validateHooks(config)
+	err = firstError(validateHooks(config))
 	assert.Error(t, err, "hooks[0].container.containerName is defined but hooks[0].container.labelSelector is not defined")
 }
 
@@ -90,7 +105,7 @@ func TestValidateDev(t *testing.T) {
 		},
 	}
 
-	err := validateDev(config)
+	err := firstError(validateDev(config))
 	assert.NilError(t, err)
 
 	// test sync
@@ -108,7 +123,7 @@ func TestValidateDev(t *testing.T) {
 		},
 	}
 
-	err = validateDev(config)
+	err = firstError(validateDev(config))
 	assert.NilError(t, err)
 
 	// test replace pods
@@ -125,7 +140,7 @@ func TestValidateDev(t *testing.T) {
 		},
 	}
 
-	err = validateDev(config)
+	err = firstError(validateDev(config))
 	assert.NilError(t, err)
 
 	config = &latest.Config{
@@ -138,7 +153,7 @@ func TestValidateDev(t *testing.T) {
 		},
 	}
 
-	err = validateDev(config)
+	err = firstError(validateDev(config))
 	assert.Error(t, err, "dev.test: image selector and label selector are nil")
 
 	// test devpod overwritten by devcontainer
@@ -170,6 +185,279 @@ func TestValidateDev(t *testing.T) {
 		},
 	}
 
-	err = validateDev(config)
+	err = firstError(validateDev(config))
 	assert.Error(t, err, "dev.somename.reversePorts will be overwritten by dev.somename.containers[test], please specify dev.somename.containers[test].reversePorts instead")
+
+	// test intercept
+	config = &latest.Config{
+		Dev: map[string]*latest.DevPod{
+			"somename": {
+				Name: "somename",
+				LabelSelector: map[string]string{
+					"app": "MeApp",
+				},
+				Intercept: &latest.Intercept{
+					Service: "my-service",
+					Ports: []*latest.InterceptPort{
+						{
+							Port: "8080:80",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = firstError(validateDev(config))
+	assert.NilError(t, err)
+
+	config.Dev["somename"].Intercept.Ports = nil
+	err = firstError(validateDev(config))
+	assert.Error(t, err, "dev.somename.intercept.ports is required")
+
+	// test replicas
+	config = &latest.Config{
+		Dev: map[string]*latest.DevPod{
+			"somename": {
+				Name: "somename",
+				LabelSelector: map[string]string{
+					"app": "MeApp",
+				},
+				Replicas: 3,
+			},
+		},
+	}
+
+	err = firstError(validateDev(config))
+	assert.NilError(t, err)
+
+	config.Dev["somename"].PersistPaths = []latest.PersistentPath{{Path: "/data"}}
+	err = firstError(validateDev(config))
+	assert.Error(t, err, "dev.somename.replicas cannot be used together with persistPaths on a ReadWriteOnce volume, please set dev.somename.persistenceOptions.accessModes to ReadWriteMany")
+
+	config.Dev["somename"].PersistenceOptions = &latest.PersistenceOptions{AccessModes: []string{"ReadWriteMany"}}
+	err = firstError(validateDev(config))
+	assert.NilError(t, err)
+
+	config.Workspaces = map[string]*latest.Workspace{"cache": {Name: "cache"}}
+	config.Dev["somename"].PersistPaths = nil
+	config.Dev["somename"].Containers = map[string]*latest.DevContainer{
+		"api": {Container: "api", Workspaces: []*latest.WorkspaceMount{{Name: "cache", Path: "/cache"}}},
+	}
+	err = firstError(validateDev(config))
+	assert.Error(t, err, "dev.somename.replicas cannot be used together with workspace cache on a ReadWriteOnce volume, please set workspaces.cache.accessModes to ReadWriteMany")
+
+	config.Workspaces["cache"].AccessModes = []string{"ReadWriteMany"}
+	err = firstError(validateDev(config))
+	assert.NilError(t, err)
+
+	config.Dev["somename"].Replicas = -1
+	err = firstError(validateDev(config))
+	assert.Error(t, err, "dev.somename.replicas cannot be negative")
+
+	// test debug
+	config = &latest.Config{
+		Dev: map[string]*latest.DevPod{
+			"somename": {
+				Name: "somename",
+				LabelSelector: map[string]string{
+					"app": "MeApp",
+				},
+				DevContainer: latest.DevContainer{
+					Command: []string{"/app/main"},
+					Debug: &latest.Debug{
+						Preset: latest.DebugPresetGo,
+					},
+				},
+			},
+		},
+	}
+
+	err = firstError(validateDev(config))
+	assert.NilError(t, err)
+
+	config.Dev["somename"].Debug.Preset = "ruby"
+	err = firstError(validateDev(config))
+	assert.Error(t, err, "dev.somename.debug.preset is not valid 'ruby', please use one of go, python, node or java")
+}
+
+func TestValidateVars(t *testing.T) {
+	vars := map[string]*latest.Variable{
+		"TOKEN": {
+			Name: "TOKEN",
+			Secret: &latest.VariableSecret{
+				Provider: latest.VariableSecretProviderKubernetes,
+				Name:     "api",
+			},
+		},
+	}
+
+	err := firstError(validateVars(vars))
+	assert.NilError(t, err)
+
+	vars["TOKEN"].Secret.Name = ""
+	err = firstError(validateVars(vars))
+	assert.Error(t, err, "vars.TOKEN.secret.name is required for provider kubernetes")
+
+	vars["TOKEN"].Secret = nil
+	vars["TOKEN"].Source = latest.VariableSourceSecret
+	err = firstError(validateVars(vars))
+	assert.Error(t, err, "vars.TOKEN.secret is required if source is secret")
+
+	vars = map[string]*latest.Variable{
+		"HOSTS": {
+			Name: "HOSTS",
+			Type: latest.VariableTypeList,
+			Max:  3,
+			Schema: map[string]interface{}{
+				"type": "array",
+			},
+		},
+	}
+	err = firstError(validateVars(vars))
+	assert.NilError(t, err)
+
+	vars["HOSTS"].Schema["type"] = 1
+	err = firstError(validateVars(vars))
+	assert.ErrorContains(t, err, "vars.HOSTS.schema is invalid")
+
+	vars["HOSTS"].Type = "array"
+	err = firstError(validateVars(vars))
+	assert.Error(t, err, "vars.HOSTS.type array is invalid. Please choose one of string, int, bool, list, map, duration or semver")
+}
+
+func TestValidateWorkspaces(t *testing.T) {
+	config := &latest.Config{
+		Workspaces: map[string]*latest.Workspace{
+			"cache": {
+				Name: "cache",
+				Size: "5Gi",
+			},
+		},
+		Dev: map[string]*latest.DevPod{
+			"somename": {
+				Name: "somename",
+				LabelSelector: map[string]string{
+					"app": "MeApp",
+				},
+				DevContainer: latest.DevContainer{
+					Workspaces: []*latest.WorkspaceMount{
+						{
+							Name: "cache",
+							Path: "/root/.cache",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := firstError(validateWorkspaces(config))
+	assert.NilError(t, err)
+
+	config.Dev["somename"].Workspaces[0].Name = "other"
+	err = firstError(validateWorkspaces(config))
+	assert.Error(t, err, "dev.somename.workspaces[0].name: workspace other is not defined in workspaces")
+
+	config.Dev["somename"].Workspaces[0].Name = "cache"
+	config.Workspaces["cache"].Size = "abc"
+	err = firstError(validateWorkspaces(config))
+	assert.ErrorContains(t, err, "workspaces.cache.size is not a valid quantity 'abc'")
+}
+
+func TestValidateProfiles(t *testing.T) {
+	config := &latest.Config{
+		Profiles: []*latest.ProfileConfig{
+			{
+				Name: "staging",
+				Activation: []*latest.ProfileActivation{
+					{
+						KubeContext: "staging-.*",
+						Match:       latest.ProfileActivationMatchAny,
+					},
+				},
+			},
+		},
+	}
+
+	err := firstError(validateProfiles(config))
+	assert.NilError(t, err)
+
+	config.Profiles[0].Activation[0].Match = "some"
+	err = firstError(validateProfiles(config))
+	assert.Error(t, err, "profiles[0].activation[0].match some is invalid. Please choose one of all or any")
+
+	config.Profiles[0].Activation[0].Match = ""
+	config.Profiles[0].Activation[0].GitBranch = "feature/(.*"
+	err = firstError(validateProfiles(config))
+	assert.ErrorContains(t, err, "profiles[0].activation[0].gitBranch is not a valid regular expression")
+}
+
+func TestValidatePipelineOutputs(t *testing.T) {
+	config := &latest.Config{
+		Pipelines: map[string]*latest.Pipeline{
+			"db": {
+				Name:    "db",
+				Outputs: []latest.PipelineOutput{{Name: "url"}, {Name: "password_2"}},
+			},
+		},
+	}
+	assert.NilError(t, firstError(validatePipelines(config)))
+
+	config.Pipelines["db"].Outputs[1].Name = "url"
+	assert.Error(t, firstError(validatePipelines(config)), "pipelines.db.outputs[1].name: output url is defined twice")
+
+	config.Pipelines["db"].Outputs[1].Name = "db.url"
+	assert.ErrorContains(t, firstError(validatePipelines(config)), "pipelines.db.outputs[1].name has to match the following regex")
+}
+
+func TestValidateTeamVars(t *testing.T) {
+	config := &latest.Config{
+		TeamVars: &latest.TeamVars{
+			Namespace: "team",
+		},
+	}
+	assert.NilError(t, firstError(validateTeamVars(config)))
+
+	config.TeamVars.Namespace = ""
+	assert.Error(t, firstError(validateTeamVars(config)), "teamVars.namespace is required")
+
+	config.TeamVars.Namespace = "${TEAM}"
+	assert.Error(t, firstError(validateTeamVars(config)), "teamVars cannot use variables, because it is needed to resolve variables")
+}
+
+func TestValidateAll(t *testing.T) {
+	config := &latest.Config{
+		Name: "test",
+		Commands: map[string]*latest.CommandConfig{
+			"broken": {Name: "broken"},
+		},
+		PullSecrets: map[string]*latest.PullSecretConfig{
+			"secret": {Name: "secret"},
+		},
+	}
+
+	errs := ValidateAll(config)
+	assert.Equal(t, len(errs), 2)
+	assert.Error(t, errs[0], "pullSecrets.secret.registry is required")
+	assert.Error(t, errs[1], "commands.broken.command is required")
+	assert.Error(t, Validate(config), "pullSecrets.secret.registry is required")
+
+	// a single validator reports all of its errors
+	config.Hooks = []*latest.HookConfig{
+		{Command: "echo"},
+		{Events: []string{"after:deploy"}},
+	}
+	errs = ValidateAll(config)
+	assert.Equal(t, len(errs), 4)
+	assert.Error(t, errs[0], "hooks[0].events is required")
+	assert.Error(t, errs[1], "hooks[1].command, hooks[1].logs, hooks[1].wait, hooks[1].download or hooks[1].upload is required")
+}
+
+func firstError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs[0]
 }
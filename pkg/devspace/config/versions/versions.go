@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/v1beta11"
@@ -60,7 +59,7 @@ func ParseProfile(ctx context.Context, basePath string, data map[string]interfac
 	activatedProfiles := []string{}
 	if !disableProfileActivation {
 		var err error
-		activatedProfiles, err = getActivatedProfiles(ctx, data, resolver, log)
+		activatedProfiles, err = getActivatedProfiles(ctx, basePath, data, resolver, log)
 		if err != nil {
 			return nil, err
 		}
@@ -120,6 +119,39 @@ func ParseVariables(data map[string]interface{}, log log.Logger) (map[string]*la
 
 // Parse parses the data into the latest config
 func Parse(data map[string]interface{}, log log.Logger) (*latest.Config, error) {
+	latestConfig, err := ParseWithoutValidation(data, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate config
+	err = Validate(latestConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return latestConfig, nil
+}
+
+// ParseWithoutValidation parses the data into the latest config, but does not validate it
+func ParseWithoutValidation(data map[string]interface{}, log log.Logger) (*latest.Config, error) {
+	latestConfigConverted, err := UpgradeToLatest(data, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter out empty images, deployments etc.
+	err = adjustConfig(latestConfigConverted)
+	if err != nil {
+		return nil, err
+	}
+
+	return latestConfigConverted, nil
+}
+
+// UpgradeToLatest loads the data with the config version it specifies and upgrades it to
+// the latest version without filling in any defaults
+func UpgradeToLatest(data map[string]interface{}, log log.Logger) (*latest.Config, error) {
 	version, ok := data["version"].(string)
 	if !ok {
 		return nil, errors.Errorf("Version is missing in devspace.yaml")
@@ -161,19 +193,6 @@ func Parse(data map[string]interface{}, log log.Logger) (*latest.Config, error)
 
 	// Update version to latest
 	latestConfigConverted.Version = latest.Version
-
-	// Filter out empty images, deployments etc.
-	err = adjustConfig(latestConfigConverted)
-	if err != nil {
-		return nil, err
-	}
-
-	// validate config
-	err = Validate(latestConfigConverted)
-	if err != nil {
-		return nil, err
-	}
-
 	return latestConfigConverted, nil
 }
 
@@ -259,80 +278,6 @@ func getProfiles(ctx context.Context, basePath string, data map[string]interface
 	return errors.Errorf("Couldn't find profile '%s'", profile)
 }
 
-func getActivatedProfiles(ctx context.Context, data map[string]interface{}, resolver variable.Resolver, log log.Logger) ([]string, error) {
-	activatedProfiles := []string{}
-
-	// Check if there are profiles
-	if data["profiles"] == nil {
-		return activatedProfiles, nil
-	}
-
-	// get the profiles and parse them
-	profilesData, err := Get(data, "profiles")
-	if err != nil {
-		return nil, err
-	}
-	profiles, err := Parse(profilesData, log)
-	if err != nil {
-		return nil, err
-	}
-
-	// Select which profiles are activated
-	for _, profileConfig := range profiles.Profiles {
-		for _, activation := range profileConfig.Activation {
-			activatedByEnv, err := matchEnvironment(activation.Environment)
-			if err != nil {
-				return activatedProfiles, errors.Wrap(err, "error activating profile with env")
-			}
-
-			activatedByVars, err := matchVars(ctx, activation.Vars, resolver)
-			if err != nil {
-				return activatedProfiles, errors.Wrap(err, "error activating profile with vars")
-			}
-
-			if activatedByEnv && activatedByVars {
-				log.Debugf("profile %s was automatically activated", profileConfig.Name)
-				activatedProfiles = append(activatedProfiles, profileConfig.Name)
-			}
-		}
-	}
-
-	return activatedProfiles, nil
-}
-
-func matchEnvironment(env map[string]string) (bool, error) {
-	for k, v := range env {
-		match, err := regexp.MatchString(sanitizeMatchExpression(v), os.Getenv(k))
-		if err != nil {
-			return false, err
-		}
-
-		if !match {
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
-func matchVars(ctx context.Context, activationVars map[string]string, resolver variable.Resolver) (bool, error) {
-	for k, v := range activationVars {
-		value, err := resolveVariableValue(ctx, k, resolver)
-		if err != nil {
-			return false, err
-		}
-
-		match, err := regexp.MatchString(sanitizeMatchExpression(v), value)
-		if err != nil {
-			return false, err
-		} else if !match {
-			return false, nil
-		}
-	}
-
-	return true, nil
-}
-
 func filterProfileParents(profileParents []string) []string {
 	return util.Filter(profileParents, func(oidx int, os string) bool {
 		return !util.Contains(profileParents, func(iidx int, is string) bool {
@@ -286,6 +286,6 @@ func (c *context) AsDependency(dependency types.Dependency) Context {
 	n.kubeClient = dependency.KubeClient()
 	n.config = dependency.Config()
 	n.dependencies = dependency.Children()
-	n.environ = env.NewVariableEnvProvider(c.environ, env.ConvertMap(n.config.Variables()))
+	n.environ = env.NewVariableEnvProvider(c.environ, env.ConvertMap(config.PipelineVariables(n.config)))
 	return &n
 }
@@ -2,6 +2,7 @@ package values
 
 import (
 	"context"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	flag "github.com/spf13/pflag"
 	"strings"
 )
@@ -18,6 +19,7 @@ const (
 	devContextKey
 	flagsKey
 	commandFlagsKey
+	pipelineKey
 )
 
 // WithFlagsMap creates a new context with the given flags
@@ -97,6 +99,17 @@ func TempFolderFrom(ctx context.Context) (string, bool) {
 	return user, ok
 }
 
+// WithPipeline returns a copy of parent with the pipeline that is currently executed
+func WithPipeline(parent context.Context, pipeline *latest.Pipeline) context.Context {
+	return WithValue(parent, pipelineKey, pipeline)
+}
+
+// PipelineFrom returns the pipeline that is currently executed
+func PipelineFrom(ctx context.Context) (*latest.Pipeline, bool) {
+	pipeline, ok := ctx.Value(pipelineKey).(*latest.Pipeline)
+	return pipeline, ok && pipeline != nil
+}
+
 func WithDependency(parent context.Context, dependency bool) context.Context {
 	return WithValue(parent, dependencyKey, dependency)
 }
@@ -10,9 +10,11 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Manager can update, build, deploy and purge dependencies.
@@ -74,7 +76,10 @@ func (m *manager) handleDependencies(ctx devspacecontext.Context, options Resolv
 	}
 
 	// Resolve all dependencies
-	dependencies, err := m.resolver.Resolve(ctx, options)
+	spanCtx, span := tracing.Start(ctx.Context(), "resolve dependencies")
+	dependencies, err := m.resolver.Resolve(ctx.WithContext(spanCtx), options)
+	span.SetAttributes(attribute.Int("devspace.dependencies", len(dependencies)))
+	tracing.End(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "resolve dependencies")
 	}
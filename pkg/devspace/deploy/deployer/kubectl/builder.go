@@ -11,10 +11,12 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/constraint"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/utils/pkg/command"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -48,7 +50,9 @@ func (k *kustomizeBuilder) Build(ctx context.Context, environ expand.Environ, di
 
 	// Execute command
 	k.log.Infof("Render manifests with 'kustomize %s'", strings.Join(args, " "))
-	output, err := command.Output(ctx, dir, environ, k.path, args...)
+	spanCtx, span := tracing.Start(ctx, "kustomize build", attribute.StringSlice("devspace.args", args))
+	output, err := command.Output(spanCtx, dir, environ, k.path, args...)
+	tracing.End(span, err)
 	if err != nil {
 		exitError, ok := err.(*exec.ExitError)
 		if ok {
@@ -150,9 +154,11 @@ func (k *kubectlBuilder) Build(ctx context.Context, environ expand.Environ, dir,
 	args = append(args, k.config.Kubectl.CreateArgs...)
 
 	// Execute command
-	output, err := command.Output(ctx, dir, env.NewVariableEnvProvider(environ, map[string]string{
+	spanCtx, span := tracing.Start(ctx, "kubectl create", attribute.StringSlice("devspace.args", args))
+	output, err := command.Output(spanCtx, dir, env.NewVariableEnvProvider(environ, map[string]string{
 		"KUBECONFIG": tempFile.Name(),
 	}), k.path, args...)
+	tracing.End(span, err)
 	if err != nil {
 		exitError, ok := err.(*exec.ExitError)
 		if ok {
@@ -16,6 +16,7 @@ import (
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
 	"github.com/loft-sh/utils/pkg/command"
@@ -23,6 +24,7 @@ import (
 	"github.com/loft-sh/utils/pkg/downloader/commands"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"mvdan.cc/sh/v3/expand"
 	jsonyaml "sigs.k8s.io/yaml"
@@ -221,7 +223,13 @@ func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []
 		args = append(args, d.DeploymentConfig.Kubectl.ApplyArgs...)
 
 		stdErrBuffer := &bytes.Buffer{}
-		err = command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(replacedManifest), d.CmdPath, args...)
+		spanCtx, span := tracing.Start(ctx.Context(), "kubectl apply",
+			attribute.String("devspace.deployment", d.DeploymentConfig.Name),
+			attribute.String("devspace.manifest", manifest),
+			attribute.StringSlice("devspace.args", args),
+		)
+		err = command.Command(spanCtx, ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(replacedManifest), d.CmdPath, args...)
+		tracing.End(span, err)
 		if err != nil {
 			return false, nil, errors.Errorf("%v %v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", stdErrBuffer.String(), err, manifest)
 		}
@@ -20,6 +20,8 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
 	"github.com/loft-sh/devspace/pkg/devspace/services/attach"
+	"github.com/loft-sh/devspace/pkg/devspace/services/debug"
+	"github.com/loft-sh/devspace/pkg/devspace/services/intercept"
 	"github.com/loft-sh/devspace/pkg/devspace/services/logs"
 	"github.com/loft-sh/devspace/pkg/devspace/services/proxycommands"
 	"github.com/loft-sh/devspace/pkg/devspace/services/ssh"
@@ -55,6 +57,10 @@ var (
 
 type devPod struct {
 	selectedPod *selector.SelectedPodContainer
+	replicaPods []*selector.SelectedPodContainer
+
+	// pickedReplica is the name of the replica the terminal, attach and ssh connect to
+	pickedReplica string
 
 	m syncpkg.Mutex
 
@@ -141,28 +147,33 @@ func (d *devPod) startWithRetry(ctx devspacecontext.Context, devPodConfig *lates
 		// check if pod was terminated
 		d.m.Lock()
 		selectedPod := d.selectedPod
+		replicaPods := d.replicaPods
 		d.selectedPod = nil
+		d.replicaPods = nil
 		d.m.Unlock()
 
 		// check if we need to restart
 		if selectedPod != nil {
 			shouldRestart := false
+			selectedPods := append([]*selector.SelectedPodContainer{selectedPod}, replicaPods...)
 			err := wait.PollUntilContextCancel(ctx.Context(), time.Second, true, func(context.Context) (bool, error) {
-				pod, err := ctx.KubeClient().KubeClient().CoreV1().Pods(selectedPod.Pod.Namespace).Get(ctx.Context(), selectedPod.Pod.Name, metav1.GetOptions{})
-				if err != nil {
-					if kerrors.IsNotFound(err) {
-						ctx.Log().Debugf("Restart dev %s because pod isn't found anymore", devPodConfig.Name)
+				for _, selectedPod := range selectedPods {
+					pod, err := ctx.KubeClient().KubeClient().CoreV1().Pods(selectedPod.Pod.Namespace).Get(ctx.Context(), selectedPod.Pod.Name, metav1.GetOptions{})
+					if err != nil {
+						if kerrors.IsNotFound(err) {
+							ctx.Log().Debugf("Restart dev %s because pod %s isn't found anymore", devPodConfig.Name, selectedPod.Pod.Name)
+							shouldRestart = true
+							return true, nil
+						}
+
+						// this case means there might be problems with internet
+						ctx.Log().Debugf("error trying to retrieve pod: %v", err)
+						return false, nil
+					} else if pod.DeletionTimestamp != nil {
+						ctx.Log().Debugf("Restart dev %s because pod %s is terminating", devPodConfig.Name, selectedPod.Pod.Name)
 						shouldRestart = true
 						return true, nil
 					}
-
-					// this case means there might be problems with internet
-					ctx.Log().Debugf("error trying to retrieve pod: %v", err)
-					return false, nil
-				} else if pod.DeletionTimestamp != nil {
-					ctx.Log().Debugf("Restart dev %s because pod is terminating", devPodConfig.Name)
-					shouldRestart = true
-					return true, nil
 				}
 
 				return true, nil
@@ -283,9 +294,29 @@ func (d *devPod) start(ctx devspacecontext.Context, devPodConfig *latest.DevPod,
 	}
 	ctx.Log().Infof("Selected pod %s", ansi.Color(selectedPod.Pod.Name, "yellow+b"))
 
+	// wait for the other replicas if configured
+	replicaPods, err := selectReplicas(ctx, devPodConfig, imageSelector, selectedPod)
+	if err != nil {
+		return errors.Wrap(err, "select replicas")
+	}
+	for _, replicaPod := range replicaPods {
+		ctx.Log().Infof("Selected replica %s", ansi.Color(replicaPod.Pod.Name, "yellow+b"))
+	}
+
+	// the terminal, attach and ssh connect to a single replica
+	d.m.Lock()
+	pickedReplica := d.pickedReplica
+	d.m.Unlock()
+	interactivePod, err := pickReplica(ctx, devPodConfig, selectedPod, replicaPods, pickedReplica)
+	if err != nil {
+		return errors.Wrap(err, "pick replica")
+	}
+
 	// set selected pod
 	d.m.Lock()
 	d.selectedPod = selectedPod
+	d.replicaPods = replicaPods
+	d.pickedReplica = interactivePod.Pod.Name
 	d.m.Unlock()
 
 	// Run dev.open configs
@@ -316,7 +347,7 @@ func (d *devPod) start(ctx devspacecontext.Context, devPodConfig *latest.DevPod,
 	}
 
 	// start sync and port forwarding
-	err = d.startServices(ctx, devPodConfig, newTargetSelector(selectedPod.Pod.Name, selectedPod.Pod.Namespace, selectedPod.Container.Name, parent), opts, parent)
+	err = d.startServices(ctx, devPodConfig, newTargetSelector(selectedPod.Pod.Name, selectedPod.Pod.Namespace, selectedPod.Container.Name, parent), newTargetSelector(interactivePod.Pod.Name, interactivePod.Pod.Namespace, interactivePod.Container.Name, parent), replicaPods, opts, parent)
 	if err != nil {
 		return err
 	}
@@ -324,16 +355,16 @@ func (d *devPod) start(ctx devspacecontext.Context, devPodConfig *latest.DevPod,
 	// start logs
 	terminalDevContainer := d.getTerminalDevContainer(devPodConfig)
 	if terminalDevContainer != nil {
-		return d.startTerminal(ctx, terminalDevContainer, opts, selectedPod, parent)
+		return d.startTerminal(ctx, terminalDevContainer, opts, interactivePod, parent)
 	}
 
 	// start attach if defined
 	attachDevContainer := d.getAttachDevContainer(devPodConfig)
 	if attachDevContainer != nil {
-		return d.startAttach(ctx, attachDevContainer, opts, selectedPod, parent)
+		return d.startAttach(ctx, attachDevContainer, opts, interactivePod, parent)
 	}
 
-	return d.startLogs(ctx, devPodConfig, selectedPod, parent)
+	return d.startLogs(ctx, devPodConfig, append([]*selector.SelectedPodContainer{selectedPod}, replicaPods...), parent)
 }
 
 func tryOpen(ctx context.Context, url string, log logpkg.Logger) error {
@@ -373,19 +404,27 @@ func tryOpen(ctx context.Context, url string, log logpkg.Logger) error {
 	return fmt.Errorf("not reachable")
 }
 
-func (d *devPod) startLogs(ctx devspacecontext.Context, devPodConfig *latest.DevPod, selectedPod *selector.SelectedPodContainer, parent *tomb.Tomb) error {
-	ctx = ctx.WithLogger(ctx.Log().WithPrefixColor("logs  ", "yellow+b"))
-	loader.EachDevContainer(devPodConfig, func(devContainer *latest.DevContainer) bool {
-		if devContainer.Logs == nil || (devContainer.Logs.Enabled != nil && !*devContainer.Logs.Enabled) {
-			return true
+func (d *devPod) startLogs(ctx devspacecontext.Context, devPodConfig *latest.DevPod, selectedPods []*selector.SelectedPodContainer, parent *tomb.Tomb) error {
+	for _, selectedPod := range selectedPods {
+		// prefix the logs with the pod name if there are multiple replicas
+		selectedPod := selectedPod
+		ctx := ctx.WithLogger(ctx.Log().WithPrefixColor("logs  ", "yellow+b"))
+		if len(selectedPods) > 1 {
+			ctx = ctx.WithLogger(ctx.Log().WithPrefixColor("logs:"+selectedPod.Pod.Name+" ", "yellow+b"))
 		}
 
-		parent.Go(func() error {
-			return logs.StartLogs(ctx, devContainer, newTargetSelector(selectedPod.Pod.Name, selectedPod.Pod.Namespace, selectedPod.Container.Name, parent))
-		})
+		loader.EachDevContainer(devPodConfig, func(devContainer *latest.DevContainer) bool {
+			if devContainer.Logs == nil || (devContainer.Logs.Enabled != nil && !*devContainer.Logs.Enabled) {
+				return true
+			}
 
-		return true
-	})
+			parent.Go(func() error {
+				return logs.StartLogs(ctx, devContainer, newTargetSelector(selectedPod.Pod.Name, selectedPod.Pod.Namespace, selectedPod.Container.Name, parent))
+			})
+
+			return true
+		})
+	}
 
 	return nil
 }
@@ -498,7 +537,7 @@ func (d *devPod) startTerminal(ctx devspacecontext.Context, devContainer *latest
 	return nil
 }
 
-func (d *devPod) startServices(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, opts Options, parent *tomb.Tomb) error {
+func (d *devPod) startServices(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, interactiveSelector targetselector.TargetSelector, replicaPods []*selector.SelectedPodContainer, opts Options, parent *tomb.Tomb) error {
 	pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{}, "devCommand:before:sync", "dev.beforeSync", "devCommand:before:portForwarding", "dev.beforePortForwarding")
 	if pluginErr != nil {
 		return pluginErr
@@ -513,7 +552,19 @@ func (d *devPod) startServices(ctx devspacecontext.Context, devPod *latest.DevPo
 		// add prefix
 		ctx := ctx.WithLogger(ctx.Log().WithPrefixColor("sync  ", "yellow+b"))
 		err := sync.StartSync(ctx, devPod, selector, parent)
-		return err
+		if err != nil {
+			return err
+		}
+
+		// fan out the upstream sync to the other replicas
+		for _, replicaPod := range replicaPods {
+			err = sync.StartReplicaSync(ctx, devPod, newTargetSelector(replicaPod.Pod.Name, replicaPod.Pod.Namespace, replicaPod.Container.Name, parent), parent)
+			if err != nil {
+				return errors.Wrapf(err, "sync replica %s", replicaPod.Pod.Name)
+			}
+		}
+
+		return nil
 	})
 
 	// Start Port Forwarding
@@ -530,11 +581,28 @@ func (d *devPod) startServices(ctx devspacecontext.Context, devPod *latest.DevPo
 	<-syncDone
 	<-portForwardingDone
 
+	// write debugger launch configurations
+	err := debug.WriteLaunchConfigs(ctx.WithLogger(ctx.Log().WithPrefixColor("debug ", "yellow+b")), devPod)
+	if err != nil {
+		return err
+	}
+
+	// Start Intercept
+	interceptDone := parent.NotifyGo(func() error {
+		if opts.DisableIntercept {
+			return nil
+		}
+
+		ctx := ctx.WithLogger(ctx.Log().WithPrefixColor("intcpt", "yellow+b"))
+		return intercept.StartIntercept(ctx, devPod, selector, parent)
+	})
+	<-interceptDone
+
 	// Start SSH
 	sshDone := parent.NotifyGo(func() error {
 		// add ssh prefix
 		ctx := ctx.WithLogger(ctx.Log().WithPrefixColor("ssh   ", "yellow+b"))
-		return ssh.StartSSH(ctx, devPod, selector, parent)
+		return ssh.StartSSH(ctx, devPod, interactiveSelector, parent)
 	})
 
 	// Start Reverse Commands
@@ -558,7 +626,7 @@ func (d *devPod) startServices(ctx devspacecontext.Context, devPod *latest.DevPo
 }
 
 func needPodReplace(devPodConfig *latest.DevPod) bool {
-	if len(devPodConfig.Patches) > 0 {
+	if len(devPodConfig.Patches) > 0 || devPodConfig.Replicas > 1 {
 		return true
 	}
 
@@ -578,7 +646,7 @@ func needPodReplaceContainer(devContainer *latest.DevContainer) bool {
 	if devContainer.DevImage != "" {
 		return true
 	}
-	if len(devContainer.PersistPaths) > 0 {
+	if len(devContainer.PersistPaths) > 0 || len(devContainer.Workspaces) > 0 {
 		return true
 	}
 	if devContainer.RestartHelper != nil && devContainer.RestartHelper.Inject != nil && *devContainer.RestartHelper.Inject {
@@ -612,6 +680,9 @@ func needPodReplaceContainer(devContainer *latest.DevContainer) bool {
 	if devContainer.Resources != nil {
 		return true
 	}
+	if debug.Enabled(devContainer) {
+		return true
+	}
 
 	return false
 }
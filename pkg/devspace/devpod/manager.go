@@ -8,6 +8,7 @@ import (
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/services/intercept"
 	"github.com/loft-sh/devspace/pkg/devspace/services/podreplace"
 	"github.com/loft-sh/devspace/pkg/util/lockfactory"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
@@ -20,6 +21,7 @@ type Options struct {
 
 	DisableSync           bool `long:"disable-sync" description:"If enabled will not start any sync configuration"`
 	DisablePortForwarding bool `long:"disable-port-forwarding" description:"If enabled will not start any port forwarding configuration"`
+	DisableIntercept      bool `long:"disable-intercept" description:"If enabled will not intercept any service traffic"`
 	DisablePodReplace     bool `long:"disable-pod-replace" description:"If enabled will not replace any pods"`
 	DisableOpen           bool `long:"disable-open" description:"If enabled will not auto-open the URL"`
 }
@@ -198,6 +200,14 @@ func (d *devPodManager) Reset(ctx devspacecontext.Context, name string, options
 
 	d.stop(name)
 	devPod, ok := ctx.Config().RemoteCache().GetDevPod(name)
+	if ok && devPod.Intercept != nil {
+		err := intercept.RestoreIntercept(ctx, &devPod)
+		if err != nil {
+			return err
+		}
+
+		devPod, ok = ctx.Config().RemoteCache().GetDevPod(name)
+	}
 	if ok {
 		_, err := podreplace.NewPodReplacer().RevertReplacePod(ctx, &devPod, options)
 		return err
@@ -0,0 +1,146 @@
+package devpod
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/podreplace"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/util/survey"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// selectReplicas waits until all additional replicas of the dev pod are running and
+// returns them sorted by name. The primary replica is not part of the returned list.
+func selectReplicas(ctx devspacecontext.Context, devPodConfig *latest.DevPod, imageSelector []string, primary *selector.SelectedPodContainer) ([]*selector.SelectedPodContainer, error) {
+	replicas := int(podreplace.Replicas(devPodConfig))
+	if replicas <= 1 {
+		return nil, nil
+	}
+
+	// only pods of the replaced dev workload are replicas, other pods might match the selector as well
+	owner := metav1.GetControllerOf(primary.Pod)
+	if owner == nil {
+		return nil, errors.Errorf("pod %s/%s has no controller, but replicas require a replaced dev workload", primary.Pod.Namespace, primary.Pod.Name)
+	}
+
+	podSelector := selector.Selector{
+		ImageSelector:      imageSelector,
+		Namespace:          primary.Pod.Namespace,
+		ContainerName:      primary.Container.Name,
+		SkipInitContainers: true,
+		FilterContainer:    selector.FilterNonRunningContainers,
+	}
+	if len(devPodConfig.LabelSelector) > 0 {
+		podSelector.LabelSelector = labels.Set(devPodConfig.LabelSelector).String()
+	}
+
+	ctx.Log().Infof("Waiting for %d replicas to become ready...", replicas)
+	var selected []*selector.SelectedPodContainer
+	lastWarning := time.Now()
+	err := wait.PollUntilContextTimeout(ctx.Context(), time.Millisecond*500, targetselector.DefaultTimeout, true, func(waitCtx context.Context) (bool, error) {
+		containers, err := selector.NewFilter(ctx.KubeClient()).SelectContainers(waitCtx, podSelector)
+		if err != nil {
+			return false, err
+		}
+
+		selected = []*selector.SelectedPodContainer{}
+		for _, container := range containers {
+			if container.Pod.Name == primary.Pod.Name {
+				continue
+			} else if controller := metav1.GetControllerOf(container.Pod); controller == nil || controller.UID != owner.UID {
+				continue
+			}
+
+			selected = append(selected, container)
+		}
+		if len(selected)+1 >= replicas {
+			return true, nil
+		}
+
+		if time.Since(lastWarning) > time.Second*10 {
+			ctx.Log().Infof("%d/%d replicas are ready...", len(selected)+1, replicas)
+			lastWarning = time.Now()
+		}
+		return false, nil
+	})
+	if err != nil {
+		if ctx.IsDone() {
+			return nil, ctx.Context().Err()
+		} else if wait.Interrupted(err) {
+			return nil, errors.Errorf("timed out waiting for %d replicas to become ready", replicas)
+		}
+
+		return nil, err
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Pod.Name < selected[j].Pod.Name
+	})
+	return selected[:replicas-1], nil
+}
+
+// pickReplica asks which replica the terminal, attach and ssh should connect to. The replica that
+// was picked before is reused as long as it exists and the primary replica is the default if there
+// is nobody to ask.
+func pickReplica(ctx devspacecontext.Context, devPodConfig *latest.DevPod, primary *selector.SelectedPodContainer, replicas []*selector.SelectedPodContainer, picked string) (*selector.SelectedPodContainer, error) {
+	if len(replicas) == 0 || ctx.Log().GetLevel() < logrus.InfoLevel || !needsInteractiveReplica(devPodConfig) {
+		return primary, nil
+	} else if picked == primary.Pod.Name {
+		return primary, nil
+	}
+	for _, replica := range replicas {
+		if replica.Pod.Name == picked {
+			return replica, nil
+		}
+	}
+
+	options := []string{primary.Pod.Name}
+	for _, replica := range replicas {
+		options = append(options, replica.Pod.Name)
+	}
+
+	answer, err := ctx.Log().Question(&survey.QuestionOptions{
+		Question:        "Which replica should the terminal, attach and ssh connect to?",
+		DefaultValue:    primary.Pod.Name,
+		DefaultValueSet: true,
+		Options:         options,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, replica := range replicas {
+		if replica.Pod.Name == answer {
+			return replica, nil
+		}
+	}
+
+	return primary, nil
+}
+
+func needsInteractiveReplica(devPodConfig *latest.DevPod) bool {
+	needed := false
+	loader.EachDevContainer(devPodConfig, func(devContainer *latest.DevContainer) bool {
+		if devContainer.Terminal != nil && (devContainer.Terminal.Enabled == nil || *devContainer.Terminal.Enabled) {
+			needed = true
+		} else if devContainer.Attach != nil && (devContainer.Attach.Enabled == nil || *devContainer.Attach.Enabled) {
+			needed = true
+		} else if devContainer.SSH != nil && (devContainer.SSH.Enabled == nil || *devContainer.SSH.Enabled) {
+			needed = true
+		}
+
+		return !needed
+	})
+
+	return needed
+}
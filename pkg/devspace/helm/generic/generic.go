@@ -8,6 +8,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/utils/pkg/command"
 
 	"gopkg.in/yaml.v3"
@@ -17,6 +18,7 @@ import (
 	"github.com/loft-sh/utils/pkg/downloader"
 	"github.com/loft-sh/utils/pkg/downloader/commands"
 	"github.com/loft-sh/utils/pkg/extract"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/pkg/errors"
 )
@@ -65,8 +67,14 @@ func (c *client) WriteValues(values map[string]interface{}) (string, error) {
 	return f.Name(), nil
 }
 
-func (c *client) Exec(ctx devspacecontext.Context, args []string) ([]byte, error) {
-	err := c.ensureHelmBinary(ctx.Context())
+func (c *client) Exec(ctx devspacecontext.Context, args []string) (_ []byte, err error) {
+	spanCtx, span := tracing.Start(ctx.Context(), "helm "+args[0])
+	defer func() {
+		tracing.End(span, err)
+	}()
+	ctx = ctx.WithContext(spanCtx)
+
+	err = c.ensureHelmBinary(ctx.Context())
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +86,7 @@ func (c *client) Exec(ctx devspacecontext.Context, args []string) ([]byte, error
 	// disable log for list, because it prints same command multiple times if we've multiple deployments.
 	if args[0] != "list" && args[0] != "registry" && (len(args) == 1 || args[1] != "login") {
 		c.log.Debugf("Execute '%s %s' in directory %s", c.helmPath, strings.Join(args, " "), ctx.WorkingDir())
+		span.SetAttributes(attribute.StringSlice("devspace.args", args))
 	}
 
 	result, err := command.Output(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), c.helmPath, args...)
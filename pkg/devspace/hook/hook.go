@@ -11,12 +11,14 @@ import (
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/utils/pkg/command"
 	"github.com/mgutz/ansi"
 	dockerterm "github.com/moby/term"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -184,7 +186,7 @@ func executeHook(ctx devspacecontext.Context, hookConfig *latest.HookConfig, hoo
 	if hookConfig.Background {
 		ctx.Log().Infof("Execute hook '%s' in background at %s", ansi.Color(hookName(hookConfig), "white+b"), ansi.Color(event, "white+b"))
 		go func() {
-			err := hook.Execute(ctx.WithLogger(hookLog), hookConfig, extraEnv)
+			err := executeTraced(ctx.WithLogger(hookLog), hookConfig, extraEnv, hook, event)
 			if err != nil {
 				if hookConfig.Silent {
 					ctx.Log().Warnf("Error executing hook '%s' in background: %s %v", ansi.Color(hookName(hookConfig), "white+b"), hookWriter.(logpkg.NopCloser).Writer.(*bytes.Buffer).String(), err)
@@ -198,7 +200,7 @@ func executeHook(ctx devspacecontext.Context, hookConfig *latest.HookConfig, hoo
 	}
 
 	ctx.Log().Infof("Execute hook '%s' at %s", ansi.Color(hookName(hookConfig), "white+b"), ansi.Color(event, "white+b"))
-	err := hook.Execute(ctx.WithLogger(hookLog), hookConfig, extraEnv)
+	err := executeTraced(ctx.WithLogger(hookLog), hookConfig, extraEnv, hook, event)
 	if err != nil {
 		if hookConfig.Silent {
 			return errors.Wrapf(err, "in hook '%s': %s", ansi.Color(hookName(hookConfig), "white+b"), hookWriter.(*logpkg.NopCloser).Writer.(*bytes.Buffer).String())
@@ -209,6 +211,17 @@ func executeHook(ctx devspacecontext.Context, hookConfig *latest.HookConfig, hoo
 	return nil
 }
 
+// executeTraced executes the hook and records the execution as span
+func executeTraced(ctx devspacecontext.Context, hookConfig *latest.HookConfig, extraEnv map[string]string, hook Hook, event string) error {
+	spanCtx, span := tracing.Start(ctx.Context(), "hook "+hookName(hookConfig),
+		attribute.String("devspace.hook.event", event),
+		attribute.Bool("devspace.hook.background", hookConfig.Background),
+	)
+	err := hook.Execute(ctx.WithContext(spanCtx), hookConfig, extraEnv)
+	tracing.End(span, err)
+	return err
+}
+
 func hookName(hook *latest.HookConfig) string {
 	if hook.Name != "" {
 		return hook.Name
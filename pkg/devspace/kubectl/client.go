@@ -182,13 +182,14 @@ func CheckKubeContext(client Client, localCache localcache.Cache, noWarning, aut
 					log.Warnf("Last    kube context: '%s'", ansi.Color(lastConfigContext.Context, "white+b"))
 
 					// if terminal is not interactive then return the same client
-					if !isTerminalIn {
+					if !isTerminalIn && !survey.IsNonInteractive() {
 						return client, nil
 					}
 
 					kc, err := log.Question(&survey.QuestionOptions{
-						Question:     "Which context do you want to use?",
-						DefaultValue: currentConfigContext.Context,
+						Question:           "Which context do you want to use?",
+						DefaultValue:       currentConfigContext.Context,
+						NonInteractiveHint: "--kube-context, --switch-context or --no-warn",
 						Options: []string{
 							currentConfigContext.Context,
 							lastConfigContext.Context,
@@ -215,13 +216,14 @@ func CheckKubeContext(client Client, localCache localcache.Cache, noWarning, aut
 					log.Warnf("Last    namespace: '%s'", ansi.Color(lastConfigContext.Namespace, "white+b"))
 
 					// if terminal is not interactive then return the same client
-					if !isTerminalIn {
+					if !isTerminalIn && !survey.IsNonInteractive() {
 						return client, nil
 					}
 
 					ns, err := log.Question(&survey.QuestionOptions{
-						Question:     "Which namespace do you want to use?",
-						DefaultValue: currentConfigContext.Namespace,
+						Question:           "Which namespace do you want to use?",
+						DefaultValue:       currentConfigContext.Namespace,
+						NonInteractiveHint: "--namespace, --switch-context or --no-warn",
 						Options: []string{
 							currentConfigContext.Namespace,
 							lastConfigContext.Namespace,
@@ -239,15 +241,16 @@ func CheckKubeContext(client Client, localCache localcache.Cache, noWarning, aut
 		}
 
 		// Warn if using default namespace unless previous deployment was also to default namespace
-		if isTerminalIn &&
+		if (isTerminalIn || survey.IsNonInteractive()) &&
 			log.GetLevel() >= logrus.InfoLevel &&
 			currentConfigContext.Namespace == metav1.NamespaceDefault &&
 			(lastConfigContext == nil || lastConfigContext.Namespace != metav1.NamespaceDefault) {
 			log.Warn("Deploying into the 'default' namespace is usually not a good idea as this namespace cannot be deleted")
 			log.Warn("Please use 'devspace use namespace my-namespace' to select a different one\n")
 			useDefault, err := log.Question(&survey.QuestionOptions{
-				Question:     "Are you sure you want to use the 'default' namespace?",
-				DefaultValue: "No",
+				Question:           "Are you sure you want to use the 'default' namespace?",
+				DefaultValue:       "No",
+				NonInteractiveHint: "--namespace or --no-warn",
 				Options: []string{
 					"No",
 					"Yes",
@@ -0,0 +1,182 @@
+package lint
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the severity of a finding
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding is a single problem found in the config
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+
+	// Path is the dot separated config path the finding belongs to
+	Path string `json:"path,omitempty"`
+}
+
+// Rule is a single check that is executed on the config
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+
+	// PerFile rules only look at the raw content of a single file and are also
+	// checked on every imported file
+	PerFile bool
+
+	Check func(target *Target) []*Finding
+}
+
+// Target holds the config that should be linted
+type Target struct {
+	// File is the path of the config file
+	File string
+
+	// Content is the raw content of the config file
+	Content []byte
+
+	// Raw is the unparsed config
+	Raw map[string]interface{}
+
+	// Config is the parsed config with profiles applied and variables
+	// resolved. Can be nil if the config couldn't be loaded.
+	Config *latest.Config
+
+	// LoadErr is the error that occurred while loading the config
+	LoadErr error
+
+	// Imports are the other files the config was loaded from. Findings of the
+	// loaded config that are defined in an import are reported there.
+	Imports []*Target
+
+	doc *yaml.Node
+}
+
+// Lint runs the given rules on the target and returns all findings that were not suppressed
+// ordered by their file and position
+func Lint(target *Target, rules []*Rule) ([]*Finding, error) {
+	targets := append([]*Target{target}, target.Imports...)
+	for _, t := range targets {
+		if t.doc == nil && len(t.Content) > 0 {
+			t.doc = &yaml.Node{}
+			err := yaml.Unmarshal(t.Content, t.doc)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	findings := []*Finding{}
+	for _, rule := range rules {
+		for _, finding := range rule.Check(target) {
+			findings = append(findings, newFinding(target, rule, finding))
+		}
+		if !rule.PerFile {
+			continue
+		}
+
+		for _, imported := range target.Imports {
+			for _, finding := range rule.Check(imported) {
+				findings = append(findings, newFinding(imported, rule, finding))
+			}
+		}
+	}
+
+	suppressions := map[string]*suppressions{}
+	for _, t := range targets {
+		suppressions[t.File] = parseSuppressions(t.Content)
+	}
+	retFindings := []*Finding{}
+	for _, finding := range findings {
+		if s, ok := suppressions[finding.File]; ok && s.suppressed(finding) {
+			continue
+		}
+
+		retFindings = append(retFindings, finding)
+	}
+
+	order := map[string]int{}
+	for i, t := range targets {
+		order[t.File] = i
+	}
+	sort.SliceStable(retFindings, func(i, j int) bool {
+		if retFindings[i].File != retFindings[j].File {
+			return order[retFindings[i].File] < order[retFindings[j].File]
+		}
+		return retFindings[i].Line < retFindings[j].Line
+	})
+	return retFindings, nil
+}
+
+// newFinding fills the rule, severity and position of the finding. If the path of the
+// finding is not defined in the target, but in one of its imports, the import is used.
+func newFinding(target *Target, rule *Rule, finding *Finding) *Finding {
+	finding.Rule = rule.ID
+	if finding.Severity == "" {
+		finding.Severity = rule.Severity
+	}
+	if finding.File == "" {
+		finding.File = target.File
+	}
+	if finding.Line != 0 || finding.Path == "" {
+		return finding
+	}
+
+	path := explain.SplitPath(finding.Path)
+	line, ok := explain.Defines(target.doc, path)
+	if !ok {
+		for _, imported := range target.Imports {
+			if importLine, ok := explain.Defines(imported.doc, path); ok {
+				finding.File = imported.File
+				line = importLine
+				break
+			}
+		}
+	}
+
+	finding.Line = line
+	return finding
+}
+
+// Count returns the number of findings that have at least the given severity
+func Count(findings []*Finding, severity Severity) int {
+	count := 0
+	for _, finding := range findings {
+		if rank(finding.Severity) >= rank(severity) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func rank(severity Severity) int {
+	switch severity {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func joinPath(parts ...string) string {
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,260 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gopkg.in/yaml.v3"
+	"gotest.tools/assert"
+)
+
+type lintTestCase struct {
+	name   string
+	config string
+
+	expectedFindings []string
+}
+
+func TestLint(t *testing.T) {
+	testCases := []lintTestCase{
+		{
+			name: "valid config",
+			config: `version: v2beta1
+name: test
+vars:
+  TAG: latest
+images:
+  api:
+    image: myrepo/api
+deployments:
+  api:
+    helm:
+      values:
+        tag: ${TAG}
+dev:
+  api:
+    imageSelector: myrepo/api
+    ports:
+    - port: "8080"
+    sync:
+    - path: ./src:/app/src
+    - path: ./docs:/app/docs
+`,
+		},
+		{
+			name: "invalid config reports all errors",
+			config: `version: v2beta1
+name: test
+commands:
+  broken: {}
+pullSecrets:
+  secret: {}
+`,
+			expectedFindings: []string{
+				"4:invalid-config:error",
+				"6:invalid-config:error",
+			},
+		},
+		{
+			name: "suspicious config",
+			config: `version: v2beta1
+name: test
+vars:
+  UNUSED: test
+images:
+  api:
+    image: myrepo/api
+    docker:
+      useBuildKit: true
+dev:
+  api:
+    imageSelector: myrepo/other
+    ports:
+    - port: "8080"
+    sync:
+    - path: .:/app
+    - path: ./src:/app/src
+  web:
+    imageSelector: ${runtime.images.web.image}
+    ports:
+    - port: 8080:80
+profiles:
+- name: prod
+  patches:
+  - op: remove
+    path: dev.api.missing
+`,
+			expectedFindings: []string{
+				"4:unused-var:warning",
+				"9:deprecated-field:warning",
+				"12:image-selector-no-match:warning",
+				"17:sync-overlap:warning",
+				"19:image-selector-no-match:warning",
+				"21:duplicate-port:warning",
+				"26:patch-target-missing:warning",
+			},
+		},
+		{
+			name: "deprecated sync exec",
+			config: `version: v2beta1
+name: test
+dev:
+  api:
+    imageSelector: myrepo/api
+    sync:
+    - path: ./src:/app/src
+      onUpload:
+        execRemote:
+          command: make
+  worker:
+    imageSelector: myrepo/worker
+    containers:
+      worker:
+        sync:
+        - path: ./worker:/app
+          onUpload:
+            execRemote:
+              command: make
+`,
+			expectedFindings: []string{
+				"9:deprecated-field:warning",
+				"18:deprecated-field:warning",
+			},
+		},
+		{
+			name: "suppressed findings",
+			config: `version: v2beta1
+# devspace-lint-disable-file deprecated-field
+name: test
+vars:
+  UNUSED: test # devspace-lint-disable unused-var
+  # devspace-lint-disable
+  UNUSED2: test
+  UNUSED3: test # devspace-lint-disable sync-overlap
+images:
+  api:
+    image: myrepo/api
+    docker:
+      useBuildKit: true
+`,
+			expectedFindings: []string{
+				"8:unused-var:warning",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		target := &Target{
+			File:    "devspace.yaml",
+			Content: []byte(testCase.config),
+			Raw:     map[string]interface{}{},
+		}
+		err := yaml.Unmarshal(target.Content, &target.Raw)
+		assert.NilError(t, err, testCase.name)
+
+		// remove the profiles and vars as the loader would
+		data := map[string]interface{}{}
+		for k, v := range target.Raw {
+			if k != "profiles" && k != "vars" {
+				data[k] = v
+			}
+		}
+		target.Config, err = versions.ParseWithoutValidation(data, log.Discard)
+		assert.NilError(t, err, testCase.name)
+
+		findings, err := Lint(target, Rules)
+		assert.NilError(t, err, testCase.name)
+
+		actual := []string{}
+		for _, finding := range findings {
+			actual = append(actual, strings.Join([]string{strconv.Itoa(finding.Line), finding.Rule, string(finding.Severity)}, ":"))
+		}
+		if testCase.expectedFindings == nil {
+			testCase.expectedFindings = []string{}
+		}
+		assert.DeepEqual(t, actual, testCase.expectedFindings)
+	}
+}
+
+func TestLintImports(t *testing.T) {
+	target := &Target{
+		File: "devspace.yaml",
+		Content: []byte(`version: v2beta1
+name: test
+vars:
+  TAG: latest
+imports:
+- path: ./images.yaml
+`),
+		Raw: map[string]interface{}{},
+	}
+	imported := &Target{
+		File: "images.yaml",
+		Content: []byte(`version: v2beta1
+images:
+  api:
+    image: myrepo/api
+    docker:
+      useBuildKit: true
+  worker:
+    image: myrepo/worker
+    rebuildStrategy: sometimes
+    tags:
+    - ${TAG}
+`),
+		Raw: map[string]interface{}{},
+	}
+	target.Imports = []*Target{imported}
+	assert.NilError(t, yaml.Unmarshal(target.Content, &target.Raw))
+	assert.NilError(t, yaml.Unmarshal(imported.Content, &imported.Raw))
+
+	// the loaded config contains the values of the import and TAG is only used there
+	data := map[string]interface{}{"version": "v2beta1", "name": "test", "images": imported.Raw["images"]}
+	var err error
+	target.Config, err = versions.ParseWithoutValidation(data, log.Discard)
+	assert.NilError(t, err)
+
+	findings, err := Lint(target, Rules)
+	assert.NilError(t, err)
+
+	actual := []string{}
+	for _, finding := range findings {
+		actual = append(actual, strings.Join([]string{finding.File, strconv.Itoa(finding.Line), finding.Rule}, ":"))
+	}
+	assert.DeepEqual(t, actual, []string{
+		"images.yaml:6:deprecated-field",
+		"images.yaml:9:invalid-config",
+	})
+}
+
+func TestWriteSARIF(t *testing.T) {
+	findings := []*Finding{
+		{
+			Rule:     "unused-var",
+			Severity: SeverityWarning,
+			Message:  "variable TEST is defined but never used",
+			File:     "/project/devspace.yaml",
+			Line:     4,
+		},
+	}
+
+	out := &bytes.Buffer{}
+	err := WriteSARIF(out, findings, Rules, "1.0.0", "/project")
+	assert.NilError(t, err)
+
+	sarif := &sarifLog{}
+	err = json.Unmarshal(out.Bytes(), sarif)
+	assert.NilError(t, err)
+	assert.Equal(t, sarif.Version, "2.1.0")
+	assert.Equal(t, len(sarif.Runs), 1)
+	assert.Equal(t, len(sarif.Runs[0].Tool.Driver.Rules), len(Rules))
+	assert.Equal(t, len(sarif.Runs[0].Results), 1)
+	assert.Equal(t, sarif.Runs[0].Results[0].Level, "warning")
+	assert.Equal(t, sarif.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI, "devspace.yaml")
+	assert.Equal(t, sarif.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine, 4)
+}
@@ -0,0 +1,519 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/util"
+	"github.com/loft-sh/devspace/pkg/devspace/imageselector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/debug"
+	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
+	"gopkg.in/yaml.v3"
+)
+
+// Rules are all rules that are checked by devspace lint
+var Rules = []*Rule{
+	{
+		ID:          "invalid-config",
+		Severity:    SeverityError,
+		Description: "The config cannot be loaded or is invalid",
+		Check:       checkInvalidConfig,
+	},
+	{
+		ID:          "sync-overlap",
+		Severity:    SeverityWarning,
+		Description: "Sync paths of the same container overlap",
+		Check:       checkSyncOverlap,
+	},
+	{
+		ID:          "image-selector-no-match",
+		Severity:    SeverityWarning,
+		Description: "An imageSelector does not match any image of the config",
+		Check:       checkImageSelectors,
+	},
+	{
+		ID:          "duplicate-port",
+		Severity:    SeverityWarning,
+		Description: "A local port is forwarded more than once",
+		Check:       checkDuplicatePorts,
+	},
+	{
+		ID:          "unused-var",
+		Severity:    SeverityWarning,
+		Description: "A variable is defined but never used",
+		Check:       checkUnusedVars,
+	},
+	{
+		ID:          "patch-target-missing",
+		Severity:    SeverityWarning,
+		Description: "A profile patch targets a path that does not exist",
+		Check:       checkPatchTargets,
+	},
+	{
+		ID:          "deprecated-field",
+		Severity:    SeverityWarning,
+		Description: "A deprecated field is used",
+		PerFile:     true,
+		Check:       checkDeprecatedFields,
+	},
+}
+
+// deprecatedFields maps deprecated config paths to their replacement. A * matches any key or index.
+var deprecatedFields = map[string]string{
+	"images.*.docker.useBuildKit":                   "images.*.buildKit",
+	"images.*.custom.commands":                      "images.*.custom.command",
+	"images.*.custom.args":                          "images.*.custom.command",
+	"images.*.custom.appendArgs":                    "images.*.custom.command",
+	"images.*.custom.imageFlag":                     "images.*.custom.command",
+	"images.*.custom.imageTagOnly":                  "images.*.custom.command",
+	"images.*.custom.skipImageArg":                  "images.*.custom.command",
+	"dev.*.sync.*.onUpload.execRemote":              "dev.*.sync.*.onUpload.exec",
+	"dev.*.containers.*.sync.*.onUpload.execRemote": "dev.*.containers.*.sync.*.onUpload.exec",
+}
+
+var configPathRegEx = regexp.MustCompile(`\b([a-zA-Z]+)((?:\.[\w\-]+|\[[^\]]+\])*)`)
+
+var unknownFieldRegEx = regexp.MustCompile(`field (\S+) not found in type`)
+
+func checkInvalidConfig(target *Target) []*Finding {
+	if target.LoadErr != nil {
+		message := target.LoadErr.Error()
+		finding := &Finding{
+			Message: message,
+			Path:    pathFromMessage(strings.Split(message, "\n")[0]),
+		}
+		if matches := unknownFieldRegEx.FindStringSubmatch(message); matches != nil {
+			finding.Line = keyLine(target.doc, matches[1])
+		}
+
+		return []*Finding{finding}
+	} else if target.Config == nil {
+		return nil
+	}
+
+	findings := []*Finding{}
+	for _, err := range versions.ValidateAll(target.Config) {
+		findings = append(findings, &Finding{
+			Message: err.Error(),
+			Path:    pathFromMessage(err.Error()),
+		})
+	}
+
+	return findings
+}
+
+// pathFromMessage returns the first config path (e.g. images.api.image or hooks[0].command)
+// that is mentioned in the message
+func pathFromMessage(message string) string {
+	topLevel := map[string]bool{}
+	configType := reflect.TypeOf(latest.Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		name := strings.Split(configType.Field(i).Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			topLevel[name] = true
+		}
+	}
+
+	for _, match := range configPathRegEx.FindAllStringSubmatch(message, -1) {
+		if !topLevel[match[1]] {
+			continue
+		}
+
+		path := match[1] + strings.NewReplacer("[", ".", "]", "").Replace(match[2])
+		return strings.TrimRight(path, ".")
+	}
+
+	return ""
+}
+
+// keyLine returns the line of the first mapping key with the given name in the document
+func keyLine(node *yaml.Node, key string) int {
+	if node == nil {
+		return 0
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i].Line
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if line := keyLine(child, key); line > 0 {
+			return line
+		}
+	}
+
+	return 0
+}
+
+func checkSyncOverlap(target *Target) []*Finding {
+	if target.Config == nil {
+		return nil
+	}
+
+	findings := []*Finding{}
+	for _, devPodName := range sortedKeys(target.Config.Dev) {
+		devPod := target.Config.Dev[devPodName]
+		containers := map[string]*latest.DevContainer{"": &devPod.DevContainer}
+		for name, container := range devPod.Containers {
+			containers[name] = container
+		}
+
+		for _, containerName := range sortedKeys(containers) {
+			basePath := joinPath("dev", explain.JoinPath(devPodName))
+			if containerName != "" {
+				basePath = joinPath(basePath, "containers", explain.JoinPath(containerName))
+			}
+
+			syncConfigs := containers[containerName].Sync
+			for i := range syncConfigs {
+				for j := i + 1; j < len(syncConfigs); j++ {
+					if syncConfigs[i] == nil || syncConfigs[j] == nil {
+						continue
+					}
+
+					localA, _, errA := sync.ParseSyncPath(syncConfigs[i].Path)
+					localB, _, errB := sync.ParseSyncPath(syncConfigs[j].Path)
+					if errA != nil || errB != nil || !pathsOverlap(localA, localB) {
+						continue
+					}
+
+					findings = append(findings, &Finding{
+						Message: fmt.Sprintf("sync path %s overlaps with %s (%s), changed files might be synced twice", syncConfigs[j].Path, syncConfigs[i].Path, joinPath(basePath, "sync", strconv.Itoa(i))),
+						Path:    joinPath(basePath, "sync", strconv.Itoa(j), "path"),
+					})
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+func pathsOverlap(a, b string) bool {
+	a = filepath.ToSlash(filepath.Clean(a))
+	b = filepath.ToSlash(filepath.Clean(b))
+	return a == b || a == "." || b == "." || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+var runtimeImageRegEx = regexp.MustCompile(`^\$\{runtime\.images\.([^.}]+)(\.[^}]*)?\}$`)
+
+func checkImageSelectors(target *Target) []*Finding {
+	if target.Config == nil {
+		return nil
+	}
+
+	// collect all images we know about
+	images := []string{}
+	manifests := []string{}
+	for _, image := range target.Config.Images {
+		images = append(images, image.Image)
+	}
+	for _, deployment := range target.Config.Deployments {
+		if deployment.Helm != nil {
+			images = append(images, stringLeaves(deployment.Helm.Values)...)
+		}
+		if deployment.Kubectl != nil && deployment.Kubectl.InlineManifest != "" {
+			manifests = append(manifests, deployment.Kubectl.InlineManifest)
+		}
+	}
+
+	findings := []*Finding{}
+	for _, devPodName := range sortedKeys(target.Config.Dev) {
+		selector := target.Config.Dev[devPodName].ImageSelector
+		path := joinPath("dev", explain.JoinPath(devPodName), "imageSelector")
+		if selector == "" {
+			continue
+		}
+
+		// ${runtime.images.NAME...} has to reference a defined image
+		if matches := runtimeImageRegEx.FindStringSubmatch(selector); matches != nil {
+			if _, ok := target.Config.Images[matches[1]]; !ok {
+				findings = append(findings, &Finding{
+					Message: fmt.Sprintf("imageSelector %s references image %s, which is not defined in images", selector, matches[1]),
+					Path:    path,
+				})
+			}
+			continue
+		} else if strings.Contains(selector, "${") || strings.Contains(selector, "$(") {
+			continue
+		}
+
+		// images from outside of the config are fine if there are no images defined at all
+		if len(target.Config.Images) == 0 {
+			continue
+		}
+
+		found := false
+		for _, image := range images {
+			if imageselector.CompareImageNames(selector, image) {
+				found = true
+				break
+			}
+		}
+		for _, manifest := range manifests {
+			if strings.Contains(manifest, selector) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			findings = append(findings, &Finding{
+				Message: fmt.Sprintf("imageSelector %s does not match any image defined in images or deployments", selector),
+				Path:    path,
+			})
+		}
+	}
+
+	return findings
+}
+
+func stringLeaves(value interface{}) []string {
+	switch t := value.(type) {
+	case string:
+		return []string{t}
+	case map[string]interface{}:
+		leaves := []string{}
+		for _, v := range t {
+			leaves = append(leaves, stringLeaves(v)...)
+		}
+		return leaves
+	case []interface{}:
+		leaves := []string{}
+		for _, v := range t {
+			leaves = append(leaves, stringLeaves(v)...)
+		}
+		return leaves
+	}
+
+	return nil
+}
+
+func checkDuplicatePorts(target *Target) []*Finding {
+	if target.Config == nil {
+		return nil
+	}
+
+	used := map[string]string{}
+	findings := []*Finding{}
+	for _, devPodName := range sortedKeys(target.Config.Dev) {
+		devPod := target.Config.Dev[devPodName]
+		mappings := append([]*latest.PortMapping{}, devPod.Ports...)
+		debugStart := len(mappings)
+		mappings = append(mappings, debug.PortMappings(devPod)...)
+
+		for i, mapping := range mappings {
+			if mapping == nil {
+				continue
+			}
+
+			localPort := strings.Split(mapping.Port, ":")[0]
+			key := mapping.BindAddress + ":" + localPort
+			path := joinPath("dev", explain.JoinPath(devPodName), "ports", strconv.Itoa(i), "port")
+			if i >= debugStart {
+				path = joinPath("dev", explain.JoinPath(devPodName), "debug")
+			}
+
+			if other, ok := used[key]; ok {
+				findings = append(findings, &Finding{
+					Message: fmt.Sprintf("local port %s is already forwarded by %s", localPort, other),
+					Path:    path,
+				})
+				continue
+			}
+
+			used[key] = path
+		}
+	}
+
+	return findings
+}
+
+func checkUnusedVars(target *Target) []*Finding {
+	definedVars, ok := target.Raw["vars"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	// variables of the config can be used in its imports as well
+	content := string(target.Content)
+	for _, imported := range target.Imports {
+		content += "\n" + string(imported.Content)
+	}
+
+	findings := []*Finding{}
+	for _, name := range sortedKeys(definedVars) {
+		// variables prefixed with DEVSPACE_ are used by DevSpace itself
+		if strings.HasPrefix(name, "DEVSPACE_") {
+			continue
+		}
+
+		usage := regexp.MustCompile(`\$!?\{?` + regexp.QuoteMeta(name) + `(\}|[^\w\-.]|$)`)
+		if usage.MatchString(content) {
+			continue
+		}
+
+		findings = append(findings, &Finding{
+			Message: fmt.Sprintf("variable %s is defined but never used", name),
+			Path:    joinPath("vars", explain.JoinPath(name)),
+		})
+	}
+
+	return findings
+}
+
+func checkPatchTargets(target *Target) []*Finding {
+	rawProfiles, ok := target.Raw["profiles"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	base := map[string]interface{}{}
+	for k, v := range target.Raw {
+		if k != "profiles" {
+			base[k] = v
+		}
+	}
+
+	findings := []*Finding{}
+	for i, rawProfile := range rawProfiles {
+		profile := &latest.ProfileConfig{}
+		err := util.Convert(rawProfile, profile)
+		if err != nil {
+			continue
+		}
+
+		data, err := copyMap(base)
+		if err != nil {
+			continue
+		}
+		err = loader.ApplyReplace(data, profile)
+		if err != nil {
+			continue
+		}
+		data, err = loader.ApplyMerge(data, profile)
+		if err != nil {
+			continue
+		}
+
+		for j, patch := range profile.Patches {
+			if patch == nil || patch.Path == "" || (patch.Operation != "replace" && patch.Operation != "remove") {
+				continue
+			}
+
+			exists, err := loader.PatchTargetExists(data, patch)
+			if err != nil || exists {
+				continue
+			}
+
+			findings = append(findings, &Finding{
+				Message: fmt.Sprintf("profile %s: %s patch targets %s, which does not exist in the config", profile.Name, patch.Operation, patch.Path),
+				Path:    joinPath("profiles", strconv.Itoa(i), "patches", strconv.Itoa(j), "path"),
+			})
+		}
+	}
+
+	return findings
+}
+
+func copyMap(data map[string]interface{}) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	err := util.Convert(data, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func checkDeprecatedFields(target *Target) []*Finding {
+	findings := []*Finding{}
+	for _, path := range matchPaths(target.Raw, "") {
+		for pattern, replacement := range deprecatedFields {
+			if !pathMatches(pattern, path) {
+				continue
+			}
+
+			findings = append(findings, &Finding{
+				Message: fmt.Sprintf("%s is deprecated, please use %s instead", path, replacementFor(replacement, path)),
+				Path:    path,
+			})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Path < findings[j].Path
+	})
+	return findings
+}
+
+// matchPaths returns the paths of all keys in the config
+func matchPaths(value interface{}, prefix string) []string {
+	paths := []string{}
+	switch t := value.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			path := explain.JoinPath(k)
+			if prefix != "" {
+				path = joinPath(prefix, path)
+			}
+
+			paths = append(paths, path)
+			paths = append(paths, matchPaths(v, path)...)
+		}
+	case []interface{}:
+		for i, v := range t {
+			paths = append(paths, matchPaths(v, joinPath(prefix, strconv.Itoa(i)))...)
+		}
+	}
+
+	return paths
+}
+
+func pathMatches(pattern, path string) bool {
+	patternParts := strings.Split(pattern, ".")
+	pathParts := explain.SplitPath(path)
+	if len(patternParts) != len(pathParts) {
+		return false
+	}
+
+	for i := range patternParts {
+		if patternParts[i] != "*" && patternParts[i] != pathParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// replacementFor fills the wildcards of the replacement with the segments of the path
+func replacementFor(replacement, path string) string {
+	replacementParts := strings.Split(replacement, ".")
+	pathParts := explain.SplitPath(path)
+	for i := range replacementParts {
+		if replacementParts[i] == "*" && i < len(pathParts) {
+			replacementParts[i] = explain.JoinPath(pathParts[i])
+		}
+	}
+
+	return strings.Join(replacementParts, ".")
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
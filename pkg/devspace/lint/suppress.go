@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// suppressionRegEx matches comments like
+//
+//	# devspace-lint-disable sync-overlap,unused-var
+//	# devspace-lint-disable-file deprecated-field
+//
+// Without rule ids all rules are disabled.
+var suppressionRegEx = regexp.MustCompile(`#\s*devspace-lint-disable(-file)?(?:\s+([a-z0-9\-,\s]+))?\s*$`)
+
+type suppressions struct {
+	// lines maps a line to the rules that are disabled on it
+	lines map[int][]string
+
+	// file are the rules that are disabled for the whole file
+	file []string
+}
+
+// parseSuppressions finds all suppression comments in the content. A comment disables rules on
+// its own line and, if the comment is the only content of a line, on the following line.
+func parseSuppressions(content []byte) *suppressions {
+	s := &suppressions{
+		lines: map[int][]string{},
+	}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		matches := suppressionRegEx.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		rules := []string{}
+		for _, rule := range strings.Split(matches[2], ",") {
+			rule = strings.TrimSpace(rule)
+			if rule != "" {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) == 0 {
+			rules = []string{"*"}
+		}
+
+		if matches[1] != "" {
+			s.file = append(s.file, rules...)
+			continue
+		}
+
+		lineNumber := i + 1
+		s.lines[lineNumber] = append(s.lines[lineNumber], rules...)
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			s.lines[lineNumber+1] = append(s.lines[lineNumber+1], rules...)
+		}
+	}
+
+	return s
+}
+
+func (s *suppressions) suppressed(finding *Finding) bool {
+	return matchesRule(s.file, finding.Rule) || (finding.Line > 0 && matchesRule(s.lines[finding.Line], finding.Rule))
+}
+
+func matchesRule(rules []string, rule string) bool {
+	for _, r := range rules {
+		if r == "*" || r == rule {
+			return true
+		}
+	}
+
+	return false
+}
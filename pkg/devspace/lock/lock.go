@@ -0,0 +1,405 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/randutil"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// LeaseName is the name of the lease that locks a namespace
+	LeaseName = "devspace-lock"
+
+	// CommandAnnotation holds the command of the lock holder
+	CommandAnnotation = "devspace.sh/lock-command"
+)
+
+var (
+	// LeaseDuration is the time after which a lock that was not renewed can be taken over
+	LeaseDuration = 30 * time.Second
+
+	// RenewInterval is the interval in which a held lock is renewed
+	RenewInterval = 10 * time.Second
+
+	// PollInterval is the interval in which a locked namespace is checked while waiting
+	PollInterval = 2 * time.Second
+)
+
+// Options configure how a lock is acquired
+type Options struct {
+	// Wait waits until the lock is released instead of failing
+	Wait bool
+
+	// Force takes over the lock even if it is held by someone else
+	Force bool
+
+	// Command is the command that holds the lock, shown to others that try to acquire it
+	Command string
+
+	namespaceEnsured bool
+}
+
+// Lock is a namespace lock backed by a coordination.k8s.io lease
+type Lock struct {
+	kubeClient kubectl.Client
+	client     kubernetes.Interface
+	namespace  string
+	identity   string
+	log        log.Logger
+
+	m      sync.Mutex
+	held   bool
+	lost   error
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewLock creates a new lock for the namespace of the client
+func NewLock(client kubectl.Client, logger log.Logger) *Lock {
+	return &Lock{
+		kubeClient: client,
+		client:     client.KubeClient(),
+		namespace:  client.Namespace(),
+		identity:   Identity() + "/" + randutil.GenerateRandomString(5),
+		log:        logger,
+	}
+}
+
+// Identity returns the user and host that are shown as holder of a lock
+func Identity() string {
+	name := ""
+	u, err := user.Current()
+	if err == nil {
+		name = u.Username
+	}
+	if name == "" {
+		name = os.Getenv("USER")
+	}
+	if name == "" {
+		name = "unknown"
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return name
+	}
+
+	return name + "@" + hostname
+}
+
+// Namespace returns the namespace that is locked
+func (l *Lock) Namespace() string {
+	return l.namespace
+}
+
+// Acquire acquires the lock and starts renewing it in the background until it is released
+func (l *Lock) Acquire(ctx context.Context, options Options) error {
+	waiting := ""
+	for {
+		lease, err := l.tryAcquire(ctx, options)
+		if err == nil {
+			break
+		} else if kerrors.IsForbidden(err) {
+			l.log.Warnf("Skip locking namespace %s, because you are not allowed to manage leases: %v", l.namespace, err)
+			return nil
+		} else if lease == nil || !options.Wait {
+			return err
+		}
+
+		// print who we are waiting for once
+		if lease.Spec.HolderIdentity != nil && waiting != *lease.Spec.HolderIdentity {
+			waiting = *lease.Spec.HolderIdentity
+			l.log.Infof("Waiting for lock: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(PollInterval):
+		}
+	}
+
+	renewCtx, cancel := context.WithCancel(context.Background())
+	l.m.Lock()
+	l.held = true
+	l.lost = nil
+	l.cancel = cancel
+	l.done = make(chan struct{})
+	l.m.Unlock()
+
+	go l.renew(renewCtx)
+	return nil
+}
+
+// tryAcquire creates or takes over the lease. If the lease is held by someone else, the lease is
+// returned together with an error that describes the holder.
+func (l *Lock) tryAcquire(ctx context.Context, options Options) (*coordinationv1.Lease, error) {
+	now := metav1.NewMicroTime(time.Now())
+	leaseDurationSeconds := int32(LeaseDuration.Seconds())
+
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, LeaseName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "get lock")
+		}
+
+		_, err = l.client.CoordinationV1().Leases(l.namespace).Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        LeaseName,
+				Labels:      map[string]string{"owner": "devspace"},
+				Annotations: map[string]string{CommandAnnotation: options.Command},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &l.identity,
+				LeaseDurationSeconds: &leaseDurationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			if kerrors.IsAlreadyExists(err) {
+				return l.tryAcquire(ctx, options)
+			} else if kerrors.IsNotFound(err) && !options.namespaceEnsured {
+				// the namespace doesn't exist yet, so nobody can hold the lock
+				err = kubectl.EnsureNamespace(ctx, l.kubeClient, l.namespace, l.log)
+				if err != nil {
+					return nil, errors.Wrap(err, "create namespace")
+				}
+
+				options.namespaceEnsured = true
+				return l.tryAcquire(ctx, options)
+			}
+
+			return nil, errors.Wrap(err, "create lock")
+		}
+
+		return nil, nil
+	}
+
+	if isHeld(lease, l.identity) {
+		if !options.Force {
+			return lease, &LockedError{Namespace: l.namespace, Holder: Describe(lease)}
+		}
+
+		l.log.Warnf("Take over lock of namespace %s from %s", l.namespace, Describe(lease))
+	}
+
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[CommandAnnotation] = options.Command
+	lease.Spec.HolderIdentity = &l.identity
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	_, err = l.client.CoordinationV1().Leases(l.namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		if kerrors.IsConflict(err) {
+			return l.tryAcquire(ctx, options)
+		}
+
+		return nil, errors.Wrap(err, "update lock")
+	}
+
+	return nil, nil
+}
+
+func (l *Lock) renew(ctx context.Context) {
+	defer close(l.done)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(RenewInterval):
+		}
+
+		err := l.renewOnce(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			var lostErr *lostError
+			if errors.As(err, &lostErr) {
+				l.log.Warnf("Lost lock of namespace %s: %v", l.namespace, err)
+				l.m.Lock()
+				l.lost = err
+				l.m.Unlock()
+				return
+			}
+
+			l.log.Debugf("Error renewing lock of namespace %s: %v", l.namespace, err)
+		}
+	}
+}
+
+func (l *Lock) renewOnce(ctx context.Context) error {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, LeaseName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return &lostError{message: "lock was removed"}
+		}
+
+		return err
+	} else if !isOwn(lease, l.identity) {
+		return &lostError{message: "lock was taken over by " + Describe(lease)}
+	}
+
+	now := metav1.NewMicroTime(time.Now())
+	lease.Spec.RenewTime = &now
+	_, err = l.client.CoordinationV1().Leases(l.namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// Held returns true if the lock was acquired and not released yet
+func (l *Lock) Held() bool {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.held
+}
+
+// Err returns an error if the lock was lost in the meantime
+func (l *Lock) Err() error {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.lost != nil {
+		return errors.Wrapf(l.lost, "lock of namespace %s", l.namespace)
+	}
+
+	return nil
+}
+
+// Release stops renewing the lock and deletes the lease if it is still held by us
+func (l *Lock) Release() {
+	l.m.Lock()
+	if !l.held {
+		l.m.Unlock()
+		return
+	}
+	l.held = false
+	l.cancel()
+	done := l.done
+	l.m.Unlock()
+	<-done
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, LeaseName, metav1.GetOptions{})
+	if err != nil || !isOwn(lease, l.identity) {
+		return
+	}
+
+	err = l.client.CoordinationV1().Leases(l.namespace).Delete(ctx, LeaseName, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+	})
+	if err != nil && !kerrors.IsNotFound(err) {
+		l.log.Debugf("Error releasing lock of namespace %s: %v", l.namespace, err)
+	}
+}
+
+// Verify returns an error if the namespace is locked by someone else. If the lock of the namespace is
+// held through the context, it returns an error if the lock was lost.
+func Verify(ctx context.Context, client kubernetes.Interface, namespace string) error {
+	if held, ok := FromContext(ctx); ok && held.Namespace() == namespace {
+		if err := held.Err(); err != nil {
+			return err
+		} else if held.Held() {
+			return nil
+		}
+	}
+
+	lease, err := client.CoordinationV1().Leases(namespace).Get(ctx, LeaseName, metav1.GetOptions{})
+	if err != nil {
+		// namespaces without a lease or clusters where leases cannot be read are not locked
+		return nil
+	} else if isHeld(lease, "") {
+		return &LockedError{Namespace: namespace, Holder: Describe(lease)}
+	}
+
+	return nil
+}
+
+// Describe returns who holds the lease and since when
+func Describe(lease *coordinationv1.Lease) string {
+	holder := "unknown"
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+		if index := strings.LastIndex(holder, "/"); index != -1 {
+			holder = holder[:index]
+		}
+	}
+	if command := lease.Annotations[CommandAnnotation]; command != "" {
+		holder += " (" + command + ")"
+	}
+
+	if lease.Spec.AcquireTime != nil {
+		holder += fmt.Sprintf(" since %s (%s ago)", lease.Spec.AcquireTime.Format(time.RFC1123), duration.HumanDuration(time.Since(lease.Spec.AcquireTime.Time)))
+	}
+
+	return holder
+}
+
+// isHeld returns true if the lease is held by someone other than the identity and did not expire
+func isHeld(lease *coordinationv1.Lease, identity string) bool {
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" || *lease.Spec.HolderIdentity == identity {
+		return false
+	} else if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+
+	expires := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return time.Now().Before(expires)
+}
+
+func isOwn(lease *coordinationv1.Lease, identity string) bool {
+	return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == identity
+}
+
+// LockedError is returned if the namespace is locked by someone else
+type LockedError struct {
+	Namespace string
+	Holder    string
+}
+
+func (l *LockedError) Error() string {
+	return fmt.Sprintf("namespace %s is locked by %s", l.Namespace, l.Holder)
+}
+
+type lostError struct {
+	message string
+}
+
+func (l *lostError) Error() string {
+	return l.message
+}
+
+type lockKey struct{}
+
+// WithLock returns a context that holds the lock
+func WithLock(ctx context.Context, lock *Lock) context.Context {
+	return context.WithValue(ctx, lockKey{}, lock)
+}
+
+// FromContext returns the lock held through the context
+func FromContext(ctx context.Context) (*Lock, bool) {
+	lock, ok := ctx.Value(lockKey{}).(*Lock)
+	return lock, ok
+}
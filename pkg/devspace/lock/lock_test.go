@@ -0,0 +1,121 @@
+package lock
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestLock(t *testing.T) {
+	client := &kubectltesting.Client{Client: fake.NewSimpleClientset()}
+	ctx := context.Background()
+
+	first := NewLock(client, log.Discard)
+	assert.NilError(t, first.Acquire(ctx, Options{Command: "devspace dev"}))
+	defer first.Release()
+	assert.Assert(t, first.Held())
+	assert.NilError(t, Verify(WithLock(ctx, first), client.Client, client.Namespace()))
+
+	// a second run cannot acquire the lock and sees who holds it
+	second := NewLock(client, log.Discard)
+	err := second.Acquire(ctx, Options{Command: "devspace deploy"})
+	lockedErr := &LockedError{}
+	assert.Assert(t, errors.As(err, &lockedErr))
+	assert.Assert(t, strings.HasPrefix(lockedErr.Holder, Identity()+" (devspace dev) since "), lockedErr.Holder)
+	assert.Assert(t, !second.Held())
+
+	// saving without the lock fails
+	err = Verify(ctx, client.Client, client.Namespace())
+	assert.ErrorContains(t, err, "namespace testNamespace is locked by "+Identity()+" (devspace dev)")
+
+	// waiting stops when the context is done
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, second.Acquire(waitCtx, Options{Wait: true}), context.DeadlineExceeded)
+
+	// forcing takes over the lock and the first run loses it
+	assert.NilError(t, second.Acquire(ctx, Options{Force: true, Command: "devspace deploy"}))
+	assert.ErrorContains(t, first.renewOnce(ctx), "lock was taken over by "+Identity()+" (devspace deploy)")
+
+	// releasing deletes the lease
+	second.Release()
+	_, err = client.Client.CoordinationV1().Leases(client.Namespace()).Get(ctx, LeaseName, metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+}
+
+func TestLockMissingNamespace(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	client := &kubectltesting.Client{Client: clientset}
+	ctx := context.Background()
+
+	// leases cannot be created in a namespace that doesn't exist
+	clientset.PrependReactor("create", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		_, err := clientset.Tracker().Get(corev1.SchemeGroupVersion.WithResource("namespaces"), "", action.GetNamespace())
+		return err != nil, nil, err
+	})
+
+	namespaceLock := NewLock(client, log.Discard)
+	assert.NilError(t, namespaceLock.Acquire(ctx, Options{Command: "devspace dev"}))
+	defer namespaceLock.Release()
+	assert.Assert(t, namespaceLock.Held())
+
+	_, err := clientset.CoreV1().Namespaces().Get(ctx, client.Namespace(), metav1.GetOptions{})
+	assert.NilError(t, err)
+	_, err = clientset.CoordinationV1().Leases(client.Namespace()).Get(ctx, LeaseName, metav1.GetOptions{})
+	assert.NilError(t, err)
+}
+
+func TestLockWait(t *testing.T) {
+	oldPollInterval := PollInterval
+	PollInterval = 10 * time.Millisecond
+	defer func() { PollInterval = oldPollInterval }()
+
+	client := &kubectltesting.Client{Client: fake.NewSimpleClientset()}
+	ctx := context.Background()
+
+	first := NewLock(client, log.Discard)
+	assert.NilError(t, first.Acquire(ctx, Options{}))
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		first.Release()
+	}()
+
+	second := NewLock(client, log.Discard)
+	assert.NilError(t, second.Acquire(ctx, Options{Wait: true}))
+	assert.Assert(t, second.Held())
+	second.Release()
+}
+
+func TestLockExpired(t *testing.T) {
+	client := &kubectltesting.Client{Client: fake.NewSimpleClientset()}
+	ctx := context.Background()
+
+	first := NewLock(client, log.Discard)
+	assert.NilError(t, first.Acquire(ctx, Options{}))
+
+	// a lock that was not renewed in time can be taken over
+	lease, err := client.Client.CoordinationV1().Leases(client.Namespace()).Get(ctx, LeaseName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	expired := metav1.NewMicroTime(time.Now().Add(-LeaseDuration - time.Second))
+	lease.Spec.RenewTime = &expired
+	_, err = client.Client.CoordinationV1().Leases(client.Namespace()).Update(ctx, lease, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+
+	assert.NilError(t, Verify(ctx, client.Client, client.Namespace()))
+	second := NewLock(client, log.Discard)
+	assert.NilError(t, second.Acquire(ctx, Options{}))
+	second.Release()
+	first.Release()
+}
@@ -0,0 +1,202 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/basichandler"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/pipelinehandler"
+)
+
+// functionArguments maps pipeline functions to the config section their arguments refer to
+var functionArguments = map[string]string{
+	"build_images":             "images",
+	"get_image":                "images",
+	"create_deployments":       "deployments",
+	"purge_deployments":        "deployments",
+	"start_dev":                "dev",
+	"stop_dev":                 "dev",
+	"run_pipelines":            "pipelines",
+	"run_default_pipeline":     "pipelines",
+	"run_dependencies":         "dependencies",
+	"run_dependency_pipelines": "dependencies",
+}
+
+func (s *Server) completion(doc *document, position Position) *CompletionList {
+	items := []*CompletionItem{}
+	prefix := doc.prefix(position)
+	if name, ok := variablePrefix(prefix); ok {
+		items = s.variableCompletion(doc, name)
+	} else if script, ok := scriptPrefix(doc, position); ok {
+		items = s.scriptCompletion(doc, script)
+	} else {
+		items = keyCompletion(doc, position)
+	}
+
+	return &CompletionList{Items: items}
+}
+
+// variablePrefix returns the part of the variable name in front of the position if the
+// position is inside a ${...} reference
+func variablePrefix(prefix string) (string, bool) {
+	idx := strings.LastIndex(prefix, "${")
+	if idx == -1 || strings.Contains(prefix[idx:], "}") {
+		return "", false
+	}
+
+	return prefix[idx+2:], true
+}
+
+func (s *Server) variableCompletion(doc *document, prefix string) []*CompletionItem {
+	files := s.configFiles(doc)
+	items := []*CompletionItem{}
+	for _, name := range names(files, "vars") {
+		items = append(items, &CompletionItem{Label: name, Kind: CompletionItemKindVariable, Detail: "variable"})
+	}
+	for _, name := range variable.PredefinedVariableNames() {
+		items = append(items, &CompletionItem{Label: name, Kind: CompletionItemKindVariable, Detail: "predefined variable"})
+	}
+	for _, name := range names(files, "images") {
+		items = append(items,
+			&CompletionItem{Label: "runtime.images." + name + ".image", Kind: CompletionItemKindVariable, Detail: "image name of " + name + " without tag"},
+			&CompletionItem{Label: "runtime.images." + name + ".tag", Kind: CompletionItemKindVariable, Detail: "tag of image " + name},
+		)
+	}
+	for _, name := range names(files, "dependencies") {
+		items = append(items, &CompletionItem{Label: "runtime.dependencies." + name, Kind: CompletionItemKindVariable, Detail: "runtime variables of dependency " + name})
+	}
+
+	// clients treat dots as word boundary, so we only insert the rest of the current segment
+	retItems := []*CompletionItem{}
+	for _, item := range items {
+		if !strings.HasPrefix(item.Label, prefix) {
+			continue
+		}
+		if idx := strings.LastIndex(prefix, "."); idx != -1 {
+			item.InsertText = item.Label[idx+1:]
+		}
+
+		retItems = append(retItems, item)
+	}
+
+	return retItems
+}
+
+// scriptPrefix returns the script in front of the position if the position is inside
+// a pipeline or function script
+func scriptPrefix(doc *document, position Position) (string, bool) {
+	path := doc.parentPath(position.Line)
+	prefix := doc.prefix(position)
+	key, keyRange, ok := doc.keyAt(position.Line)
+	if ok && position.Character > keyRange.End.Character && isScriptPath(append(path, key)) {
+		script := strings.TrimPrefix(strings.TrimLeft(prefix[keyRange.End.Character:], " "), ":")
+		if trimmed := strings.TrimSpace(script); strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, ">") {
+			return "", false
+		}
+
+		return script, true
+	} else if isScriptPath(path) {
+		return prefix, true
+	}
+
+	return "", false
+}
+
+func isScriptPath(path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	switch path[0] {
+	case "pipelines":
+		return len(path) == 2 || (len(path) == 3 && path[2] == "run")
+	case "functions":
+		return len(path) == 2
+	}
+
+	return false
+}
+
+func (s *Server) scriptCompletion(doc *document, script string) []*CompletionItem {
+	// find the start of the current command
+	start := 0
+	for _, separator := range []string{";", "&&", "||", "|", "$(", "(", "`"} {
+		if idx := strings.LastIndex(script, separator); idx != -1 && idx+len(separator) > start {
+			start = idx + len(separator)
+		}
+	}
+
+	command := strings.TrimLeft(script[start:], " \t")
+	fields := strings.Fields(command)
+	files := s.configFiles(doc)
+	items := []*CompletionItem{}
+	if len(fields) == 0 || (len(fields) == 1 && !strings.HasSuffix(command, " ")) {
+		for _, name := range pipelineFunctions(files) {
+			items = append(items, &CompletionItem{Label: name, Kind: CompletionItemKindFunction, Detail: "pipeline function"})
+		}
+
+		return items
+	}
+
+	section, ok := functionArguments[fields[0]]
+	if !ok {
+		return items
+	}
+	for _, name := range names(files, section) {
+		items = append(items, &CompletionItem{Label: name, Kind: CompletionItemKindValue, Detail: strings.TrimSuffix(section, "s")})
+	}
+
+	return items
+}
+
+// pipelineFunctions returns the names of all functions that can be used in a pipeline
+func pipelineFunctions(files []*configFile) []string {
+	retFunctions := names(files, "functions")
+	for name := range pipelinehandler.PipelineCommands {
+		retFunctions = append(retFunctions, name)
+	}
+	for name := range basichandler.BasicCommands {
+		retFunctions = append(retFunctions, name)
+	}
+	for name := range basichandler.OverwriteCommands {
+		if !contains(retFunctions, name) {
+			retFunctions = append(retFunctions, name)
+		}
+	}
+
+	sort.Strings(retFunctions)
+	return retFunctions
+}
+
+// keyCompletion completes config keys from the schema or enum values after a key
+func keyCompletion(doc *document, position Position) []*CompletionItem {
+	path := doc.parentPath(position.Line)
+	items := []*CompletionItem{}
+	key, keyRange, ok := doc.keyAt(position.Line)
+	if ok && position.Character > keyRange.End.Character {
+		for _, value := range schemaEnum(schemaNodes(getSchema(), append(path, key))) {
+			items = append(items, &CompletionItem{Label: value, Kind: CompletionItemKindEnum})
+		}
+
+		return items
+	}
+
+	for name, property := range schemaProperties(schemaNodes(getSchema(), path)) {
+		item := &CompletionItem{
+			Label:      name,
+			Kind:       CompletionItemKindField,
+			InsertText: name + ": ",
+		}
+		if description := schemaDescription(alternatives(getSchema(), property)); description != "" {
+			item.Documentation = &MarkupContent{Kind: "markdown", Value: description}
+		}
+
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
@@ -0,0 +1,95 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *Server) definition(doc *document, position Position) *Location {
+	files := s.configFiles(doc)
+	if name, _, ok := variableAt(doc, position); ok {
+		return locate(files, variableDefinitionPath(name))
+	}
+
+	if script, ok := scriptPrefix(doc, position); ok {
+		word, wordRange := doc.wordAt(position, isNameChar)
+		if word == "" {
+			return nil
+		}
+
+		// the script in front of the word
+		script = script[:len(script)-(position.Character-wordRange.Start.Character)]
+		start := 0
+		for _, separator := range []string{";", "&&", "||", "|", "$(", "(", "`"} {
+			if idx := strings.LastIndex(script, separator); idx != -1 && idx+len(separator) > start {
+				start = idx + len(separator)
+			}
+		}
+
+		fields := strings.Fields(script[start:])
+		if len(fields) == 0 {
+			return locate(files, []string{"functions", word})
+		} else if section, ok := functionArguments[fields[0]]; ok && !strings.HasPrefix(word, "-") {
+			return locate(files, []string{section, word})
+		}
+
+		return nil
+	}
+
+	return s.importDefinition(doc, position)
+}
+
+// variableDefinitionPath returns the config path that defines the variable
+func variableDefinitionPath(name string) []string {
+	if strings.HasPrefix(name, "runtime.images.") {
+		return []string{"images", strings.Split(strings.TrimPrefix(name, "runtime.images."), ".")[0]}
+	} else if strings.HasPrefix(name, "runtime.dependencies.") {
+		return []string{"dependencies", strings.Split(strings.TrimPrefix(name, "runtime.dependencies."), ".")[0]}
+	}
+
+	return []string{"vars", name}
+}
+
+// importDefinition returns the location of the imported config if the position is on an import path
+func (s *Server) importDefinition(doc *document, position Position) *Location {
+	key, keyRange, ok := doc.keyAt(position.Line)
+	if !ok || position.Character <= keyRange.End.Character || (key != "path" && key != "subPath") {
+		return nil
+	}
+
+	path := doc.parentPath(position.Line)
+	if len(path) != 2 || path[0] != "imports" {
+		return nil
+	}
+
+	_, imports := findEntry(doc.node(), []string{"imports"})
+	if imports == nil {
+		return nil
+	}
+
+	// find the import that is defined at the position
+	var importConfig map[string]interface{}
+	for _, item := range imports.Content {
+		for i := 0; i < len(item.Content); i += 2 {
+			if item.Content[i].Line == position.Line+1 {
+				_ = item.Decode(&importConfig)
+			}
+		}
+	}
+
+	paths := importPaths(map[string]interface{}{"imports": []interface{}{importConfig}})
+	if importConfig == nil || len(paths) == 0 {
+		return nil
+	}
+
+	configPath := paths[0]
+	if !filepath.IsAbs(configPath) {
+		configPath = filepath.Join(filepath.Dir(doc.Path), configPath)
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return nil
+	}
+
+	return &Location{URI: pathToURI(configPath)}
+}
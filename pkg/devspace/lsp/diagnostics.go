@@ -0,0 +1,191 @@
+package lsp
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/explain"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/lint"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var yamlErrorLineRegEx = regexp.MustCompile(`line (\d+):`)
+
+// variableRegEx matches variable references and expressions that are only resolved by the loader
+var variableRegEx = regexp.MustCompile(`\$\{[^}]*\}|\$\([^)]*\)`)
+
+func (s *Server) publishDiagnostics(ctx context.Context, doc *document, load bool) error {
+	return s.conn.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{
+		URI:         doc.URI,
+		Diagnostics: s.diagnostics(ctx, doc, load),
+	})
+}
+
+// diagnostics lints the document. By default only static checks on the document are
+// done. If the loadConfig option is enabled and load is true, the config is loaded from disk
+// with the config loader to also report loader errors.
+func (s *Server) diagnostics(ctx context.Context, doc *document, load bool) []*Diagnostic {
+	target := &lint.Target{
+		File:    doc.Path,
+		Content: []byte(doc.Text),
+		Raw:     map[string]interface{}{},
+	}
+	err := yaml.Unmarshal(target.Content, &target.Raw)
+	if err != nil {
+		line := 0
+		if matches := yamlErrorLineRegEx.FindStringSubmatch(err.Error()); matches != nil {
+			line, _ = strconv.Atoi(matches[1])
+		}
+
+		return []*Diagnostic{newDiagnostic(doc, line, DiagnosticSeverityError, "", err.Error())}
+	}
+
+	static := false
+	if _, ok := target.Raw["version"]; ok {
+		if s.loadConfig && load {
+			if _, err := os.Stat(doc.Path); err == nil {
+				target.Config, target.LoadErr = loadConfig(ctx, doc.Path)
+
+				// questions can't be asked in the language server, so variables without a
+				// value are no error
+				if errors.As(target.LoadErr, &log.SurveyError{}) {
+					target.LoadErr = nil
+				}
+			}
+		} else {
+			static = true
+			target.Config, target.LoadErr = parseConfig(target.Raw)
+		}
+	}
+
+	findings, err := lint.Lint(target, lint.Rules)
+	if err != nil {
+		s.log.Debugf("lsp: lint %s: %v", doc.Path, err)
+		return []*Diagnostic{}
+	}
+
+	diagnostics := []*Diagnostic{}
+	for _, finding := range findings {
+		// without the loader, variables are not resolved and can make values invalid
+		if static && finding.Rule == "invalid-config" && usesVariables(target.Raw, finding.Path) {
+			continue
+		}
+
+		severity := DiagnosticSeverityInformation
+		switch finding.Severity {
+		case lint.SeverityError:
+			severity = DiagnosticSeverityError
+		case lint.SeverityWarning:
+			severity = DiagnosticSeverityWarning
+		}
+
+		diagnostics = append(diagnostics, newDiagnostic(doc, finding.Line, severity, finding.Rule, finding.Message))
+	}
+
+	return diagnostics
+}
+
+func loadConfig(ctx context.Context, path string) (*latest.Config, error) {
+	configLoader, err := loader.NewConfigLoader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := configLoader.LoadWithParser(ctx, nil, nil, loader.NewLintParser(), &loader.ConfigOptions{Dry: true}, log.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.Config(), nil
+}
+
+// parseConfig converts the document into the latest config without resolving variables,
+// profiles or imports. Variables are replaced with a placeholder or, if the value then still
+// can't be parsed (e.g. numbers), left out.
+func parseConfig(raw map[string]interface{}) (*latest.Config, error) {
+	data := map[string]interface{}{}
+	for k, v := range raw {
+		if k != "profiles" && k != "vars" {
+			data[k] = v
+		}
+	}
+
+	config, err := versions.ParseWithoutValidation(replaceVariables(data, false).(map[string]interface{}), log.Discard)
+	if err != nil {
+		config, err = versions.ParseWithoutValidation(replaceVariables(data, true).(map[string]interface{}), log.Discard)
+	}
+
+	return config, err
+}
+
+// replaceVariables returns a copy of the value where variables in strings are replaced with
+// a placeholder. If remove is true, map entries with variables are removed instead and list
+// items with variables are replaced with an empty string.
+func replaceVariables(value interface{}, remove bool) interface{} {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, v := range t {
+			if !remove || !isVariable(v) {
+				out[k] = replaceVariables(v, remove)
+			}
+		}
+		return out
+	case []interface{}:
+		out := []interface{}{}
+		for _, v := range t {
+			if remove && isVariable(v) {
+				out = append(out, "")
+			} else {
+				out = append(out, replaceVariables(v, remove))
+			}
+		}
+		return out
+	case string:
+		return variableRegEx.ReplaceAllString(t, "var")
+	default:
+		return value
+	}
+}
+
+func isVariable(value interface{}) bool {
+	str, ok := value.(string)
+	return ok && variableRegEx.MatchString(str)
+}
+
+// usesVariables returns true if the value at the path references a variable or expression
+func usesVariables(raw map[string]interface{}, path string) bool {
+	value, ok := explain.Lookup(raw, path)
+	if !ok || path == "" {
+		return false
+	}
+
+	out, err := yaml.Marshal(value)
+	return err == nil && variableRegEx.Match(out)
+}
+
+// newDiagnostic creates a diagnostic that spans the given one based line
+func newDiagnostic(doc *document, line int, severity DiagnosticSeverity, code, message string) *Diagnostic {
+	if line > 0 {
+		line--
+	}
+
+	text := doc.line(line)
+	return &Diagnostic{
+		Range: Range{
+			Start: Position{Line: line, Character: indentation(text)},
+			End:   Position{Line: line, Character: len(text)},
+		},
+		Severity: severity,
+		Code:     code,
+		Source:   "devspace",
+		Message:  message,
+	}
+}
@@ -0,0 +1,217 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var keyRegEx = regexp.MustCompile(`^(\s*)(- +)?("[^"]*"|'[^']*'|[^\s#'"][^:#]*?)\s*:(\s|$)`)
+
+// document is a text document that is open in the client
+type document struct {
+	URI   string
+	Path  string
+	Text  string
+	Lines []string
+}
+
+func newDocument(uri, text string) *document {
+	return &document{
+		URI:   uri,
+		Path:  uriToPath(uri),
+		Text:  text,
+		Lines: strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"),
+	}
+}
+
+// line returns the text of the given line
+func (d *document) line(line int) string {
+	if line < 0 || line >= len(d.Lines) {
+		return ""
+	}
+
+	return d.Lines[line]
+}
+
+// prefix returns the text of the line in front of the position
+func (d *document) prefix(position Position) string {
+	text := d.line(position.Line)
+	if position.Character > len(text) {
+		return text
+	} else if position.Character < 0 {
+		return ""
+	}
+
+	return text[:position.Character]
+}
+
+// raw parses the document into a map, invalid documents return nil
+func (d *document) raw() map[string]interface{} {
+	raw := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(d.Text), &raw)
+	if err != nil {
+		return nil
+	}
+
+	return raw
+}
+
+// node parses the document into a yaml node, invalid documents return nil
+func (d *document) node() *yaml.Node {
+	node := &yaml.Node{}
+	err := yaml.Unmarshal([]byte(d.Text), node)
+	if err != nil {
+		return nil
+	}
+
+	return node
+}
+
+// parentPath returns the key path of the mapping the given line belongs to. The
+// path is determined by the indentation, so it also works for documents that
+// are currently not valid yaml. List items are returned as *.
+func (d *document) parentPath(line int) []string {
+	text := d.line(line)
+	indent := indentation(text)
+	allowEqual := false
+	reversed := []string{}
+
+	// a list item on the current line
+	if strings.HasPrefix(strings.TrimSpace(text), "-") {
+		reversed = append(reversed, "*")
+		allowEqual = true
+	} else if strings.TrimSpace(text) == "" {
+		indent = len(text)
+	}
+
+	for i := line - 1; i >= 0 && (indent > 0 || allowEqual); i-- {
+		current := d.Lines[i]
+		trimmed := strings.TrimSpace(current)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		currentIndent := indentation(current)
+		matches := keyRegEx.FindStringSubmatch(current)
+		if matches == nil {
+			// list items without keys or block scalar content
+			if strings.HasPrefix(trimmed, "-") && currentIndent < indent {
+				reversed = append(reversed, "*")
+				indent = currentIndent
+				allowEqual = true
+			}
+			continue
+		}
+
+		key := unquote(matches[3])
+		if matches[2] != "" {
+			keyIndent := len(matches[1]) + len(matches[2])
+			if keyIndent < indent {
+				reversed = append(reversed, key, "*")
+			} else if keyIndent == indent {
+				reversed = append(reversed, "*")
+			} else {
+				continue
+			}
+
+			indent = currentIndent
+			allowEqual = true
+			continue
+		}
+
+		if currentIndent < indent || (allowEqual && currentIndent == indent) {
+			reversed = append(reversed, key)
+			indent = currentIndent
+			allowEqual = false
+		}
+	}
+
+	path := []string{}
+	for i := len(reversed) - 1; i >= 0; i-- {
+		path = append(path, reversed[i])
+	}
+	return path
+}
+
+// keyAt returns the key that is defined on the given line and its range
+func (d *document) keyAt(line int) (string, Range, bool) {
+	matches := keyRegEx.FindStringSubmatchIndex(d.line(line))
+	if matches == nil {
+		return "", Range{}, false
+	}
+
+	text := d.line(line)
+	return unquote(text[matches[6]:matches[7]]), Range{
+		Start: Position{Line: line, Character: matches[6]},
+		End:   Position{Line: line, Character: matches[7]},
+	}, true
+}
+
+// wordAt returns the word at the position that consists of the given characters
+func (d *document) wordAt(position Position, isWordChar func(c byte) bool) (string, Range) {
+	text := d.line(position.Line)
+	start := position.Character
+	if start > len(text) {
+		start = len(text)
+	}
+	end := start
+	for start > 0 && isWordChar(text[start-1]) {
+		start--
+	}
+	for end < len(text) && isWordChar(text[end]) {
+		end++
+	}
+
+	return text[start:end], Range{
+		Start: Position{Line: position.Line, Character: start},
+		End:   Position{Line: position.Line, Character: end},
+	}
+}
+
+func isVariableChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isNameChar(c byte) bool {
+	return c != '.' && isVariableChar(c)
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func unquote(key string) string {
+	if len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0] {
+		return key[1 : len(key)-1]
+	}
+
+	return strings.TrimSpace(key)
+}
+
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return uri
+	}
+
+	path := parsed.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+
+	return filepath.FromSlash(path)
+}
+
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,81 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"gopkg.in/yaml.v3"
+)
+
+func (s *Server) hover(doc *document, position Position) *Hover {
+	if name, nameRange, ok := variableAt(doc, position); ok {
+		content := s.variableDocs(doc, name)
+		if content == "" {
+			return nil
+		}
+
+		return &Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: content},
+			Range:    &nameRange,
+		}
+	}
+
+	key, keyRange, ok := doc.keyAt(position.Line)
+	if !ok || position.Character < keyRange.Start.Character || position.Character > keyRange.End.Character {
+		return nil
+	}
+
+	path := append(doc.parentPath(position.Line), key)
+	description := schemaDescription(schemaNodes(getSchema(), path))
+	if description == "" {
+		return nil
+	}
+
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  "markdown",
+			Value: fmt.Sprintf("**%s**\n\n%s", strings.Join(path, "."), description),
+		},
+		Range: &keyRange,
+	}
+}
+
+// variableAt returns the variable name of the ${...} reference at the position
+func variableAt(doc *document, position Position) (string, Range, bool) {
+	if _, ok := variablePrefix(doc.prefix(position)); !ok {
+		return "", Range{}, false
+	}
+
+	name, nameRange := doc.wordAt(position, isVariableChar)
+	return name, nameRange, name != ""
+}
+
+func (s *Server) variableDocs(doc *document, name string) string {
+	if variable.IsPredefinedVariable(name) {
+		return fmt.Sprintf("**${%s}**\n\nPredefined DevSpace variable", name)
+	} else if strings.HasPrefix(name, "runtime.") {
+		return fmt.Sprintf("**${%s}**\n\nRuntime variable that is resolved during pipeline execution", name)
+	}
+
+	for _, file := range s.configFiles(doc) {
+		vars, ok := file.Raw["vars"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		definition, ok := vars[name]
+		if !ok {
+			continue
+		}
+
+		out, err := yaml.Marshal(definition)
+		if err != nil {
+			return ""
+		}
+
+		return fmt.Sprintf("**${%s}**\n\n```yaml\n%s```", name, string(out))
+	}
+
+	return fmt.Sprintf("**${%s}**\n\nVariable is not defined in vars and will be loaded from the environment", name)
+}
@@ -0,0 +1,173 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is a config file that belongs to the project of an open document, either
+// the document itself or one of its local imports
+type configFile struct {
+	Path string
+	Raw  map[string]interface{}
+	Node *yaml.Node
+}
+
+// configFiles returns the document and all local files it imports recursively
+func (s *Server) configFiles(doc *document) []*configFile {
+	visited := map[string]bool{}
+	files := []*configFile{}
+	s.collectConfigFiles(doc.Path, doc, visited, &files)
+	return files
+}
+
+func (s *Server) collectConfigFiles(path string, doc *document, visited map[string]bool, files *[]*configFile) {
+	if path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+	}
+	if visited[path] {
+		return
+	}
+	visited[path] = true
+
+	if doc == nil {
+		doc = s.getDocument(pathToURI(path))
+		if doc == nil {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return
+			}
+
+			doc = newDocument(pathToURI(path), string(content))
+		}
+	}
+
+	file := &configFile{
+		Path: path,
+		Raw:  doc.raw(),
+		Node: doc.node(),
+	}
+	*files = append(*files, file)
+	for _, importPath := range importPaths(file.Raw) {
+		if !filepath.IsAbs(importPath) {
+			importPath = filepath.Join(filepath.Dir(path), importPath)
+		}
+
+		s.collectConfigFiles(importPath, nil, visited, files)
+	}
+}
+
+// importPaths returns the local config paths of the imports of the config the same
+// way the loader resolves them
+func importPaths(raw map[string]interface{}) []string {
+	imports, ok := raw["imports"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	paths := []string{}
+	for _, item := range imports {
+		importConfig, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		path, ok := importConfig["path"].(string)
+		if !ok || path == "" || strings.Contains(path, "${") || strings.Contains(path, "$(") {
+			continue
+		}
+
+		path = filepath.FromSlash(path)
+		if subPath, ok := importConfig["subPath"].(string); ok && subPath != "" {
+			path = filepath.Join(path, filepath.FromSlash(subPath))
+		}
+		if !strings.HasSuffix(path, ".yaml") && !strings.HasSuffix(path, ".yml") {
+			path = filepath.Join(path, constants.DefaultConfigPath)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// names returns the sorted keys of the given top level section of all files
+func names(files []*configFile, section string) []string {
+	retNames := []string{}
+	for _, file := range files {
+		sectionMap, ok := file.Raw[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for name := range sectionMap {
+			if !contains(retNames, name) {
+				retNames = append(retNames, name)
+			}
+		}
+	}
+
+	sort.Strings(retNames)
+	return retNames
+}
+
+// locate returns the location of the key at the given path in the first file that defines it
+func locate(files []*configFile, path []string) *Location {
+	for _, file := range files {
+		key := findKey(file.Node, path)
+		if key == nil {
+			continue
+		}
+
+		return &Location{
+			URI: pathToURI(file.Path),
+			Range: Range{
+				Start: Position{Line: key.Line - 1, Character: key.Column - 1},
+				End:   Position{Line: key.Line - 1, Character: key.Column - 1 + len(key.Value)},
+			},
+		}
+	}
+
+	return nil
+}
+
+// findKey returns the key node of the mapping entry at the given path
+func findKey(node *yaml.Node, path []string) *yaml.Node {
+	key, _ := findEntry(node, path)
+	return key
+}
+
+// findEntry returns the key and value node of the mapping entry at the given path
+func findEntry(node *yaml.Node, path []string) (*yaml.Node, *yaml.Node) {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	var key *yaml.Node
+	for _, segment := range path {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				key, value = node.Content[i], node.Content[i+1]
+				break
+			}
+		}
+		if value == nil {
+			return nil, nil
+		}
+
+		node = value
+	}
+
+	return key, node
+}
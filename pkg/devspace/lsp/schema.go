@@ -0,0 +1,194 @@
+package lsp
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	schemaBytes []byte
+
+	parsedSchemaOnce sync.Once
+	parsedSchema     map[string]interface{}
+)
+
+// SetSchema sets the json schema of devspace.yaml that is used for key completion and hover docs
+func SetSchema(schema []byte) {
+	schemaBytes = schema
+}
+
+func getSchema() map[string]interface{} {
+	parsedSchemaOnce.Do(func() {
+		if len(schemaBytes) == 0 {
+			return
+		}
+
+		_ = json.Unmarshal(schemaBytes, &parsedSchema)
+	})
+
+	return parsedSchema
+}
+
+// schemaNodes returns all schema definitions that describe the value at the given yaml path.
+// Map keys and list items are matched by pattern properties and items.
+func schemaNodes(root map[string]interface{}, path []string) []map[string]interface{} {
+	if root == nil {
+		return nil
+	}
+
+	nodes := alternatives(root, root)
+	for _, segment := range path {
+		next := []map[string]interface{}{}
+		for _, node := range nodes {
+			for _, child := range childSchemas(node, segment) {
+				next = append(next, alternatives(root, child)...)
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+
+		nodes = next
+	}
+
+	return nodes
+}
+
+// childSchemas returns the schemas of the property, map value or list item of the node
+func childSchemas(node map[string]interface{}, segment string) []map[string]interface{} {
+	if properties, ok := node["properties"].(map[string]interface{}); ok {
+		if property, ok := properties[segment].(map[string]interface{}); ok {
+			return []map[string]interface{}{property}
+		}
+	}
+
+	retSchemas := []map[string]interface{}{}
+	if items, ok := node["items"].(map[string]interface{}); ok && isIndex(segment) {
+		retSchemas = append(retSchemas, items)
+	}
+	if patternProperties, ok := node["patternProperties"].(map[string]interface{}); ok {
+		for _, value := range patternProperties {
+			if schema, ok := value.(map[string]interface{}); ok {
+				retSchemas = append(retSchemas, schema)
+			}
+		}
+	}
+	if additionalProperties, ok := node["additionalProperties"].(map[string]interface{}); ok {
+		retSchemas = append(retSchemas, additionalProperties)
+	}
+
+	return retSchemas
+}
+
+// alternatives resolves references and returns the node itself and all of its oneOf and anyOf options
+func alternatives(root, node map[string]interface{}) []map[string]interface{} {
+	node = resolveRef(root, node)
+	retNodes := []map[string]interface{}{node}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		options, ok := node[key].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, option := range options {
+			if optionSchema, ok := option.(map[string]interface{}); ok {
+				retNodes = append(retNodes, alternatives(root, optionSchema)...)
+			}
+		}
+	}
+
+	return retNodes
+}
+
+func resolveRef(root, node map[string]interface{}) map[string]interface{} {
+	ref, ok := node["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/$defs/") {
+		return node
+	}
+
+	defs, _ := root["$defs"].(map[string]interface{})
+	definition, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+	if !ok {
+		return node
+	}
+
+	return definition
+}
+
+// schemaProperties returns the properties of all nodes with their schema
+func schemaProperties(nodes []map[string]interface{}) map[string]map[string]interface{} {
+	retProperties := map[string]map[string]interface{}{}
+	for _, node := range nodes {
+		properties, ok := node["properties"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for key, value := range properties {
+			if _, ok := retProperties[key]; ok {
+				continue
+			}
+			if property, ok := value.(map[string]interface{}); ok {
+				retProperties[key] = property
+			}
+		}
+	}
+
+	return retProperties
+}
+
+// schemaDescription returns the first description of the nodes
+func schemaDescription(nodes []map[string]interface{}) string {
+	for _, node := range nodes {
+		if description, ok := node["description"].(string); ok && description != "" {
+			return description
+		}
+	}
+
+	return ""
+}
+
+// schemaEnum returns the enum values of the nodes
+func schemaEnum(nodes []map[string]interface{}) []string {
+	retEnum := []string{}
+	for _, node := range nodes {
+		values, ok := node["enum"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, value := range values {
+			if str, ok := value.(string); ok && str != ".*" && !contains(retEnum, str) {
+				retEnum = append(retEnum, str)
+			}
+		}
+	}
+
+	sort.Strings(retEnum)
+	return retEnum
+}
+
+func isIndex(segment string) bool {
+	if segment == "*" {
+		return true
+	}
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return segment != ""
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
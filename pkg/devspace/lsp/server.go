@@ -0,0 +1,214 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// Server is a language server for devspace.yaml files that communicates
+// via json-rpc with the client
+type Server struct {
+	conn    *conn
+	version string
+	log     log.Logger
+
+	// loadConfig enables the diagnostics from the config loader
+	loadConfig bool
+
+	documentsMutex sync.Mutex
+	documents      map[string]*document
+}
+
+// NewServer creates a new language server that reads requests from in and writes responses to out
+func NewServer(in io.Reader, out io.Writer, version string, log log.Logger) *Server {
+	return &Server{
+		conn:      newConn(in, out),
+		version:   version,
+		log:       log,
+		documents: map[string]*document{},
+	}
+}
+
+// Run serves requests until the client sends exit, the input is closed or the context is canceled
+func (s *Server) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		msg, err := s.conn.read()
+		if err != nil {
+			if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			} else if rpcErr, ok := err.(*responseError); ok {
+				_ = s.conn.reply(nil, nil, rpcErr)
+				continue
+			}
+
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(ctx, msg)
+		if msg.ID == nil {
+			if err != nil {
+				s.log.Debugf("lsp: %s: %v", msg.Method, err)
+			}
+			continue
+		}
+
+		err = s.conn.reply(msg.ID, result, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, msg *message) (interface{}, error) {
+	switch msg.Method {
+	case "initialize":
+		params := &InitializeParams{}
+		if err := unmarshalParams(msg, params); err != nil {
+			return nil, err
+		}
+		if params.InitializationOptions != nil {
+			s.loadConfig = params.InitializationOptions.LoadConfig
+		}
+
+		return &InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync: &TextDocumentSyncOptions{
+					OpenClose: true,
+					Change:    TextDocumentSyncKindFull,
+					Save:      true,
+				},
+				CompletionProvider: &CompletionOptions{
+					TriggerCharacters: []string{"{", ".", " "},
+				},
+				HoverProvider:      true,
+				DefinitionProvider: true,
+			},
+			ServerInfo: &ServerInfo{
+				Name:    "devspace",
+				Version: s.version,
+			},
+		}, nil
+	case "initialized", "$/cancelRequest", "$/setTrace", "workspace/didChangeConfiguration":
+		return nil, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		params := &DidOpenTextDocumentParams{}
+		if err := unmarshalParams(msg, params); err != nil {
+			return nil, err
+		}
+
+		doc := s.setDocument(params.TextDocument.URI, params.TextDocument.Text)
+		return nil, s.publishDiagnostics(ctx, doc, true)
+	case "textDocument/didChange":
+		params := &DidChangeTextDocumentParams{}
+		if err := unmarshalParams(msg, params); err != nil {
+			return nil, err
+		} else if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+
+		doc := s.setDocument(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		return nil, s.publishDiagnostics(ctx, doc, false)
+	case "textDocument/didSave":
+		params := &DidSaveTextDocumentParams{}
+		if err := unmarshalParams(msg, params); err != nil {
+			return nil, err
+		}
+
+		doc := s.getDocument(params.TextDocument.URI)
+		if doc == nil {
+			return nil, nil
+		}
+		return nil, s.publishDiagnostics(ctx, doc, true)
+	case "textDocument/didClose":
+		params := &DidCloseTextDocumentParams{}
+		if err := unmarshalParams(msg, params); err != nil {
+			return nil, err
+		}
+
+		s.documentsMutex.Lock()
+		delete(s.documents, params.TextDocument.URI)
+		s.documentsMutex.Unlock()
+		return nil, s.conn.notify("textDocument/publishDiagnostics", &PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []*Diagnostic{}})
+	case "textDocument/completion":
+		params := &TextDocumentPositionParams{}
+		doc, err := s.positionDocument(msg, params)
+		if err != nil || doc == nil {
+			return nil, err
+		}
+
+		return s.completion(doc, params.Position), nil
+	case "textDocument/hover":
+		params := &TextDocumentPositionParams{}
+		doc, err := s.positionDocument(msg, params)
+		if err != nil || doc == nil {
+			return nil, err
+		}
+
+		return s.hover(doc, params.Position), nil
+	case "textDocument/definition":
+		params := &TextDocumentPositionParams{}
+		doc, err := s.positionDocument(msg, params)
+		if err != nil || doc == nil {
+			return nil, err
+		}
+
+		location := s.definition(doc, params.Position)
+		if location == nil {
+			return nil, nil
+		}
+		return location, nil
+	}
+
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method " + msg.Method + " is not supported"}
+}
+
+func (s *Server) positionDocument(msg *message, params *TextDocumentPositionParams) (*document, error) {
+	err := unmarshalParams(msg, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDocument(params.TextDocument.URI), nil
+}
+
+func (s *Server) setDocument(uri, text string) *document {
+	doc := newDocument(uri, text)
+	s.documentsMutex.Lock()
+	defer s.documentsMutex.Unlock()
+
+	s.documents[uri] = doc
+	return doc
+}
+
+func (s *Server) getDocument(uri string) *document {
+	s.documentsMutex.Lock()
+	defer s.documentsMutex.Unlock()
+
+	return s.documents[uri]
+}
+
+func unmarshalParams(msg *message, params interface{}) error {
+	err := json.Unmarshal(msg.Params, params)
+	if err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
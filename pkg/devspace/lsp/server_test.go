@@ -0,0 +1,261 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+const testConfig = `version: v2beta1
+name: test
+imports:
+- path: ./shared.yaml
+vars:
+  TAG: latest
+images:
+  api:
+    image: myrepo/api
+deployments:
+  api:
+    helm:
+      values:
+        tag: ${TAG}
+        other: ${
+pipelines:
+  dev:
+    run: |-
+      build_images api
+      create_deployments api
+      start_dev web
+dev:
+  api:
+    imageSelector: ${runtime.images.api.image}
+
+`
+
+const sharedConfig = `version: v2beta1
+name: shared
+dev:
+  web:
+    imageSelector: myrepo/web
+`
+
+type completionTestCase struct {
+	name     string
+	position Position
+
+	expectedLabels []string
+}
+
+func TestServer(t *testing.T) {
+	schema, err := os.ReadFile(filepath.Join("..", "..", "..", "devspace-schema.json"))
+	assert.NilError(t, err)
+	SetSchema(schema)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "devspace.yaml")
+	assert.NilError(t, os.WriteFile(configPath, []byte(testConfig), 0666))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "shared.yaml"), []byte(sharedConfig), 0666))
+	uri := pathToURI(configPath)
+
+	requests := []interface{}{
+		map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		map[string]interface{}{"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": &DidOpenTextDocumentParams{
+			TextDocument: TextDocumentItem{URI: uri, LanguageID: "yaml", Text: testConfig},
+		}},
+	}
+	completionTestCases := []completionTestCase{
+		{
+			name:           "variables",
+			position:       Position{Line: 14, Character: 17},
+			expectedLabels: []string{"TAG", "DEVSPACE_NAME", "runtime.images.api.image", "runtime.images.api.tag"},
+		},
+		{
+			name:           "pipeline functions",
+			position:       Position{Line: 18, Character: 9},
+			expectedLabels: []string{"build_images", "create_deployments", "start_dev"},
+		},
+		{
+			name:           "deployment names",
+			position:       Position{Line: 19, Character: 25},
+			expectedLabels: []string{"api"},
+		},
+		{
+			name:           "dev names across imports",
+			position:       Position{Line: 20, Character: 16},
+			expectedLabels: []string{"api", "web"},
+		},
+		{
+			name:           "config keys",
+			position:       Position{Line: 23, Character: 4},
+			expectedLabels: []string{"imageSelector", "sync", "ports"},
+		},
+	}
+	for i, testCase := range completionTestCases {
+		requests = append(requests, map[string]interface{}{"jsonrpc": "2.0", "id": 10 + i, "method": "textDocument/completion", "params": &TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: uri},
+			Position:     testCase.position,
+		}})
+	}
+	requests = append(requests,
+		map[string]interface{}{"jsonrpc": "2.0", "id": 2, "method": "textDocument/hover", "params": &TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: uri},
+			Position:     Position{Line: 23, Character: 6},
+		}},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 3, "method": "textDocument/definition", "params": &TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: uri},
+			Position:     Position{Line: 20, Character: 18},
+		}},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 4, "method": "textDocument/definition", "params": &TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: uri},
+			Position:     Position{Line: 13, Character: 17},
+		}},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 5, "method": "shutdown"},
+		map[string]interface{}{"jsonrpc": "2.0", "method": "exit"},
+	)
+
+	in := &bytes.Buffer{}
+	for _, request := range requests {
+		body, err := json.Marshal(request)
+		assert.NilError(t, err)
+		fmt.Fprintf(in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+
+	out := &bytes.Buffer{}
+	err = NewServer(in, out, "test", log.Discard).Run(context.Background())
+	assert.NilError(t, err)
+
+	responses := map[string]json.RawMessage{}
+	diagnostics := []*PublishDiagnosticsParams{}
+	reader := textproto.NewReader(bufio.NewReader(out))
+	for {
+		header, err := reader.ReadMIMEHeader()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+
+		length, err := strconv.Atoi(header.Get("Content-Length"))
+		assert.NilError(t, err)
+		body := make([]byte, length)
+		_, err = io.ReadFull(reader.R, body)
+		assert.NilError(t, err)
+
+		msg := &struct {
+			ID     *int            `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			Result json.RawMessage `json:"result"`
+		}{}
+		assert.NilError(t, json.Unmarshal(body, msg))
+		if msg.Method == "textDocument/publishDiagnostics" {
+			params := &PublishDiagnosticsParams{}
+			assert.NilError(t, json.Unmarshal(msg.Params, params))
+			diagnostics = append(diagnostics, params)
+		} else if msg.ID != nil {
+			responses[strconv.Itoa(*msg.ID)] = msg.Result
+		}
+	}
+
+	initializeResult := &InitializeResult{}
+	assert.NilError(t, json.Unmarshal(responses["1"], initializeResult))
+	assert.Equal(t, initializeResult.Capabilities.HoverProvider, true)
+	assert.Equal(t, initializeResult.Capabilities.TextDocumentSync.Change, TextDocumentSyncKindFull)
+
+	for i, testCase := range completionTestCases {
+		list := &CompletionList{}
+		assert.NilError(t, json.Unmarshal(responses[strconv.Itoa(10+i)], list), testCase.name)
+		labels := map[string]bool{}
+		for _, item := range list.Items {
+			labels[item.Label] = true
+		}
+		for _, label := range testCase.expectedLabels {
+			assert.Assert(t, labels[label], "%s: expected completion %s in %v", testCase.name, label, labels)
+		}
+	}
+
+	hover := &Hover{}
+	assert.NilError(t, json.Unmarshal(responses["2"], hover))
+	assert.Assert(t, bytes.Contains([]byte(hover.Contents.Value), []byte("**dev.api.imageSelector**")), hover.Contents.Value)
+
+	location := &Location{}
+	assert.NilError(t, json.Unmarshal(responses["3"], location))
+	assert.Equal(t, location.URI, pathToURI(filepath.Join(dir, "shared.yaml")))
+	assert.Equal(t, location.Range.Start, Position{Line: 3, Character: 2})
+
+	location = &Location{}
+	assert.NilError(t, json.Unmarshal(responses["4"], location))
+	assert.Equal(t, location.URI, uri)
+	assert.Equal(t, location.Range.Start, Position{Line: 5, Character: 2})
+
+	assert.Equal(t, len(diagnostics), 1)
+	assert.Equal(t, diagnostics[0].URI, uri)
+}
+
+func TestParentPath(t *testing.T) {
+	doc := newDocument("file:///devspace.yaml", `version: v2beta1
+imports:
+- path: ./a
+  subPath: b
+dev:
+  api:
+    sync:
+    - path: ./:/app
+      excludePaths:
+      - node_modules
+      `)
+
+	assert.DeepEqual(t, doc.parentPath(0), []string{})
+	assert.DeepEqual(t, doc.parentPath(2), []string{"imports", "*"})
+	assert.DeepEqual(t, doc.parentPath(3), []string{"imports", "*"})
+	assert.DeepEqual(t, doc.parentPath(6), []string{"dev", "api"})
+	assert.DeepEqual(t, doc.parentPath(8), []string{"dev", "api", "sync", "*"})
+	assert.DeepEqual(t, doc.parentPath(9), []string{"dev", "api", "sync", "*", "excludePaths", "*"})
+	assert.DeepEqual(t, doc.parentPath(10), []string{"dev", "api", "sync", "*"})
+}
+
+func TestDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "executed")
+	config := `version: v2beta1
+name: test
+vars:
+  IMAGE:
+    command: touch ` + marker + ` && echo myrepo/api
+images:
+  api:
+    image: ${IMAGE}
+pullSecrets:
+  registry: {}
+`
+	configPath := filepath.Join(dir, "devspace.yaml")
+	assert.NilError(t, os.WriteFile(configPath, []byte(config), 0666))
+	doc := newDocument(pathToURI(configPath), config)
+
+	// by default only static checks are done and commands are not executed
+	server := NewServer(&bytes.Buffer{}, &bytes.Buffer{}, "test", log.Discard)
+	diagnostics := server.diagnostics(context.Background(), doc, true)
+	assert.Equal(t, len(diagnostics), 1)
+	assert.Equal(t, diagnostics[0].Message, "pullSecrets.registry.registry is required")
+	assert.Equal(t, diagnostics[0].Range.Start.Line, 9)
+	_, err := os.Stat(marker)
+	assert.Assert(t, os.IsNotExist(err), "command variable was executed")
+
+	// the config loader is only used if enabled
+	server.loadConfig = true
+	diagnostics = server.diagnostics(context.Background(), doc, true)
+	assert.Equal(t, len(diagnostics), 1)
+	_, err = os.Stat(marker)
+	assert.NilError(t, err)
+}
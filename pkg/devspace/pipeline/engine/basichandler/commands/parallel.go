@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"mvdan.cc/sh/v3/interp"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+const parallelSeparator = ":::"
+
+var errParallelUsage = errors.New("usage: parallel [--max N] [--fail-fast] -- command-1 [args...] ::: command-2 [args...]")
+
+type ParallelOptions struct {
+	Max      int  `long:"max" description:"The maximum number of commands to run at the same time. Defaults to no limit"`
+	FailFast bool `long:"fail-fast" description:"If true, cancels all other commands as soon as one command fails"`
+}
+
+// Parallel runs the commands separated by ::: concurrently. The output of each command is
+// prefixed with its position and name, and the errors of all failed commands are returned together.
+func Parallel(ctx context.Context, args []string, handler types.ExecHandler, logger log.Logger) error {
+	options := &ParallelOptions{}
+	args, err := flags.NewParser(options, flags.PassDoubleDash|flags.PassAfterNonOption).ParseArgs(args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	} else if options.Max < 0 {
+		return errParallelUsage
+	}
+
+	commands := [][]string{}
+	command := []string{}
+	for _, arg := range append(args, parallelSeparator) {
+		if arg != parallelSeparator {
+			command = append(command, arg)
+			continue
+		} else if len(command) == 0 {
+			return errParallelUsage
+		}
+
+		commands = append(commands, command)
+		command = []string{}
+	}
+
+	parallelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var limit chan struct{}
+	if options.Max > 0 {
+		limit = make(chan struct{}, options.Max)
+	}
+
+	errs := make([]error, len(commands))
+	waitGroup := sync.WaitGroup{}
+	for i := range commands {
+		waitGroup.Add(1)
+		go func(i int, command []string) {
+			defer waitGroup.Done()
+
+			if limit != nil {
+				select {
+				case <-parallelCtx.Done():
+					return
+				case limit <- struct{}{}:
+				}
+				defer func() { <-limit }()
+			}
+
+			name := fmt.Sprintf("%d:%s", i+1, command[0])
+			err := execWithLogger(parallelCtx, command, handler, logger.WithPrefix(name+" "))
+			if err == nil || parallelCtx.Err() != nil {
+				// failures caused by the cancellation are not interesting
+				return
+			}
+
+			errs[i] = errors.Wrap(err, name)
+			if options.FailFast {
+				cancel()
+			}
+		}(i, commands[i])
+	}
+	waitGroup.Wait()
+
+	err = utilerrors.NewAggregate(errs)
+	if err == nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+// execWithLogger runs the command within a new shell that writes all output to the given logger
+func execWithLogger(ctx context.Context, command []string, handler types.ExecHandler, logger log.Logger) error {
+	stdout := &lineWriter{log: logger, level: logrus.InfoLevel}
+	defer stdout.Close()
+	stderr := &lineWriter{log: logger, level: logrus.WarnLevel}
+	defer stderr.Close()
+
+	file, err := syntax.NewParser().Parse(strings.NewReader(`"$@"`), "")
+	if err != nil {
+		return err
+	}
+
+	hc := interp.HandlerCtx(ctx)
+	r, err := interp.New(
+		interp.Dir(hc.Dir),
+		interp.StdIO(nil, stdout, stderr),
+		interp.Env(hc.Env),
+		interp.ExecHandler(handler.ExecHandler),
+	)
+	if err != nil {
+		return errors.Wrap(err, "create shell runner")
+	}
+	r.Params = command
+
+	err = r.Run(ctx, file)
+	if isSuccess(err) {
+		return nil
+	}
+
+	return err
+}
+
+// lineWriter prints every complete line written to it with the logger
+type lineWriter struct {
+	log   log.Logger
+	level logrus.Level
+
+	m      sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	l.buffer.Write(p)
+	for {
+		index := bytes.IndexByte(l.buffer.Bytes(), '\n')
+		if index == -1 {
+			return len(p), nil
+		}
+
+		line := l.buffer.Next(index + 1)
+		l.log.Print(l.level, strings.TrimSuffix(string(line), "\n"))
+	}
+}
+
+func (l *lineWriter) Close() error {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.buffer.Len() > 0 {
+		l.log.Print(l.level, l.buffer.String())
+		l.buffer.Reset()
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+)
+
+var errRetryUsage = errors.New("usage: retry [--attempts N] [--backoff DURATION] -- command [args...]")
+
+type RetryOptions struct {
+	Attempts int    `long:"attempts" description:"The maximum number of times the command is executed. Defaults to 3"`
+	Backoff  string `long:"backoff" description:"The time to wait after the first failed attempt, e.g. 5s. The time is doubled after each further failed attempt. Defaults to 1s"`
+}
+
+// Retry executes the command until it succeeds or the maximum number of attempts is reached
+func Retry(ctx context.Context, args []string, handler types.ExecHandler, log log.Logger) error {
+	options := &RetryOptions{
+		Attempts: 3,
+		Backoff:  "1s",
+	}
+	args, err := flags.NewParser(options, flags.PassDoubleDash|flags.PassAfterNonOption).ParseArgs(args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	} else if len(args) == 0 || options.Attempts < 1 {
+		return errRetryUsage
+	}
+
+	backoff, err := parseDuration(options.Backoff)
+	if err != nil {
+		return errors.Wrap(err, "parse backoff")
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = handler.ExecHandler(ctx, args)
+		if isSuccess(err) {
+			return nil
+		} else if attempt >= options.Attempts || ctx.Err() != nil {
+			return err
+		}
+
+		log.Warnf("Attempt %d/%d of %s failed (%v), retrying in %s", attempt, options.Attempts, args[0], err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isSuccess(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	status, ok := interp.IsExitStatus(err)
+	return ok && status == 0
+}
+
+// parseDuration parses a duration such as 1m30s or a number of seconds
+func parseDuration(value string) (time.Duration, error) {
+	seconds, err := strconv.ParseUint(value, 10, 32)
+	if err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+)
+
+var errTimeoutUsage = errors.New("usage: timeout DURATION -- command [args...]")
+
+// Timeout executes the command and cancels it if it does not finish within the given duration
+func Timeout(ctx context.Context, args []string, handler types.ExecHandler) error {
+	if len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		// options are only supported by the timeout binary
+		newArgs := []string{"timeout"}
+		newArgs = append(newArgs, args...)
+		return interp.DefaultExecHandler(2*time.Second)(ctx, newArgs)
+	} else if len(args) < 2 {
+		return errTimeoutUsage
+	}
+
+	duration, err := parseDuration(args[0])
+	if err != nil {
+		return errTimeoutUsage
+	}
+
+	command := args[1:]
+	if command[0] == "--" {
+		command = command[1:]
+	}
+	if len(command) == 0 {
+		return errTimeoutUsage
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	err = handler.ExecHandler(timeoutCtx, command)
+	if !isSuccess(err) && ctx.Err() == nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		return errors.Errorf("%s timed out after %s", command[0], duration)
+	}
+
+	return err
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/loft-sh/utils/pkg/downloader"
 	"github.com/loft-sh/utils/pkg/downloader/commands"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"mvdan.cc/sh/v3/interp"
 )
 
@@ -29,6 +30,10 @@ func init() {
 // BasicCommands are extra commands DevSpace provides within the shell or are common
 // commands that might not be available locally for example in windows systems.
 var BasicCommands = map[string]func(ctx context.Context, args []string) error{
+	"breakpoint": func(ctx context.Context, args []string) error {
+		// breakpoints are handled by the debugger and do nothing otherwise
+		return nil
+	},
 	"get_flag": func(ctx context.Context, args []string) error {
 		return enginecommands.GetFlag(ctx, args)
 	},
@@ -64,6 +69,15 @@ var OverwriteCommands = map[string]func(ctx context.Context, args []string, hand
 	"run_watch": func(ctx context.Context, args []string, handler types.ExecHandler) error {
 		return HandleError(ctx, "run_watch", enginecommands.RunWatch(ctx, args, handler, log.Discard))
 	},
+	"parallel": func(ctx context.Context, args []string, handler types.ExecHandler) error {
+		return HandleError(ctx, "parallel", enginecommands.Parallel(ctx, args, handler, newShellLogger(ctx)))
+	},
+	"retry": func(ctx context.Context, args []string, handler types.ExecHandler) error {
+		return HandleError(ctx, "retry", enginecommands.Retry(ctx, args, handler, newShellLogger(ctx)))
+	},
+	"timeout": func(ctx context.Context, args []string, handler types.ExecHandler) error {
+		return HandleError(ctx, "timeout", enginecommands.Timeout(ctx, args, handler))
+	},
 }
 
 // EnsureCommands are commands where devspace makes sure those are installed locally before
@@ -161,4 +175,10 @@ func HandleError(ctx context.Context, command string, err error) error {
 	return interp.NewExitStatus(1)
 }
 
+// newShellLogger returns a logger that writes to the output streams of the shell
+func newShellLogger(ctx context.Context) log.Logger {
+	hc := interp.HandlerCtx(ctx)
+	return log.NewStreamLoggerWithFormat(hc.Stdout, hc.Stderr, logrus.InfoLevel, log.RawFormat)
+}
+
 var lookPathDir = interp.LookPathDir
@@ -0,0 +1,278 @@
+package debugger
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// BreakpointCommand is the statement that pauses the pipeline execution if the debugger is enabled
+const BreakpointCommand = "breakpoint"
+
+// resumeCommand marks a command that was already paused at and is executed after variables
+// were set through eval
+const resumeCommand = "__devspace_debugger_resume"
+
+var variableNameRegEx = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ErrAborted is returned if the pipeline execution was aborted through the debugger
+var ErrAborted = errors.New("pipeline execution aborted by debugger")
+
+const help = `Commands:
+  s, step              Executes the command and pauses before the next one (default)
+  c, continue          Executes the command and continues until the next breakpoint
+  skip                 Skips the command and pauses before the next one
+  set NAME=VALUE       Sets a shell variable before the command is executed
+  p, print NAME        Prints the value of a shell, flag or runtime variable
+  env                  Prints the flags and runtime variables
+  bt, stack            Prints the stack of pipelines and functions
+  q, abort             Aborts the pipeline execution
+  h, help              Prints this help`
+
+// Frame is a pipeline or function that is currently executed
+type Frame struct {
+	// Name of the frame, e.g. pipeline deploy
+	Name string
+
+	// Variables returns the runtime variables of the config the frame is executed with
+	Variables func() map[string]interface{}
+}
+
+// Debugger pauses the pipeline execution before commands and lets the user inspect and change it
+type Debugger struct {
+	out io.Writer
+
+	m        sync.Mutex
+	in       *bufio.Reader
+	stepping bool
+}
+
+// New creates a new debugger that reads commands from in and pauses before the first command
+func New(in io.Reader, out io.Writer) *Debugger {
+	return &Debugger{
+		in:       bufio.NewReader(in),
+		out:      out,
+		stepping: true,
+	}
+}
+
+type debuggerKey struct{}
+
+type stackKey struct{}
+
+// WithDebugger returns a context that debugs the pipelines executed with it. A nil debugger
+// disables debugging, e.g. for hooks that are executed by a pipeline.
+func WithDebugger(ctx context.Context, debugger *Debugger) context.Context {
+	return context.WithValue(ctx, debuggerKey{}, debugger)
+}
+
+// FromContext returns the debugger of the context
+func FromContext(ctx context.Context) (*Debugger, bool) {
+	debugger, ok := ctx.Value(debuggerKey{}).(*Debugger)
+	return debugger, ok && debugger != nil
+}
+
+// WithFrame returns a context with the frame added on top of the stack
+func WithFrame(ctx context.Context, frame Frame) context.Context {
+	stack := Stack(ctx)
+	newStack := make([]Frame, 0, len(stack)+1)
+	newStack = append(newStack, stack...)
+	return context.WithValue(ctx, stackKey{}, append(newStack, frame))
+}
+
+// Stack returns the frames of the context, the innermost last
+func Stack(ctx context.Context) []Frame {
+	stack, _ := ctx.Value(stackKey{}).([]Frame)
+	return stack
+}
+
+// CallHandler is called by the shell runner before every command with its expanded arguments
+func (d *Debugger) CallHandler(ctx context.Context, args []string) ([]string, error) {
+	if len(args) == 0 {
+		return args, nil
+	} else if args[0] == resumeCommand {
+		return args[1:], nil
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	isBreakpoint := args[0] == BreakpointCommand
+	if !d.stepping && !isBreakpoint {
+		return args, nil
+	}
+
+	// after a breakpoint, we step through the following commands
+	d.stepping = true
+	return d.pause(ctx, args, isBreakpoint)
+}
+
+func (d *Debugger) pause(ctx context.Context, args []string, isBreakpoint bool) ([]string, error) {
+	if isBreakpoint {
+		fmt.Fprintf(d.out, "[debug] Breakpoint in %s\n", stackString(Stack(ctx)))
+	} else {
+		fmt.Fprintf(d.out, "[debug] %s\n", stackString(Stack(ctx)))
+		fmt.Fprintf(d.out, "[debug] > %s\n", quoteArgs(args))
+	}
+
+	assignments := []string{}
+	for {
+		fmt.Fprint(d.out, "(debug) ")
+		line, err := d.in.ReadString('\n')
+		if err != nil && line == "" {
+			// nothing to read anymore, so we continue without pausing
+			fmt.Fprintln(d.out)
+			fmt.Fprintln(d.out, "[debug] No more input, continue without debugging")
+			d.stepping = false
+			return resume(args, assignments)
+		}
+
+		command, argument, _ := strings.Cut(strings.TrimSpace(line), " ")
+		argument = strings.TrimSpace(argument)
+		switch command {
+		case "", "s", "step":
+			return resume(args, assignments)
+		case "c", "continue":
+			d.stepping = false
+			return resume(args, assignments)
+		case "skip":
+			if isBreakpoint {
+				return resume(args, assignments)
+			}
+			return resume([]string{"true"}, assignments)
+		case "set":
+			name, value, ok := strings.Cut(argument, "=")
+			if !ok || !variableNameRegEx.MatchString(name) {
+				fmt.Fprintln(d.out, "usage: set NAME=VALUE")
+				continue
+			}
+
+			quoted, err := syntax.Quote(value, syntax.LangBash)
+			if err != nil {
+				fmt.Fprintf(d.out, "cannot set %s: %v\n", name, err)
+				continue
+			}
+
+			assignments = append(assignments, name+"="+quoted)
+			fmt.Fprintf(d.out, "%s will be set to %s before the command is executed\n", name, quoted)
+		case "p", "print":
+			if argument == "" {
+				fmt.Fprintln(d.out, "usage: print NAME")
+				continue
+			}
+
+			d.printVariable(ctx, argument)
+		case "env":
+			d.printEnv(ctx)
+		case "bt", "stack":
+			stack := Stack(ctx)
+			for i := len(stack) - 1; i >= 0; i-- {
+				fmt.Fprintf(d.out, "  %d: %s\n", len(stack)-1-i, stack[i].Name)
+			}
+		case "q", "abort":
+			return nil, ErrAborted
+		case "h", "help", "?":
+			fmt.Fprintln(d.out, help)
+		default:
+			fmt.Fprintf(d.out, "unknown command %s, type help to show all commands\n", command)
+		}
+	}
+}
+
+func (d *Debugger) printVariable(ctx context.Context, name string) {
+	if flags, ok := values.FlagsFrom(ctx); ok {
+		if value, ok := flags[name]; ok {
+			fmt.Fprintf(d.out, "%s (flag) = %s\n", name, value)
+			return
+		}
+	}
+
+	variable := interp.HandlerCtx(ctx).Env.Get(name)
+	if !variable.IsSet() {
+		fmt.Fprintf(d.out, "%s is not set\n", name)
+		return
+	}
+
+	fmt.Fprintf(d.out, "%s = %s\n", name, variable.String())
+}
+
+func (d *Debugger) printEnv(ctx context.Context) {
+	flags, _ := values.FlagsFrom(ctx)
+	fmt.Fprintln(d.out, "Flags:")
+	for _, name := range sortedKeys(flags) {
+		fmt.Fprintf(d.out, "  %s = %s\n", name, flags[name])
+	}
+
+	// use the variables of the innermost frame that has them
+	var listVariables func() map[string]interface{}
+	stack := Stack(ctx)
+	for i := len(stack) - 1; i >= 0 && listVariables == nil; i-- {
+		listVariables = stack[i].Variables
+	}
+	if listVariables == nil {
+		return
+	}
+
+	variables := map[string]string{}
+	for name, value := range listVariables() {
+		variables["runtime."+name] = fmt.Sprintf("%v", value)
+	}
+	fmt.Fprintln(d.out, "Runtime variables:")
+	for _, name := range sortedKeys(variables) {
+		fmt.Fprintf(d.out, "  %s = %s\n", name, variables[name])
+	}
+}
+
+// resume returns the arguments to execute. If variables were set, the command is executed through
+// eval after the assignments.
+func resume(args []string, assignments []string) ([]string, error) {
+	if len(assignments) == 0 {
+		return args, nil
+	}
+
+	return []string{"eval", strings.Join(assignments, "; ") + "; " + resumeCommand + " " + quoteArgs(args)}, nil
+}
+
+func quoteArgs(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		q, err := syntax.Quote(arg, syntax.LangBash)
+		if err != nil {
+			q = fmt.Sprintf("%q", arg)
+		}
+		quoted = append(quoted, q)
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func stackString(stack []Frame) string {
+	names := make([]string, 0, len(stack))
+	for _, frame := range stack {
+		names = append(names, frame.Name)
+	}
+	if len(names) == 0 {
+		return "pipeline"
+	}
+
+	return strings.Join(names, " > ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
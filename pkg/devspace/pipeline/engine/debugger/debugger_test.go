@@ -0,0 +1,85 @@
+package debugger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	"mvdan.cc/sh/v3/interp"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+func run(t *testing.T, script string, input string) (string, string, error) {
+	file, err := syntax.NewParser().Parse(strings.NewReader(script), "")
+	assert.NilError(t, err)
+
+	stdout := &bytes.Buffer{}
+	debugOut := &bytes.Buffer{}
+	d := New(strings.NewReader(input), debugOut)
+	runner, err := interp.New(
+		interp.StdIO(nil, stdout, stdout),
+		interp.CallHandler(d.CallHandler),
+		interp.ExecHandler(func(ctx context.Context, args []string) error {
+			if args[0] == BreakpointCommand {
+				return nil
+			}
+			return interp.NewExitStatus(127)
+		}),
+	)
+	assert.NilError(t, err)
+
+	ctx := WithFrame(context.Background(), Frame{Name: "pipeline deploy (app)", Variables: func() map[string]interface{} {
+		return map[string]interface{}{"images.api": "api:v1"}
+	}})
+	err = runner.Run(WithFrame(ctx, Frame{Name: "function greet"}), file)
+	return stdout.String(), debugOut.String(), err
+}
+
+func TestDebuggerStep(t *testing.T) {
+	stdout, debugOut, err := run(t, `NAME=world
+echo "hello $NAME"
+echo second
+echo third`, "s\nset NAME=debug ger\nskip\nbt\nc\n")
+	assert.NilError(t, err)
+
+	// the first echo was executed as is, then NAME was set and the second echo skipped
+	assert.Equal(t, stdout, "hello world\nthird\n")
+	assert.Assert(t, strings.Contains(debugOut, "[debug] pipeline deploy (app) > function greet\n[debug] > echo 'hello world'\n"), debugOut)
+	assert.Assert(t, strings.Contains(debugOut, "NAME will be set to 'debug ger' before the command is executed"), debugOut)
+	assert.Assert(t, strings.Contains(debugOut, "[debug] > echo third\n(debug)   0: function greet\n  1: pipeline deploy (app)\n"), debugOut)
+}
+
+func TestDebuggerSet(t *testing.T) {
+	stdout, _, err := run(t, `NAME=world
+echo "hello $NAME"
+echo "bye $NAME"`, "set NAME=debugger\ns\nc\n")
+	assert.NilError(t, err)
+	assert.Equal(t, stdout, "hello world\nbye debugger\n")
+}
+
+func TestDebuggerBreakpoint(t *testing.T) {
+	stdout, debugOut, err := run(t, `echo first
+breakpoint
+echo second
+echo third`, "c\nenv\np NAME\ns\nq\n")
+	assert.ErrorContains(t, err, ErrAborted.Error())
+
+	// continue runs until the breakpoint, then the debugger steps again until it is aborted
+	assert.Equal(t, stdout, "first\n")
+	assert.Assert(t, strings.Contains(debugOut, "[debug] Breakpoint in pipeline deploy (app) > function greet\n"), debugOut)
+	assert.Assert(t, strings.Contains(debugOut, "  runtime.images.api = api:v1\n"), debugOut)
+	assert.Assert(t, strings.Contains(debugOut, "NAME is not set\n"), debugOut)
+	assert.Assert(t, strings.Contains(debugOut, "> echo second\n"), debugOut)
+	assert.Assert(t, !strings.Contains(debugOut, "> echo third\n"), debugOut)
+}
+
+func TestDebuggerNoInput(t *testing.T) {
+	stdout, debugOut, err := run(t, `echo first
+breakpoint
+echo second`, "")
+	assert.NilError(t, err)
+	assert.Equal(t, stdout, "first\nsecond\n")
+	assert.Assert(t, strings.Contains(debugOut, "No more input, continue without debugging"), debugOut)
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/basichandler"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/debugger"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
 	"github.com/pkg/errors"
 	"io"
@@ -14,7 +15,7 @@ import (
 	"strings"
 )
 
-var replaceVariablesRegEx = regexp.MustCompile(`\$\{[a-zA-Z_.]+?\}`)
+var replaceVariablesRegEx = regexp.MustCompile(`\$\{[a-zA-Z0-9_.]+?\}`)
 
 func ExecuteSimpleShellCommand(
 	ctx context.Context,
@@ -26,7 +27,8 @@ func ExecuteSimpleShellCommand(
 	command string,
 	args ...string,
 ) error {
-	_, err := ExecutePipelineShellCommand(ctx, command, args, dir, false, stdout, stderr, stdin, environ, basichandler.NewBasicExecHandler())
+	// simple shell commands such as hooks are not debugged
+	_, err := ExecutePipelineShellCommand(debugger.WithDebugger(ctx, nil), command, args, dir, false, stdout, stderr, stdin, environ, basichandler.NewBasicExecHandler())
 	return err
 }
 
@@ -76,6 +78,9 @@ func ExecutePipelineShellCommand(
 	if !continueOnError {
 		options = append(options, interp.Params("-e"))
 	}
+	if d, ok := debugger.FromContext(ctx); ok {
+		options = append(options, interp.CallHandler(d.CallHandler))
+	}
 
 	// Create shell runner
 	r, err := interp.New(options...)
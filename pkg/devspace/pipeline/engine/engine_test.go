@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 	"mvdan.cc/sh/v3/expand"
@@ -106,6 +107,71 @@ func TestShellCatEnforce(t *testing.T) {
 	}
 }
 
+func TestShellParallel(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("Hello A\n"), 0666))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("Hello B\n"), 0666))
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	err := ExecuteSimpleShellCommand(context.Background(), dir, expand.ListEnviron(os.Environ()...), stdout, stderr, nil, "parallel --max 1 -- cat a.txt ::: cat b.txt")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(stdout.String(), "1:cat"), stdout.String())
+	assert.Assert(t, strings.Contains(stdout.String(), "Hello A"), stdout.String())
+	assert.Assert(t, strings.Contains(stdout.String(), "2:cat"), stdout.String())
+	assert.Assert(t, strings.Contains(stdout.String(), "Hello B"), stdout.String())
+
+	// errors of all commands are returned
+	stderr.Reset()
+	err = ExecuteSimpleShellCommand(context.Background(), dir, expand.ListEnviron(os.Environ()...), stdout, stderr, nil, "parallel -- cat missing.txt ::: cat a.txt ::: cat missing.txt")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, strings.Contains(stderr.String(), "1:cat: exit status 1"), stderr.String())
+	assert.Assert(t, strings.Contains(stderr.String(), "3:cat: exit status 1"), stderr.String())
+
+	// fail fast cancels the other commands
+	stderr.Reset()
+	start := time.Now()
+	err = ExecuteSimpleShellCommand(context.Background(), dir, expand.ListEnviron(os.Environ()...), stdout, stderr, nil, "parallel --fail-fast -- cat missing.txt ::: sleep 10")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, time.Since(start) < 5*time.Second)
+	assert.Assert(t, strings.Contains(stderr.String(), "1:cat: exit status 1"), stderr.String())
+	assert.Assert(t, !strings.Contains(stderr.String(), "2:sleep"), stderr.String())
+
+	err = ExecuteSimpleShellCommand(context.Background(), dir, expand.ListEnviron(os.Environ()...), stdout, stderr, nil, "parallel -- cat a.txt :::")
+	assert.Assert(t, err != nil)
+}
+
+func TestShellRetry(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("Hello A"), 0666))
+
+	stdout := &bytes.Buffer{}
+	err := ExecuteSimpleShellCommand(context.Background(), dir, expand.ListEnviron(os.Environ()...), stdout, nil, nil, "retry -- cat a.txt")
+	assert.NilError(t, err)
+	assert.Equal(t, stdout.String(), "Hello A")
+
+	stderr := &bytes.Buffer{}
+	err = ExecuteSimpleShellCommand(context.Background(), dir, expand.ListEnviron(os.Environ()...), stderr, stderr, nil, "retry --attempts 3 --backoff 10ms -- cat missing.txt")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, strings.Contains(stderr.String(), "Attempt 1/3 of cat failed (exit status 1), retrying in 10ms"), stderr.String())
+	assert.Assert(t, strings.Contains(stderr.String(), "Attempt 2/3 of cat failed (exit status 1), retrying in 20ms"), stderr.String())
+	assert.Assert(t, !strings.Contains(stderr.String(), "Attempt 3/3"), stderr.String())
+}
+
+func TestShellTimeout(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	err := ExecuteSimpleShellCommand(context.Background(), ".", expand.ListEnviron(os.Environ()...), stdout, nil, nil, "timeout 5s -- echo hello")
+	assert.NilError(t, err)
+	assert.Equal(t, stdout.String(), "hello\n")
+
+	stderr := &bytes.Buffer{}
+	start := time.Now()
+	err = ExecuteSimpleShellCommand(context.Background(), ".", expand.ListEnviron(os.Environ()...), stdout, stderr, nil, "timeout 100ms -- sleep 10")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, time.Since(start) < 5*time.Second)
+	assert.Equal(t, stderr.String(), "timeout: sleep timed out after 100ms\n")
+}
+
 func TestKubectlDownload(t *testing.T) {
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
+	"github.com/loft-sh/devspace/pkg/util/fsutil"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+)
+
+var errCachedUsage = errors.New("usage: cached [--key-files PATTERNS] [--key VALUE] [--outputs PATHS] [--name NAME] -- command [args...]")
+
+// cachedDataPrefix is the prefix of the local cache data keys the input hashes are saved under
+const cachedDataPrefix = "cached."
+
+// maxStoredOutputs is the number of stored outputs that are kept per cache entry
+const maxStoredOutputs = 3
+
+type CachedOptions struct {
+	Name     string   `long:"name" description:"The name of the cache entry. Defaults to a hash of the command"`
+	KeyFiles []string `long:"key-files" description:"Comma separated glob patterns of the files the command depends on, e.g. go.sum,**/*.proto"`
+	Key      []string `long:"key" description:"A value the command depends on, e.g. $(get_flag env)"`
+	Outputs  []string `long:"outputs" description:"Comma separated files or directories the command creates. They are stored after a successful run and restored if the inputs match an earlier run"`
+}
+
+// Cached skips the command if its inputs didn't change since its last successful run
+func Cached(ctx devspacecontext.Context, args []string, handler types.ExecHandler) error {
+	options := &CachedOptions{}
+	args, err := flags.NewParser(options, flags.PassDoubleDash|flags.PassAfterNonOption).ParseArgs(args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	} else if len(args) == 0 {
+		return errCachedUsage
+	}
+
+	outputs := splitList(options.Outputs)
+	for _, output := range outputs {
+		relPath, err := filepath.Rel(ctx.WorkingDir(), ctx.ResolvePath(output))
+		if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+			return errors.Errorf("output %s has to be within the working directory %s", output, ctx.WorkingDir())
+		}
+	}
+
+	name, label := options.Name, options.Name
+	if name == "" {
+		name, label = hash.String(strings.Join(args, " "))[:12], args[0]
+	}
+
+	inputsHash, err := hashInputs(ctx, args, options.Key, splitList(options.KeyFiles))
+	if err != nil {
+		return errors.Wrap(err, "hash inputs")
+	}
+
+	lastHash, _ := ctx.Config().LocalCache().GetData(cachedDataPrefix + name)
+	if lastHash == inputsHash && outputsExist(ctx, outputs) {
+		ctx.Log().Infof("Skip %s, because its inputs didn't change", label)
+		return nil
+	}
+
+	// restore the outputs of an earlier run with the same inputs
+	storeDir := filepath.Join(filepath.Dir(ctx.Config().Path()), ".devspace", "cached", hash.String(name)[:12])
+	entryDir := filepath.Join(storeDir, inputsHash)
+	if len(outputs) > 0 {
+		if _, err := os.Stat(entryDir); err == nil {
+			err = restoreOutputs(ctx, entryDir, outputs)
+			if err == nil {
+				ctx.Log().Infof("Skip %s and restore its outputs, because it was run with the same inputs before", label)
+				return saveInputsHash(ctx, name, inputsHash)
+			}
+
+			ctx.Log().Debugf("Error restoring outputs of %s: %v", label, err)
+		}
+	}
+
+	err = handler.ExecHandler(ctx.Context(), args)
+	if status, ok := interp.IsExitStatus(err); err != nil && (!ok || status != 0) {
+		return err
+	}
+
+	if len(outputs) > 0 {
+		err = storeOutputs(ctx, storeDir, entryDir, outputs)
+		if err != nil {
+			ctx.Log().Warnf("Error storing outputs of %s: %v", label, err)
+		}
+	}
+
+	return saveInputsHash(ctx, name, inputsHash)
+}
+
+// CachedWithoutCache runs the command of cached without checking or updating the cache
+func CachedWithoutCache(ctx devspacecontext.Context, args []string, handler types.ExecHandler) error {
+	args, err := flags.NewParser(&CachedOptions{}, flags.PassDoubleDash|flags.PassAfterNonOption).ParseArgs(args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	} else if len(args) == 0 {
+		return errCachedUsage
+	}
+
+	return handler.ExecHandler(ctx.Context(), args)
+}
+
+// hashInputs hashes the command, the keys and the contents of the files matching the patterns
+func hashInputs(ctx devspacecontext.Context, args []string, keys []string, patterns []string) (string, error) {
+	inputs := []string{strings.Join(args, " ")}
+	inputs = append(inputs, keys...)
+	for _, pattern := range patterns {
+		files, err := doublestar.Glob(ctx.ResolvePath(pattern))
+		if err != nil {
+			return "", err
+		} else if len(files) == 0 {
+			ctx.Log().Debugf("Key files pattern %s doesn't match any file", pattern)
+		}
+
+		sort.Strings(files)
+		for _, file := range files {
+			contentsHash, err := hash.Contents(file)
+			if err != nil {
+				return "", errors.Wrap(err, "hash "+file)
+			}
+
+			relPath, err := filepath.Rel(ctx.WorkingDir(), file)
+			if err != nil {
+				relPath = file
+			}
+
+			inputs = append(inputs, filepath.ToSlash(relPath)+"="+contentsHash)
+		}
+	}
+
+	return hash.String(strings.Join(inputs, "\n")), nil
+}
+
+func saveInputsHash(ctx devspacecontext.Context, name, inputsHash string) error {
+	ctx.Config().LocalCache().SetData(cachedDataPrefix+name, inputsHash)
+	return ctx.Config().LocalCache().Save()
+}
+
+func outputsExist(ctx devspacecontext.Context, outputs []string) bool {
+	for _, output := range outputs {
+		_, err := os.Stat(ctx.ResolvePath(output))
+		if err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// storeOutputs copies the outputs into the entry dir and removes the oldest entries of the store
+func storeOutputs(ctx devspacecontext.Context, storeDir, entryDir string, outputs []string) error {
+	// copy to a temporary dir first, so that there are no incomplete entries
+	tempDir := entryDir + ".tmp"
+	_ = os.RemoveAll(tempDir)
+	for _, output := range outputs {
+		relPath, _ := filepath.Rel(ctx.WorkingDir(), ctx.ResolvePath(output))
+		err := fsutil.Copy(ctx.ResolvePath(output), filepath.Join(tempDir, relPath), true)
+		if err != nil {
+			_ = os.RemoveAll(tempDir)
+			return errors.Wrapf(err, "copy %s", output)
+		}
+	}
+
+	_ = os.RemoveAll(entryDir)
+	err := os.Rename(tempDir, entryDir)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(storeDir)
+	if err != nil {
+		return err
+	}
+
+	modTimes := map[string]time.Time{}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil {
+			modTimes[entry.Name()] = info.ModTime()
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return modTimes[entries[i].Name()].After(modTimes[entries[j].Name()])
+	})
+	for i := maxStoredOutputs; i < len(entries); i++ {
+		_ = os.RemoveAll(filepath.Join(storeDir, entries[i].Name()))
+	}
+
+	return nil
+}
+
+// restoreOutputs replaces the outputs with the ones stored in the entry dir
+func restoreOutputs(ctx devspacecontext.Context, entryDir string, outputs []string) error {
+	for _, output := range outputs {
+		relPath, _ := filepath.Rel(ctx.WorkingDir(), ctx.ResolvePath(output))
+		storedPath := filepath.Join(entryDir, relPath)
+		_, err := os.Stat(storedPath)
+		if err != nil {
+			return err
+		}
+
+		err = os.RemoveAll(ctx.ResolvePath(output))
+		if err != nil {
+			return err
+		}
+
+		err = fsutil.Copy(storedPath, ctx.ResolvePath(output), true)
+		if err != nil {
+			return errors.Wrapf(err, "copy %s", output)
+		}
+	}
+
+	// mark the entry as recently used, so that it is not removed first
+	now := time.Now()
+	return os.Chtimes(entryDir, now, now)
+}
+
+func splitList(values []string) []string {
+	items := []string{}
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
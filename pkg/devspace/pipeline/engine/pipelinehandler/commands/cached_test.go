@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+type execHandlerFn func(ctx context.Context, args []string) error
+
+func (e execHandlerFn) ExecHandler(ctx context.Context, args []string) error {
+	return e(ctx, args)
+}
+
+func TestCached(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "proto"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "proto", "api.proto"), []byte("v1"), 0644))
+
+	cache := localcache.New(filepath.Join(dir, ".devspace", "cache.yaml"))
+	conf := config.NewConfig(nil, nil, &latest.Config{Name: "test"}, cache, nil, map[string]interface{}{}, filepath.Join(dir, "devspace.yaml"))
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithConfig(conf).WithWorkingDir(dir)
+
+	// the handler generates the output from the proto file
+	calls := 0
+	handler := execHandlerFn(func(ctx context.Context, args []string) error {
+		calls++
+		proto, err := os.ReadFile(filepath.Join(dir, "proto", "api.proto"))
+		if err != nil {
+			return err
+		}
+		assert.NilError(t, os.MkdirAll(filepath.Join(dir, "gen"), 0755))
+		return os.WriteFile(filepath.Join(dir, "gen", "api.pb.go"), proto, 0644)
+	})
+	args := []string{"--key-files", "go.sum,**/*.proto", "--outputs", "gen", "--", "protoc", "api.proto"}
+	generated := func() string {
+		out, err := os.ReadFile(filepath.Join(dir, "gen", "api.pb.go"))
+		assert.NilError(t, err)
+		return string(out)
+	}
+
+	assert.NilError(t, Cached(ctx, args, handler))
+	assert.Equal(t, calls, 1)
+
+	// the inputs didn't change
+	assert.NilError(t, Cached(ctx, args, handler))
+	assert.Equal(t, calls, 1)
+
+	// the inputs changed
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "proto", "api.proto"), []byte("v2"), 0644))
+	assert.NilError(t, Cached(ctx, args, handler))
+	assert.Equal(t, calls, 2)
+	assert.Equal(t, generated(), "v2")
+
+	// the outputs of the first run are restored
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "proto", "api.proto"), []byte("v1"), 0644))
+	assert.NilError(t, Cached(ctx, args, handler))
+	assert.Equal(t, calls, 2)
+	assert.Equal(t, generated(), "v1")
+
+	// missing outputs are restored as well
+	assert.NilError(t, os.RemoveAll(filepath.Join(dir, "gen")))
+	assert.NilError(t, Cached(ctx, args, handler))
+	assert.Equal(t, calls, 2)
+	assert.Equal(t, generated(), "v1")
+
+	// a different key is a different input
+	assert.NilError(t, Cached(ctx, append([]string{"--key", "prod"}, args...), handler))
+	assert.Equal(t, calls, 3)
+
+	// the inputs of the last successful run are saved in the local cache
+	loaded, err := localcache.NewCacheLoader().Load(filepath.Join(dir, "devspace.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(loaded.(*localcache.LocalCache).Data), 1)
+
+	assert.Error(t, Cached(ctx, []string{"--outputs", "../gen", "--", "protoc"}, handler), "output ../gen has to be within the working directory "+dir)
+	assert.Error(t, Cached(ctx, []string{"--name", "gen"}, handler), errCachedUsage.Error())
+}
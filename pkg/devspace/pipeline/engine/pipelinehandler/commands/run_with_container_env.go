@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/devspace/services/containerenv"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+)
+
+type RunWithContainerEnvOptions struct {
+	ImageSelector string `long:"image-selector" description:"The image selector to use to select the container"`
+	LabelSelector string `long:"label-selector" description:"The label selector to use to select the container"`
+	Container     string `long:"container" description:"The container to use"`
+
+	Namespace   string `long:"namespace" short:"n" description:"The namespace to use"`
+	MirrorFiles bool   `long:"mirror-files" description:"If true, will mirror mounted secrets and config maps to a local directory"`
+	Dir         string `long:"dir" description:"The directory to mirror files to. Defaults to a temporary directory"`
+	Timeout     int64  `long:"timeout" description:"The timeout to wait. Defaults to 5 minutes"`
+}
+
+func RunWithContainerEnv(ctx devspacecontext.Context, pipeline types.Pipeline, args []string, newHandler NewHandlerFn) error {
+	hc := interp.HandlerCtx(ctx.Context())
+	if ctx.KubeClient() == nil {
+		return errors.Errorf(ErrMsg)
+	}
+	options := &RunWithContainerEnvOptions{
+		Namespace: ctx.KubeClient().Namespace(),
+	}
+	args, err := flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("usage: run_with_container_env [--image-selector|--label-selector] COMMAND")
+	}
+	if options.ImageSelector == "" && options.LabelSelector == "" {
+		return fmt.Errorf("usage: run_with_container_env [--image-selector|--label-selector] COMMAND")
+	}
+
+	logger := ctx.Log().ErrorStreamOnly()
+	var imageSelector []string
+	if options.ImageSelector != "" {
+		imageSelector = []string{options.ImageSelector}
+	}
+
+	selectorOptions := targetselector.NewOptionsFromFlags(options.Container, options.LabelSelector, imageSelector, options.Namespace, "")
+	if options.Timeout != 0 {
+		selectorOptions = selectorOptions.WithTimeout(options.Timeout)
+	}
+	selectorOptions = selectorOptions.WithWaitingStrategy(targetselector.NewUntilNewestRunningWaitingStrategy(time.Millisecond * 100))
+	selectedContainer, err := targetselector.NewTargetSelector(selectorOptions).SelectSingleContainer(ctx.Context(), ctx.KubeClient(), logger)
+	if err != nil {
+		return err
+	}
+
+	environment, err := containerenv.Resolve(ctx.Context(), ctx.KubeClient(), selectedContainer.Pod, selectedContainer.Container.Name, &containerenv.Options{
+		MirrorFiles: options.MirrorFiles,
+		Dir:         options.Dir,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = engine.ExecutePipelineShellCommand(ctx.Context(), `"$@"`, args, hc.Dir, false, hc.Stdout, hc.Stderr, hc.Stdin, env.NewVariableEnvProvider(hc.Env, environment.Map()), newHandler(ctx, hc.Stdout, hc.Stderr, pipeline))
+	return err
+}
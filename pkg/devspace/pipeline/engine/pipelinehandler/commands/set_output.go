@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	"github.com/pkg/errors"
+)
+
+type SetOutputOptions struct{}
+
+func SetOutput(ctx devspacecontext.Context, args []string) error {
+	ctx.Log().Debugf("set_output %s", strings.Join(args, " "))
+	options := &SetOutputOptions{}
+	args, err := flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	} else if len(args) < 2 {
+		return fmt.Errorf("usage: set_output NAME VALUE")
+	}
+
+	pipeline, ok := values.PipelineFrom(ctx.Context())
+	if !ok {
+		return fmt.Errorf("set_output can only be used within a pipeline")
+	}
+
+	name := args[0]
+	declared := false
+	for _, output := range pipeline.Outputs {
+		if output.Name == name {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		return fmt.Errorf("pipeline %s has no output %s, please add it to pipelines.%s.outputs", pipeline.Name, name, pipeline.Name)
+	}
+
+	value := strings.Join(args[1:], " ")
+	ctx.Config().SetRuntimeVariable(runtime.PipelineOutputKey(pipeline.Name, name), value)
+	ctx.Config().SetRuntimeVariable(runtime.OutputKey(name), value)
+	return nil
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/analyze"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// WaitOptions are the options shared by the wait commands
+type WaitOptions struct {
+	Timeout  int64  `long:"timeout" description:"The timeout to wait in seconds. Defaults to 5 minutes"`
+	Interval string `long:"interval" description:"The time between two checks, e.g. 500ms. Defaults to 1s"`
+}
+
+// waitCheck returns true if the wait is over and a short description of the current state otherwise
+type waitCheck func(ctx context.Context) (bool, string, error)
+
+// waitFor polls the check until it succeeds or the timeout is reached. On timeout, the returned error
+// contains the last state of the check. If the target runs in the cluster, the problems the analyzer
+// finds in the namespace are printed as well.
+func waitFor(ctx devspacecontext.Context, what string, cluster bool, namespace string, options WaitOptions, check waitCheck) error {
+	timeout := 5 * time.Minute
+	if options.Timeout > 0 {
+		timeout = time.Duration(options.Timeout) * time.Second
+	}
+
+	interval := time.Second
+	if options.Interval != "" {
+		var err error
+		interval, err = time.ParseDuration(options.Interval)
+		if err != nil || interval <= 0 {
+			return errors.Errorf("invalid interval %s, please use a duration such as 500ms or 2s", options.Interval)
+		}
+	}
+
+	lastState := ""
+	err := wait.PollUntilContextTimeout(ctx.Context(), interval, timeout, true, func(pollCtx context.Context) (bool, error) {
+		done, state, err := check(pollCtx)
+		if err != nil || done {
+			return done, err
+		} else if pollCtx.Err() != nil {
+			// keep the state of the last complete check
+			return false, nil
+		}
+
+		if state != lastState {
+			ctx.Log().Debugf("Waiting for %s: %s", what, state)
+			lastState = state
+		}
+		return false, nil
+	})
+	if err == nil {
+		return nil
+	} else if ctx.Context().Err() != nil {
+		return ctx.Context().Err()
+	} else if !wait.Interrupted(err) {
+		return err
+	}
+
+	if cluster {
+		printAnalyzerReport(ctx, namespace)
+	}
+	if lastState == "" {
+		return errors.Errorf("timed out after %s waiting for %s", timeout, what)
+	}
+
+	return errors.Errorf("timed out after %s waiting for %s: %s", timeout, what, lastState)
+}
+
+// clusterHost returns the namespace and true if the host is the dns name of a service or pod in the
+// cluster, e.g. api.default.svc or api.default.svc.cluster.local
+func clusterHost(host string) (string, bool) {
+	parts := strings.Split(strings.TrimSuffix(strings.ToLower(host), "."), ".")
+	for i := 2; i < len(parts); i++ {
+		if parts[i] == "svc" || parts[i] == "pod" {
+			return parts[i-1], true
+		}
+	}
+
+	return "", false
+}
+
+// printAnalyzerReport prints the problems of the namespace to help finding out why a wait failed
+func printAnalyzerReport(ctx devspacecontext.Context, namespace string) {
+	if ctx.KubeClient() == nil {
+		return
+	} else if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	report, err := analyze.NewAnalyzer(ctx.KubeClient(), log.Discard).CreateReport(namespace, analyze.Options{})
+	if err != nil {
+		ctx.Log().Debugf("Error analyzing namespace %s: %v", namespace, err)
+		return
+	} else if len(report) == 0 {
+		return
+	}
+
+	ctx.Log().ErrorStreamOnly().WriteString(logrus.InfoLevel, analyze.ReportToString(report))
+}
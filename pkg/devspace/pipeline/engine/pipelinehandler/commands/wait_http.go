@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+type WaitHTTPOptions struct {
+	WaitOptions
+
+	Status   []int  `long:"status" description:"The status code that is expected, can be used multiple times. Defaults to 200"`
+	Method   string `long:"method" description:"The http method to use. Defaults to GET"`
+	Insecure bool   `long:"insecure" description:"If true, will not verify the tls certificate of the server"`
+}
+
+func WaitHTTP(ctx devspacecontext.Context, args []string) error {
+	options := &WaitHTTPOptions{
+		Method: http.MethodGet,
+	}
+	args, err := flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("usage: wait_http [--status CODE] URL")
+	}
+	if len(options.Status) == 0 {
+		options.Status = []int{http.StatusOK}
+	}
+
+	url := args[0]
+	parsedURL, err := neturl.Parse(url)
+	if err != nil {
+		return errors.Wrapf(err, "parse url %s", url)
+	}
+	namespace, cluster := clusterHost(parsedURL.Hostname())
+
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: options.Insecure},
+		},
+	}
+	defer httpClient.CloseIdleConnections()
+
+	return waitFor(ctx, url, cluster, namespace, options.WaitOptions, func(pollCtx context.Context) (bool, string, error) {
+		req, err := http.NewRequestWithContext(pollCtx, options.Method, url, nil)
+		if err != nil {
+			return false, "", errors.Wrap(err, "create request")
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		defer resp.Body.Close()
+
+		for _, status := range options.Status {
+			if resp.StatusCode == status {
+				return true, "", nil
+			}
+		}
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		state := "unexpected status " + resp.Status
+		if message := strings.TrimSpace(string(body)); message != "" {
+			state += ": " + message
+		}
+		return false, state, nil
+	})
+}
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+type WaitResourceOptions struct {
+	WaitOptions
+
+	For       string `long:"for" description:"The state to wait for: condition=NAME[=VALUE], jsonpath={.path}=VALUE or delete. Defaults to condition=Ready"`
+	Namespace string `long:"namespace" short:"n" description:"The namespace of the resources"`
+}
+
+func WaitResource(ctx devspacecontext.Context, args []string) error {
+	if ctx.KubeClient() == nil {
+		return errors.Errorf(ErrMsg)
+	}
+	options := &WaitResourceOptions{
+		For:       "condition=Ready",
+		Namespace: ctx.KubeClient().Namespace(),
+	}
+	args, err := flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("usage: wait_resource [--for condition=Ready] KIND/NAME...")
+	}
+
+	condition, err := parseWaitCondition(options.For)
+	if err != nil {
+		return err
+	}
+
+	mapper, err := newRESTMapper(ctx.KubeClient().KubeClient().Discovery())
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(ctx.KubeClient().RestConfig())
+	if err != nil {
+		return errors.Wrap(err, "create dynamic client")
+	}
+
+	for _, arg := range args {
+		resource, namespace, name, err := resolveResource(mapper, arg, options.Namespace)
+		if err != nil {
+			return err
+		}
+
+		err = waitFor(ctx, arg, true, options.Namespace, options.WaitOptions, func(pollCtx context.Context) (bool, string, error) {
+			obj, err := dynamicClient.Resource(resource).Namespace(namespace).Get(pollCtx, name, metav1.GetOptions{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					return condition.Delete, "not found", nil
+				} else if kerrors.IsForbidden(err) || kerrors.IsUnauthorized(err) {
+					return false, "", errors.Wrapf(err, "get %s", arg)
+				}
+
+				return false, err.Error(), nil
+			}
+
+			done, state := condition.Check(obj)
+			return done, state, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// waitCondition is the state wait_resource waits for
+type waitCondition struct {
+	// Delete is true if the resource should not exist anymore
+	Delete bool
+
+	// Condition is the type of the status condition to check
+	Condition string
+
+	// JSONPath is the expression of the field to check
+	JSONPath string
+
+	// Value is the expected status of the condition or value of the field
+	Value string
+}
+
+func parseWaitCondition(value string) (*waitCondition, error) {
+	switch {
+	case value == "delete":
+		return &waitCondition{Delete: true}, nil
+	case strings.HasPrefix(value, "condition="):
+		condition, expected, found := strings.Cut(strings.TrimPrefix(value, "condition="), "=")
+		if condition == "" {
+			break
+		} else if !found {
+			expected = "True"
+		}
+
+		return &waitCondition{Condition: condition, Value: expected}, nil
+	case strings.HasPrefix(value, "jsonpath="):
+		expression := strings.TrimPrefix(value, "jsonpath=")
+		index := strings.LastIndex(expression, "}=")
+		if !strings.HasPrefix(expression, "{") || index == -1 {
+			break
+		}
+
+		err := jsonpath.New("wait").Parse(expression[:index+1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse jsonpath %s", expression[:index+1])
+		}
+
+		return &waitCondition{JSONPath: expression[:index+1], Value: expression[index+2:]}, nil
+	}
+
+	return nil, errors.Errorf("invalid --for %s, expected condition=NAME[=VALUE], jsonpath={.path}=VALUE or delete", value)
+}
+
+// Check returns true if the object is in the expected state and a description of its current state otherwise
+func (w *waitCondition) Check(obj *unstructured.Unstructured) (bool, string) {
+	if w.Delete {
+		return false, "still exists"
+	} else if w.JSONPath != "" {
+		path := jsonpath.New("wait").AllowMissingKeys(true)
+		err := path.Parse(w.JSONPath)
+		if err != nil {
+			return false, err.Error()
+		}
+
+		results, err := path.FindResults(obj.Object)
+		if err != nil {
+			return false, err.Error()
+		} else if len(results) == 0 || len(results[0]) == 0 {
+			return false, w.JSONPath + " is not set"
+		}
+
+		value := fmt.Sprint(results[0][0].Interface())
+		if value == w.Value {
+			return true, ""
+		}
+
+		return false, fmt.Sprintf("%s is %s", w.JSONPath, value)
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		if !strings.EqualFold(conditionType, w.Condition) {
+			continue
+		}
+
+		status, _, _ := unstructured.NestedString(condition, "status")
+		if strings.EqualFold(status, w.Value) {
+			return true, ""
+		}
+
+		details := []string{}
+		for _, field := range []string{"reason", "message"} {
+			value, _, _ := unstructured.NestedString(condition, field)
+			if value != "" {
+				details = append(details, value)
+			}
+		}
+
+		state := fmt.Sprintf("condition %s is %s", conditionType, status)
+		if len(details) > 0 {
+			state += " (" + strings.Join(details, ": ") + ")"
+		}
+		return false, state
+	}
+
+	return false, fmt.Sprintf("condition %s is not set", w.Condition)
+}
+
+func newRESTMapper(discoveryClient discovery.DiscoveryInterface) (meta.RESTMapper, error) {
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "discover api resources")
+	}
+
+	return restmapper.NewShortcutExpander(restmapper.NewDiscoveryRESTMapper(groupResources), discoveryClient, func(string) {}), nil
+}
+
+// resolveResource returns the resource, namespace and name of an argument in the form kind/name
+func resolveResource(mapper meta.RESTMapper, arg, namespace string) (schema.GroupVersionResource, string, string, error) {
+	kind, name, found := strings.Cut(arg, "/")
+	if !found || kind == "" || name == "" {
+		return schema.GroupVersionResource{}, "", "", errors.Errorf("invalid resource %s, expected KIND/NAME", arg)
+	}
+
+	var (
+		gvk schema.GroupVersionKind
+		err error
+	)
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(kind))
+	if fullySpecified != nil {
+		gvk, err = mapper.KindFor(*fullySpecified)
+	}
+	if gvk.Empty() {
+		gvk, err = mapper.KindFor(groupResource.WithVersion(""))
+		if err != nil {
+			return schema.GroupVersionResource{}, "", "", errors.Wrapf(err, "resolve kind %s", kind)
+		}
+	}
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, "", "", errors.Wrapf(err, "resolve kind %s", kind)
+	} else if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		namespace = ""
+	}
+
+	return mapping.Resource, namespace, name, nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+type WaitTCPOptions struct {
+	WaitOptions
+}
+
+func WaitTCP(ctx devspacecontext.Context, args []string) error {
+	options := &WaitTCPOptions{}
+	args, err := flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("usage: wait_tcp HOST:PORT")
+	}
+
+	address := args[0]
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrapf(err, "parse address %s", address)
+	}
+	namespace, cluster := clusterHost(host)
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	return waitFor(ctx, address, cluster, namespace, options.WaitOptions, func(pollCtx context.Context) (bool, string, error) {
+		conn, err := dialer.DialContext(pollCtx, "tcp", address)
+		if err != nil {
+			return false, err.Error(), nil
+		}
+
+		_ = conn.Close()
+		return true, "", nil
+	})
+}
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWaitHTTP(t *testing.T) {
+	requests := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("starting"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard)
+	assert.NilError(t, WaitHTTP(ctx, []string{"--interval", "10ms", "--status", "204", server.URL}))
+	assert.Equal(t, atomic.LoadInt32(&requests), int32(3))
+
+	err := WaitHTTP(ctx, []string{"--interval", "10ms", "--timeout", "1", server.URL})
+	assert.Error(t, err, "timed out after 1s waiting for "+server.URL+": unexpected status 204 No Content")
+}
+
+func TestWaitTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard)
+	assert.NilError(t, WaitTCP(ctx, []string{listener.Addr().String()}))
+
+	assert.NilError(t, listener.Close())
+	err = WaitTCP(ctx, []string{"--interval", "10ms", "--timeout", "1", listener.Addr().String()})
+	assert.ErrorContains(t, err, "timed out after 1s waiting for "+listener.Addr().String()+": dial tcp")
+
+	err = WaitTCP(ctx, []string{"localhost"})
+	assert.ErrorContains(t, err, "parse address localhost")
+}
+
+type clusterHostTestCase struct {
+	name string
+	host string
+
+	expectedNamespace string
+	expectedCluster   bool
+}
+
+func TestClusterHost(t *testing.T) {
+	testCases := []clusterHostTestCase{
+		{
+			name: "localhost",
+			host: "localhost",
+		},
+		{
+			name: "ip",
+			host: "127.0.0.1",
+		},
+		{
+			name: "external host",
+			host: "api.example.com",
+		},
+		{
+			name: "svc without namespace",
+			host: "api.svc",
+		},
+		{
+			name:              "service",
+			host:              "api.dev.svc",
+			expectedNamespace: "dev",
+			expectedCluster:   true,
+		},
+		{
+			name:              "fully qualified service",
+			host:              "API.dev.svc.cluster.local.",
+			expectedNamespace: "dev",
+			expectedCluster:   true,
+		},
+		{
+			name:              "pod",
+			host:              "10-0-0-1.dev.pod.cluster.local",
+			expectedNamespace: "dev",
+			expectedCluster:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		namespace, cluster := clusterHost(testCase.host)
+		assert.Equal(t, namespace, testCase.expectedNamespace, testCase.name)
+		assert.Equal(t, cluster, testCase.expectedCluster, testCase.name)
+	}
+}
+
+type waitConditionTestCase struct {
+	name     string
+	forValue string
+	object   map[string]interface{}
+
+	expectedDone  bool
+	expectedState string
+}
+
+func TestWaitCondition(t *testing.T) {
+	job := map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase": "Pending",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Complete", "status": "False", "reason": "BackoffLimitExceeded", "message": "Job has reached the specified backoff limit"},
+			},
+		},
+	}
+	testCases := []waitConditionTestCase{
+		{name: "Condition met", forValue: "condition=ready", object: job, expectedDone: true},
+		{name: "Condition with value", forValue: "condition=Complete=false", object: job, expectedDone: true},
+		{name: "Condition not met", forValue: "condition=Complete", object: job, expectedState: "condition Complete is False (BackoffLimitExceeded: Job has reached the specified backoff limit)"},
+		{name: "Condition missing", forValue: "condition=Available", object: job, expectedState: "condition Available is not set"},
+		{name: "JSONPath met", forValue: "jsonpath={.status.phase}=Pending", object: job, expectedDone: true},
+		{name: "JSONPath not met", forValue: "jsonpath={.status.phase}=Bound", object: job, expectedState: "{.status.phase} is Pending"},
+		{name: "JSONPath missing", forValue: "jsonpath={.spec.volumeName}=data", object: job, expectedState: "{.spec.volumeName} is not set"},
+		{name: "Delete", forValue: "delete", object: job, expectedState: "still exists"},
+	}
+
+	for _, testCase := range testCases {
+		condition, err := parseWaitCondition(testCase.forValue)
+		assert.NilError(t, err, testCase.name)
+
+		done, state := condition.Check(&unstructured.Unstructured{Object: testCase.object})
+		assert.Equal(t, done, testCase.expectedDone, testCase.name)
+		assert.Equal(t, state, testCase.expectedState, testCase.name)
+	}
+
+	for _, invalid := range []string{"ready", "condition=", "jsonpath=.status.phase=Bound", "jsonpath={.status.phase"} {
+		_, err := parseWaitCondition(invalid)
+		assert.ErrorContains(t, err, "invalid --for "+invalid)
+	}
+}
+
+func TestResolveResource(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "persistentvolumeclaims", SingularName: "persistentvolumeclaim", Kind: "PersistentVolumeClaim", Namespaced: true, ShortNames: []string{"pvc"}},
+				{Name: "namespaces", SingularName: "namespace", Kind: "Namespace", ShortNames: []string{"ns"}},
+			},
+		},
+		{
+			GroupVersion: "batch/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "jobs", SingularName: "job", Kind: "Job", Namespaced: true},
+			},
+		},
+	}
+
+	mapper, err := newRESTMapper(client.Discovery())
+	assert.NilError(t, err)
+
+	resource, namespace, name, err := resolveResource(mapper, "pvc/data", "default")
+	assert.NilError(t, err)
+	assert.Equal(t, resource, schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"})
+	assert.Equal(t, namespace, "default")
+	assert.Equal(t, name, "data")
+
+	resource, _, _, err = resolveResource(mapper, "Job.batch/migrate", "default")
+	assert.NilError(t, err)
+	assert.Equal(t, resource, schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"})
+
+	resource, namespace, _, err = resolveResource(mapper, "namespaces/test", "default")
+	assert.NilError(t, err)
+	assert.Equal(t, resource.Resource, "namespaces")
+	assert.Equal(t, namespace, "")
+
+	_, _, _, err = resolveResource(mapper, "job", "default")
+	assert.Error(t, err, "invalid resource job, expected KIND/NAME")
+	_, _, _, err = resolveResource(mapper, "deployment/api", "default")
+	assert.ErrorContains(t, err, "resolve kind deployment")
+}
@@ -12,10 +12,13 @@ import (
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/basichandler"
 	basichandlercommands "github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/basichandler/commands"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/debugger"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/pipelinehandler/commands"
 	enginetypes "github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/types"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/log"
+	"go.opentelemetry.io/otel/attribute"
 	"mvdan.cc/sh/v3/interp"
 )
 
@@ -28,6 +31,9 @@ var PipelineCommands = map[string]func(devCtx devspacecontext.Context, pipeline
 	"exec_container": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		return commands.ExecContainer(devCtx, args)
 	},
+	"run_with_container_env": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		return commands.RunWithContainerEnv(devCtx, pipeline, args, NewPipelineExecHandler)
+	},
 	"get_image": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		return commands.GetImage(devCtx, args)
 	},
@@ -38,6 +44,18 @@ var PipelineCommands = map[string]func(devCtx devspacecontext.Context, pipeline
 		hc := interp.HandlerCtx(devCtx.Context())
 		return basichandlercommands.RunWatch(devCtx.Context(), args, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline), devCtx.Log())
 	},
+	"parallel": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		return basichandlercommands.Parallel(devCtx.Context(), args, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline), devCtx.Log())
+	},
+	"retry": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		return basichandlercommands.Retry(devCtx.Context(), args, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline), devCtx.Log())
+	},
+	"timeout": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		return basichandlercommands.Timeout(devCtx.Context(), args, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline))
+	},
 	"run_pipelines": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		hc := interp.HandlerCtx(devCtx.Context())
 		return commands.RunPipelines(devCtx, pipeline, args, hc.Env)
@@ -79,6 +97,28 @@ var PipelineCommands = map[string]func(devCtx devspacecontext.Context, pipeline
 	"wait_pod": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		return commands.WaitPod(devCtx, args)
 	},
+	"wait_http": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		return commands.WaitHTTP(devCtx, args)
+	},
+	"wait_tcp": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		return commands.WaitTCP(devCtx, args)
+	},
+	"wait_resource": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		return commands.WaitResource(devCtx, args)
+	},
+	"set_output": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		return commands.SetOutput(devCtx, args)
+	},
+	"cached": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		handler := NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, pipeline)
+
+		// pipeline tests always run the command, so that they don't depend on earlier runs
+		if _, ok := commandInterceptorFrom(devCtx.Context()); ok {
+			return commands.CachedWithoutCache(devCtx, args, handler)
+		}
+		return commands.Cached(devCtx, args, handler)
+	},
 }
 
 func init() {
@@ -148,7 +188,13 @@ func (e *execHandler) handlePipelineCommands(ctx context.Context, command string
 	if devCtx.Config().Config().Functions != nil {
 		commandPayload, ok := devCtx.Config().Config().Functions[command]
 		if ok {
+			spanCtx, span := tracing.Start(devCtx.Context(), "function "+command, attribute.StringSlice("devspace.args", args))
+			devCtx = devCtx.WithContext(debugger.WithFrame(spanCtx, debugger.Frame{
+				Name:      "function " + command,
+				Variables: devCtx.Config().ListRuntimeVariables,
+			}))
 			_, err := engine.ExecutePipelineShellCommand(devCtx.Context(), commandPayload, args, hc.Dir, false, hc.Stdout, hc.Stderr, hc.Stdin, hc.Env, NewPipelineExecHandler(devCtx, hc.Stdout, hc.Stderr, e.pipeline))
+			tracing.End(span, err)
 			return true, err
 		}
 	}
@@ -156,7 +202,7 @@ func (e *execHandler) handlePipelineCommands(ctx context.Context, command string
 	// resolve pipeline commands
 	pipelineCommand, ok := PipelineCommands[command]
 	if ok {
-		return e.executePipelineCommand(ctx, command, func() error {
+		return e.executePipelineCommand(devCtx, command, args, func(devCtx devspacecontext.Context) error {
 			return pipelineCommand(devCtx, e.pipeline, args)
 		})
 	}
@@ -164,7 +210,7 @@ func (e *execHandler) handlePipelineCommands(ctx context.Context, command string
 	// resolve internal pipeline commands
 	pipelineCommand, ok = PipelineCommands[strings.TrimPrefix(command, "__")]
 	if ok {
-		return e.executePipelineCommand(ctx, command, func() error {
+		return e.executePipelineCommand(devCtx, command, args, func(devCtx devspacecontext.Context) error {
 			return pipelineCommand(devCtx, e.pipeline, args)
 		})
 	}
@@ -172,12 +218,30 @@ func (e *execHandler) handlePipelineCommands(ctx context.Context, command string
 	return false, nil
 }
 
-func (e *execHandler) executePipelineCommand(ctx context.Context, command string, commandFn func() error) (bool, error) {
+func (e *execHandler) executePipelineCommand(devCtx devspacecontext.Context, command string, args []string, commandFn func(devCtx devspacecontext.Context) error) (bool, error) {
+	ctx := devCtx.Context()
 	if e.pipeline == nil {
 		hc := interp.HandlerCtx(ctx)
 		_, _ = fmt.Fprintln(hc.Stderr, fmt.Errorf("%s: cannot execute the command because it can only be executed within a pipeline step", command))
 		return true, interp.NewExitStatus(1)
 	}
 
-	return true, basichandler.HandleError(ctx, command, commandFn())
+	spanCtx, span := tracing.Start(ctx, strings.TrimPrefix(command, "__"),
+		attribute.String("devspace.project", e.pipeline.Name()),
+		attribute.StringSlice("devspace.args", args),
+	)
+	devCtx = devCtx.WithContext(spanCtx)
+
+	var (
+		handled bool
+		err     error
+	)
+	if interceptor, ok := commandInterceptorFrom(ctx); ok {
+		handled, err = interceptor(devCtx, strings.TrimPrefix(command, "__"), args)
+	}
+	if !handled {
+		err = commandFn(devCtx)
+	}
+	tracing.End(span, err)
+	return true, basichandler.HandleError(ctx, command, err)
 }
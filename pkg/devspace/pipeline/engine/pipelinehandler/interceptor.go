@@ -0,0 +1,23 @@
+package pipelinehandler
+
+import (
+	"context"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+)
+
+// CommandInterceptor is called before a pipeline command is executed. If it returns true, the
+// pipeline command itself is not executed, which is used to stub pipeline commands in tests.
+type CommandInterceptor func(devCtx devspacecontext.Context, command string, args []string) (bool, error)
+
+type interceptorKey struct{}
+
+// WithCommandInterceptor returns a context that intercepts all pipeline commands executed within it
+func WithCommandInterceptor(ctx context.Context, interceptor CommandInterceptor) context.Context {
+	return context.WithValue(ctx, interceptorKey{}, interceptor)
+}
+
+func commandInterceptorFrom(ctx context.Context) (CommandInterceptor, bool) {
+	interceptor, ok := ctx.Value(interceptorKey{}).(CommandInterceptor)
+	return interceptor, ok
+}
@@ -61,6 +61,44 @@ func (p *provider) Each(visitor func(name string, vr expand.Variable) bool) {
 	})
 }
 
+// NewRuntimeEnvProvider returns an environment that resolves runtime.* variables on access, so that
+// runtime variables that are set while a pipeline is running, such as pipeline outputs, are available
+// in the commands executed afterwards.
+func NewRuntimeEnvProvider(base expand.Environ, resolve func(name string) (string, bool)) Provider {
+	return &runtimeProvider{
+		base:    base,
+		resolve: resolve,
+	}
+}
+
+type runtimeProvider struct {
+	base    expand.Environ
+	resolve func(name string) (string, bool)
+}
+
+func (p *runtimeProvider) Get(name string) expand.Variable {
+	name = strings.ReplaceAll(name, enginetypes.DotReplacement, ".")
+	variable := p.base.Get(name)
+	if variable.IsSet() || !strings.HasPrefix(name, "runtime.") {
+		return variable
+	}
+
+	value, ok := p.resolve(name)
+	if !ok {
+		return variable
+	}
+
+	return expand.Variable{
+		Exported: true,
+		Kind:     expand.String,
+		Str:      value,
+	}
+}
+
+func (p *runtimeProvider) Each(visitor func(name string, vr expand.Variable) bool) {
+	p.base.Each(visitor)
+}
+
 func ConvertMap(iMap map[string]interface{}) map[string]string {
 	retMap := map[string]string{}
 	for k, v := range iMap {
@@ -1,13 +1,20 @@
 package pipeline
 
 import (
+	"fmt"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/debugger"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/pipelinehandler"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
 	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"go.opentelemetry.io/otel/attribute"
 	"io"
 	"mvdan.cc/sh/v3/expand"
 	"os"
@@ -117,7 +124,28 @@ func (j *Job) execute(ctx devspacecontext.Context, args []string, parent *tomb.T
 		return nil
 	})
 
+	spanCtx, span := tracing.Start(ctx.Context(), "pipeline "+j.Config.Name,
+		attribute.String("devspace.pipeline", j.Config.Name),
+		attribute.String("devspace.project", j.Pipeline.Name()),
+	)
+	ctx = ctx.WithContext(debugger.WithFrame(values.WithPipeline(spanCtx, j.Config), debugger.Frame{
+		Name:      "pipeline " + j.Config.Name + " (" + j.Pipeline.Name() + ")",
+		Variables: ctx.Config().ListRuntimeVariables,
+	}))
+
+	// resolve runtime variables such as pipeline outputs when they are used
+	environ = env.NewRuntimeEnvProvider(environ, func(name string) (string, bool) {
+		_, value, err := runtime.NewRuntimeVariable(name, ctx.Config(), ctx.Dependencies()).Load()
+		if err != nil {
+			ctx.Log().Debugf("Error resolving ${%s}: %v", name, err)
+			return "", false
+		}
+
+		return fmt.Sprintf("%v", value), true
+	})
+
 	handler := pipelinehandler.NewPipelineExecHandler(ctx, stdoutWriter, stderrWriter, j.Pipeline)
 	_, err := engine.ExecutePipelineShellCommand(ctx.Context(), j.Config.Run, args, ctx.WorkingDir(), j.Config.ContinueOnError, stdoutWriter, stderrWriter, os.Stdin, environ, handler)
+	tracing.End(span, err)
 	return err
 }
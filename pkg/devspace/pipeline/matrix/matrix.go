@@ -0,0 +1,243 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// Cell is a combination of kube context and namespace a pipeline is run in. An empty context
+// or namespace means the current kube context or default namespace.
+type Cell struct {
+	Context   string
+	Namespace string
+}
+
+func (c Cell) String() string {
+	names := []string{}
+	if c.Context != "" {
+		names = append(names, c.Context)
+	}
+	if c.Namespace != "" {
+		names = append(names, c.Namespace)
+	}
+
+	return strings.Join(names, "/")
+}
+
+// Result is the result of running a pipeline in a cell
+type Result struct {
+	Cell     Cell
+	Duration time.Duration
+	Err      error
+}
+
+// Parse parses --matrix values such as contexts=a,b or "contexts=a,b namespaces=x,y" and returns
+// a copy of the given matrix with the parsed dimensions replaced
+func Parse(matrix *latest.PipelineMatrix, values []string) (*latest.PipelineMatrix, error) {
+	if len(values) == 0 {
+		return matrix, nil
+	}
+
+	parsed := &latest.PipelineMatrix{}
+	if matrix != nil {
+		*parsed = *matrix
+	}
+	for _, value := range values {
+		for _, dimension := range strings.Fields(value) {
+			name, list, ok := strings.Cut(dimension, "=")
+			if !ok || list == "" {
+				return nil, fmt.Errorf("%s is not in the format contexts=a,b or namespaces=x,y", dimension)
+			}
+
+			items := []string{}
+			for _, item := range strings.Split(list, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+
+			switch name {
+			case "contexts":
+				parsed.Contexts = items
+			case "namespaces":
+				parsed.Namespaces = items
+			default:
+				return nil, fmt.Errorf("unknown matrix dimension %s, please use contexts or namespaces", name)
+			}
+		}
+	}
+
+	return parsed, nil
+}
+
+// Cells returns every combination of the contexts and namespaces of the matrix
+func Cells(matrix *latest.PipelineMatrix) []Cell {
+	if matrix == nil || (len(matrix.Contexts) == 0 && len(matrix.Namespaces) == 0) {
+		return nil
+	}
+
+	contexts := matrix.Contexts
+	if len(contexts) == 0 {
+		contexts = []string{""}
+	}
+	namespaces := matrix.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	cells := []Cell{}
+	for _, context := range contexts {
+		for _, namespace := range namespaces {
+			cells = append(cells, Cell{Context: context, Namespace: namespace})
+		}
+	}
+
+	return cells
+}
+
+// Run runs fn for every cell with at most maxConcurrent cells in parallel (0 for infinite). A
+// failing cell doesn't stop the other cells. The results are returned in the order of the cells.
+func Run(ctx context.Context, cells []Cell, maxConcurrent int, fn func(ctx context.Context, cell Cell) error) []Result {
+	if maxConcurrent <= 0 || maxConcurrent > len(cells) {
+		maxConcurrent = len(cells)
+	}
+
+	results := make([]Result, len(cells))
+	semaphore := make(chan struct{}, maxConcurrent)
+	waitGroup := sync.WaitGroup{}
+	for i, cell := range cells {
+		results[i].Cell = cell
+
+		// cells that didn't start before the context was canceled are not started anymore
+		if ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case semaphore <- struct{}{}:
+			}
+		}
+		if ctx.Err() != nil {
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		waitGroup.Add(1)
+		go func(result *Result) {
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+
+			start := time.Now()
+			result.Err = fn(ctx, result.Cell)
+			result.Duration = time.Since(start)
+		}(&results[i])
+	}
+
+	waitGroup.Wait()
+	return results
+}
+
+// PrintSummary prints a table with the result of every cell, the errors are logged by the cells
+func PrintSummary(logger log.Logger, results []Result) {
+	values := [][]string{}
+	for _, result := range results {
+		status := "succeeded"
+		if errors.Is(result.Err, context.Canceled) {
+			status = "canceled"
+		} else if result.Err != nil {
+			status = "failed"
+		}
+
+		values = append(values, []string{
+			valueOrDefault(result.Cell.Context, "(current)"),
+			valueOrDefault(result.Cell.Namespace, "(default)"),
+			status,
+			result.Duration.Round(time.Second).String(),
+		})
+	}
+
+	log.PrintTable(logger, []string{"Kube Context", "Namespace", "Result", "Duration"}, values)
+}
+
+// Err returns an error if the pipeline failed in any cell
+func Err(results []Result) error {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("pipeline failed in %d of %d matrix combinations", failed, len(results))
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// NewPrefixWriter returns a writer that writes every line with the prefix to out. Lines are
+// written to out in a single call, so that the lines of several writers sharing out don't mix.
+// Close writes the last line if it doesn't end with a newline.
+func NewPrefixWriter(out io.Writer, prefix string) io.WriteCloser {
+	return &prefixWriter{
+		out:    out,
+		prefix: []byte(prefix),
+	}
+}
+
+type prefixWriter struct {
+	m      sync.Mutex
+	out    io.Writer
+	prefix []byte
+	buffer []byte
+}
+
+func (p *prefixWriter) Write(message []byte) (int, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.buffer = append(p.buffer, message...)
+	index := bytes.LastIndexByte(p.buffer, '\n')
+	if index == -1 {
+		return len(message), nil
+	}
+
+	lines := p.buffer[:index+1]
+	prefixed := make([]byte, 0, len(lines)+bytes.Count(lines, []byte{'\n'})*len(p.prefix))
+	for len(lines) > 0 {
+		line := lines[:bytes.IndexByte(lines, '\n')+1]
+		prefixed = append(prefixed, p.prefix...)
+		prefixed = append(prefixed, line...)
+		lines = lines[len(line):]
+	}
+	p.buffer = append([]byte{}, p.buffer[index+1:]...)
+
+	_, err := p.out.Write(prefixed)
+	return len(message), err
+}
+
+func (p *prefixWriter) Close() error {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if len(p.buffer) == 0 {
+		return nil
+	}
+
+	_, err := p.out.Write(append(append(append([]byte{}, p.prefix...), p.buffer...), '\n'))
+	p.buffer = nil
+	return err
+}
@@ -0,0 +1,136 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestParse(t *testing.T) {
+	config := &latest.PipelineMatrix{Contexts: []string{"a"}, Namespaces: []string{"x"}, MaxConcurrent: 2}
+
+	matrix, err := Parse(config, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, matrix, config)
+
+	matrix, err = Parse(config, []string{"contexts=b,c", "namespaces=y,z"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, matrix, &latest.PipelineMatrix{Contexts: []string{"b", "c"}, Namespaces: []string{"y", "z"}, MaxConcurrent: 2})
+	assert.DeepEqual(t, config.Contexts, []string{"a"})
+
+	matrix, err = Parse(nil, []string{"contexts=a,b namespaces=x"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, matrix, &latest.PipelineMatrix{Contexts: []string{"a", "b"}, Namespaces: []string{"x"}})
+
+	_, err = Parse(nil, []string{"contexts"})
+	assert.Error(t, err, "contexts is not in the format contexts=a,b or namespaces=x,y")
+	_, err = Parse(nil, []string{"clusters=a"})
+	assert.Error(t, err, "unknown matrix dimension clusters, please use contexts or namespaces")
+}
+
+func TestCells(t *testing.T) {
+	assert.Equal(t, len(Cells(nil)), 0)
+	assert.Equal(t, len(Cells(&latest.PipelineMatrix{MaxConcurrent: 1})), 0)
+
+	assert.DeepEqual(t, Cells(&latest.PipelineMatrix{Contexts: []string{"a", "b"}, Namespaces: []string{"x", "y"}}), []Cell{
+		{Context: "a", Namespace: "x"},
+		{Context: "a", Namespace: "y"},
+		{Context: "b", Namespace: "x"},
+		{Context: "b", Namespace: "y"},
+	})
+	assert.DeepEqual(t, Cells(&latest.PipelineMatrix{Namespaces: []string{"x"}}), []Cell{{Namespace: "x"}})
+	assert.Equal(t, Cell{Context: "a", Namespace: "x"}.String(), "a/x")
+	assert.Equal(t, Cell{Namespace: "x"}.String(), "x")
+}
+
+func TestRun(t *testing.T) {
+	cells := Cells(&latest.PipelineMatrix{Contexts: []string{"a", "b", "c"}, Namespaces: []string{"x", "y"}})
+
+	m := sync.Mutex{}
+	running, maxRunning := 0, 0
+	results := Run(context.Background(), cells, 2, func(ctx context.Context, cell Cell) error {
+		m.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		m.Unlock()
+
+		defer func() {
+			m.Lock()
+			running--
+			m.Unlock()
+		}()
+		if cell.Namespace == "y" {
+			return errors.New("failed in " + cell.String())
+		}
+		return nil
+	})
+
+	assert.Assert(t, maxRunning <= 2)
+	assert.Equal(t, len(results), 6)
+	for i, result := range results {
+		assert.Equal(t, result.Cell, cells[i])
+		if result.Cell.Namespace == "y" {
+			assert.Error(t, result.Err, "failed in "+result.Cell.String())
+		} else {
+			assert.NilError(t, result.Err)
+		}
+	}
+	assert.Error(t, Err(results), "pipeline failed in 3 of 6 matrix combinations")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = Run(ctx, cells[:1], 0, func(ctx context.Context, cell Cell) error {
+		t.Fatal("cell should not be run")
+		return nil
+	})
+	assert.Assert(t, errors.Is(results[0].Err, context.Canceled))
+	assert.NilError(t, Err(nil))
+}
+
+func TestRunCanceled(t *testing.T) {
+	cells := Cells(&latest.PipelineMatrix{Namespaces: []string{"x", "y", "z"}})
+
+	// the cells waiting for a free slot are not started after the context was canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := []Cell{}
+	results := Run(ctx, cells, 1, func(ctx context.Context, cell Cell) error {
+		started = append(started, cell)
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.DeepEqual(t, started, cells[:1])
+	assert.Equal(t, len(results), 3)
+	for i, result := range results {
+		assert.Equal(t, result.Cell, cells[i])
+		assert.Assert(t, errors.Is(result.Err, context.Canceled))
+	}
+	assert.Equal(t, results[1].Duration, time.Duration(0))
+	assert.Error(t, Err(results), "pipeline failed in 3 of 3 matrix combinations")
+}
+
+func TestPrefixWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	writer := NewPrefixWriter(out, "a/x ")
+
+	_, err := writer.Write([]byte("first\nsec"))
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), "a/x first\n")
+
+	_, err = writer.Write([]byte("ond\nthird\nlast"))
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), "a/x first\na/x second\na/x third\n")
+
+	assert.NilError(t, writer.Close())
+	assert.Equal(t, out.String(), "a/x first\na/x second\na/x third\na/x last\n")
+}
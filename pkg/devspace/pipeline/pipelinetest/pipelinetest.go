@@ -0,0 +1,300 @@
+package pipelinetest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/registry"
+	"github.com/loft-sh/devspace/pkg/devspace/devpod"
+	pipelinepkg "github.com/loft-sh/devspace/pkg/devspace/pipeline"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/engine/pipelinehandler"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// DefaultFile is the file the pipeline tests are loaded from by default
+const DefaultFile = "devspace.test.yaml"
+
+// DefaultPassthrough are the pipeline commands that only execute other commands, set pipeline
+// outputs or are evaluated from the context of the test and therefore are not stubbed by default.
+// is_dependency fails, because the tested pipeline is run as the root pipeline.
+var DefaultPassthrough = []string{"xargs", "parallel", "retry", "timeout", "cached", "set_output", "is_dependency"}
+
+// TestFile holds the pipeline test cases
+type TestFile struct {
+	Tests []*TestCase `yaml:"tests" json:"tests"`
+}
+
+// TestCase runs a pipeline with stubbed pipeline commands and checks which commands were called
+type TestCase struct {
+	// Name of the test case
+	Name string `yaml:"name" json:"name"`
+
+	// Pipeline is the pipeline to run
+	Pipeline string `yaml:"pipeline" json:"pipeline"`
+
+	// Args are the arguments passed to the pipeline
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+
+	// Flags are the flags the pipeline is run with, e.g. logs: true
+	Flags map[string]interface{} `yaml:"flags,omitempty" json:"flags,omitempty"`
+
+	// Vars are the variables the config is loaded with
+	Vars map[string]string `yaml:"vars,omitempty" json:"vars,omitempty"`
+
+	// Profiles are the profiles the config is loaded with
+	Profiles []string `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+
+	// Stubs define the output and exit code of pipeline commands. Commands without a stub succeed
+	// without output.
+	Stubs []*Stub `yaml:"stubs,omitempty" json:"stubs,omitempty"`
+
+	// Passthrough are pipeline commands that are executed for real, e.g. run_pipelines
+	Passthrough []string `yaml:"passthrough,omitempty" json:"passthrough,omitempty"`
+
+	// Expect are the assertions checked after the pipeline ran
+	Expect Expectation `yaml:"expect,omitempty" json:"expect,omitempty"`
+}
+
+// Stub replaces a pipeline command
+type Stub struct {
+	// Command is the name of the pipeline command
+	Command string `yaml:"command" json:"command"`
+
+	// Args restricts the stub to calls with exactly these arguments
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+
+	// Output is written to stdout when the command is called
+	Output string `yaml:"output,omitempty" json:"output,omitempty"`
+
+	// ExitCode is the exit code of the command
+	ExitCode int `yaml:"exitCode,omitempty" json:"exitCode,omitempty"`
+}
+
+// Expectation holds the assertions of a test case
+type Expectation struct {
+	// Calls are the pipeline commands that are expected to be called in this order. Other calls
+	// may happen in between.
+	Calls []Call `yaml:"calls,omitempty" json:"calls,omitempty"`
+
+	// NotCalled are pipeline commands that must not be called
+	NotCalled []string `yaml:"notCalled,omitempty" json:"notCalled,omitempty"`
+
+	// Fail expects the pipeline to fail
+	Fail bool `yaml:"fail,omitempty" json:"fail,omitempty"`
+
+	// ErrorContains expects the pipeline to fail with an error containing the text
+	ErrorContains string `yaml:"errorContains,omitempty" json:"errorContains,omitempty"`
+
+	// OutputContains expects the output of the pipeline to contain the text
+	OutputContains []string `yaml:"outputContains,omitempty" json:"outputContains,omitempty"`
+}
+
+// Call is a pipeline command call
+type Call struct {
+	// Command is the name of the pipeline command
+	Command string `yaml:"command" json:"command"`
+
+	// Args are the arguments of the call. If nil, any arguments match.
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+func (c Call) String() string {
+	return strings.TrimSpace(c.Command + " " + strings.Join(c.Args, " "))
+}
+
+// Result is the outcome of a test case run
+type Result struct {
+	Calls  []Call
+	Output string
+	Err    error
+}
+
+// LoadFile loads the test cases from the file
+func LoadFile(path string) (*TestFile, error) {
+	out, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	testFile := &TestFile{}
+	err = yamlutil.UnmarshalStrict(out, testFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+
+	for i, testCase := range testFile.Tests {
+		if testCase.Name == "" {
+			testCase.Name = fmt.Sprintf("test %d", i+1)
+		}
+		if testCase.Pipeline == "" {
+			return nil, fmt.Errorf("%s: pipeline is missing", testCase.Name)
+		}
+		for _, stub := range testCase.Stubs {
+			if stub.Command == "" {
+				return nil, fmt.Errorf("%s: stub command is missing", testCase.Name)
+			} else if stub.ExitCode < 0 || stub.ExitCode > 255 {
+				return nil, fmt.Errorf("%s: exit code of stub %s must be between 0 and 255", testCase.Name, stub.Command)
+			}
+		}
+	}
+
+	return testFile, nil
+}
+
+// recorder records the pipeline command calls and executes the stubs
+type recorder struct {
+	testCase *TestCase
+
+	m     sync.Mutex
+	calls []Call
+}
+
+// intercept records the call and executes the matching stub. Commands that are passed through
+// are executed for real, unless a stub matches them.
+func (r *recorder) intercept(devCtx devspacecontext.Context, command string, args []string) (bool, error) {
+	stub := r.findStub(command, args)
+	if stub == nil && (stringutil.Contains(DefaultPassthrough, command) || stringutil.Contains(r.testCase.Passthrough, command)) {
+		return false, nil
+	}
+
+	r.m.Lock()
+	r.calls = append(r.calls, Call{Command: command, Args: append([]string{}, args...)})
+	r.m.Unlock()
+	if stub == nil {
+		return true, nil
+	}
+
+	hc := interp.HandlerCtx(devCtx.Context())
+	if stub.Output != "" {
+		_, _ = hc.Stdout.Write([]byte(stub.Output))
+	}
+	if stub.ExitCode != 0 {
+		return true, interp.NewExitStatus(uint8(stub.ExitCode))
+	}
+
+	return true, nil
+}
+
+func (r *recorder) findStub(command string, args []string) *Stub {
+	for _, stub := range r.testCase.Stubs {
+		if stub.Command == command && (stub.Args == nil || reflect.DeepEqual(stub.Args, args)) {
+			return stub
+		}
+	}
+
+	return nil
+}
+
+// Run runs the pipeline of the test case through the pipeline engine with all pipeline commands
+// except the passthrough ones stubbed. The context needs to hold the config and the pipeline flags.
+// An error is only returned if the pipeline cannot be found, failures of the pipeline itself are
+// part of the result.
+func Run(ctx devspacecontext.Context, testCase *TestCase) (*Result, error) {
+	configPipeline, err := resolvePipeline(ctx, testCase.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &bytes.Buffer{}
+	logger := log.NewStreamLoggerWithFormat(output, output, logrus.InfoLevel, log.RawFormat)
+	rec := &recorder{testCase: testCase}
+
+	devCtxCancel, cancelDevCtx := context.WithCancel(ctx.Context())
+	defer cancelDevCtx()
+
+	runCtx := values.WithDevContext(ctx.Context(), devCtxCancel)
+	runCtx = pipelinehandler.WithCommandInterceptor(runCtx, rec.intercept)
+	devPodManager := devpod.NewManager(cancelDevCtx)
+	defer devPodManager.Close()
+
+	pipe := pipelinepkg.NewPipeline(ctx.Config().Config().Name, devPodManager, registry.NewDependencyRegistry(ctx.Config().Config().Name, true), configPipeline, types.Options{})
+	err = pipe.Run(ctx.WithContext(runCtx).WithLogger(logger), testCase.Args)
+
+	rec.m.Lock()
+	defer rec.m.Unlock()
+	return &Result{
+		Calls:  rec.calls,
+		Output: output.String(),
+		Err:    err,
+	}, nil
+}
+
+func resolvePipeline(ctx devspacecontext.Context, name string) (*latest.Pipeline, error) {
+	configPipeline, ok := ctx.Config().Config().Pipelines[name]
+	if !ok {
+		return types.GetDefaultPipeline(name)
+	}
+
+	copied := *configPipeline
+	if copied.Run == "" {
+		defaultPipeline, _ := types.GetDefaultPipeline(name)
+		if defaultPipeline != nil {
+			copied.Run = defaultPipeline.Run
+		}
+	}
+
+	return &copied, nil
+}
+
+// Verify returns the failed assertions of the test case
+func Verify(testCase *TestCase, result *Result) []string {
+	failures := []string{}
+	expect := testCase.Expect
+	if expect.Fail || expect.ErrorContains != "" {
+		if result.Err == nil {
+			failures = append(failures, "expected pipeline to fail, but it succeeded")
+		} else if expect.ErrorContains != "" && !strings.Contains(result.Err.Error(), expect.ErrorContains) {
+			failures = append(failures, fmt.Sprintf("expected error to contain %q, got: %v", expect.ErrorContains, result.Err))
+		}
+	} else if result.Err != nil {
+		failures = append(failures, fmt.Sprintf("pipeline failed: %v", result.Err))
+	}
+
+	next := 0
+	for _, expected := range expect.Calls {
+		found := false
+		for next < len(result.Calls) {
+			call := result.Calls[next]
+			next++
+			if call.Command == expected.Command && (expected.Args == nil || reflect.DeepEqual(expected.Args, call.Args)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf("expected call %q was not made in order", expected.String()))
+			break
+		}
+	}
+
+	for _, command := range expect.NotCalled {
+		for _, call := range result.Calls {
+			if call.Command == command {
+				failures = append(failures, fmt.Sprintf("expected %s not to be called, but got %q", command, call.String()))
+				break
+			}
+		}
+	}
+
+	for _, text := range expect.OutputContains {
+		if !strings.Contains(result.Output, text) {
+			failures = append(failures, fmt.Sprintf("expected output to contain %q", text))
+		}
+	}
+
+	return failures
+}
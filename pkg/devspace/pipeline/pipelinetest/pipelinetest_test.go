@@ -0,0 +1,200 @@
+package pipelinetest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/context/values"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	flag "github.com/spf13/pflag"
+	"gotest.tools/assert"
+)
+
+func TestRun(t *testing.T) {
+	conf := config.NewConfig(nil, nil, &latest.Config{
+		Name: "test",
+		Pipelines: map[string]*latest.Pipeline{
+			"deploy": {
+				Name: "deploy",
+				Run: `if [ $(get_flag images) == "true" ]; then
+  build_images --all
+fi
+create_deployments backend $(get_image api)
+retry --attempts 2 -- wait_http http://backend
+echo deployed`,
+			},
+		},
+	}, localcache.New(""), nil, map[string]interface{}{}, "")
+
+	flagSet := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	flagSet.Bool("images", true, "")
+	ctx := values.WithCommandFlags(context.Background(), flagSet)
+	devCtx := devspacecontext.NewContext(ctx, nil, log.Discard).WithConfig(conf)
+
+	testCase := &TestCase{
+		Pipeline: "deploy",
+		Stubs: []*Stub{
+			{Command: "get_image", Output: "api:v1"},
+			{Command: "wait_http", ExitCode: 1},
+		},
+		Expect: Expectation{
+			Calls: []Call{
+				{Command: "build_images", Args: []string{"--all"}},
+				{Command: "create_deployments", Args: []string{"backend", "api:v1"}},
+				{Command: "wait_http"},
+			},
+			ErrorContains: "exit status 1",
+			NotCalled:     []string{"retry"},
+		},
+	}
+	result, err := Run(devCtx, testCase)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, Verify(testCase, result), []string{})
+
+	// wait_http is retried, because retry is executed for real
+	assert.Equal(t, len(result.Calls), 5)
+
+	testCase = &TestCase{Pipeline: "dev", Expect: Expectation{Calls: []Call{{Command: "start_dev", Args: []string{"--all"}}}}}
+	result, err = Run(devCtx, testCase)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, Verify(testCase, result), []string{})
+
+	// cached always runs the command in tests
+	conf.Config().Pipelines["build"] = &latest.Pipeline{Name: "build", Run: `cached --key-files go.sum -- build_images --all`}
+	testCase = &TestCase{Pipeline: "build", Expect: Expectation{Calls: []Call{{Command: "build_images", Args: []string{"--all"}}}, NotCalled: []string{"cached"}}}
+	for i := 0; i < 2; i++ {
+		result, err = Run(devCtx, testCase)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, Verify(testCase, result), []string{})
+	}
+
+	// is_dependency is false in the root pipeline unless it is stubbed
+	conf.Config().Pipelines["branch"] = &latest.Pipeline{Name: "branch", Run: `if is_dependency; then
+  echo dependency
+else
+  echo root
+fi`}
+	testCase = &TestCase{Pipeline: "branch", Expect: Expectation{OutputContains: []string{"root"}, NotCalled: []string{"is_dependency"}}}
+	result, err = Run(devCtx, testCase)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, Verify(testCase, result), []string{})
+	testCase = &TestCase{
+		Pipeline: "branch",
+		Stubs:    []*Stub{{Command: "is_dependency"}},
+		Expect:   Expectation{Calls: []Call{{Command: "is_dependency"}}, OutputContains: []string{"dependency"}},
+	}
+	result, err = Run(devCtx, testCase)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, Verify(testCase, result), []string{})
+
+	_, err = Run(devCtx, &TestCase{Pipeline: "missing"})
+	assert.ErrorContains(t, err, "couldn't find pipeline missing")
+}
+
+func TestRunOutputs(t *testing.T) {
+	conf := config.NewConfig(nil, nil, &latest.Config{
+		Name: "test",
+		Pipelines: map[string]*latest.Pipeline{
+			"db": {
+				Name:    "db",
+				Run:     `set_output url "postgres://db:5432"`,
+				Outputs: []latest.PipelineOutput{{Name: "url"}, {Name: "password"}},
+			},
+			"deploy": {
+				Name: "deploy",
+				Run: `run_pipelines db
+echo "url=${runtime.pipelines.db.outputs.url}"
+create_deployments --set-string url=${runtime.pipelines.db.outputs.url}
+set_output token abc`,
+			},
+		},
+	}, localcache.New(""), nil, map[string]interface{}{}, "")
+	devCtx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(conf)
+
+	testCase := &TestCase{
+		Pipeline:    "deploy",
+		Passthrough: []string{"run_pipelines"},
+		Expect: Expectation{
+			Calls:          []Call{{Command: "create_deployments", Args: []string{"--set-string", "url=postgres://db:5432"}}},
+			OutputContains: []string{"url=postgres://db:5432", "pipeline deploy has no output token"},
+			Fail:           true,
+		},
+	}
+	result, err := Run(devCtx, testCase)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, Verify(testCase, result), []string{})
+
+	url, _ := conf.GetRuntimeVariable("pipelines.db.outputs.url")
+	assert.Equal(t, url, "postgres://db:5432")
+	url, _ = conf.GetRuntimeVariable("outputs.url")
+	assert.Equal(t, url, "postgres://db:5432")
+}
+
+func TestVerify(t *testing.T) {
+	result := &Result{
+		Calls: []Call{
+			{Command: "build_images", Args: []string{"--all"}},
+			{Command: "create_deployments", Args: []string{"--all"}},
+		},
+		Output: "done",
+	}
+
+	assert.DeepEqual(t, Verify(&TestCase{Expect: Expectation{
+		Calls:          []Call{{Command: "create_deployments"}},
+		NotCalled:      []string{"purge_deployments"},
+		OutputContains: []string{"done"},
+	}}, result), []string{})
+
+	assert.DeepEqual(t, Verify(&TestCase{Expect: Expectation{
+		Calls:          []Call{{Command: "create_deployments"}, {Command: "build_images"}},
+		NotCalled:      []string{"build_images"},
+		OutputContains: []string{"other"},
+		Fail:           true,
+	}}, result), []string{
+		"expected pipeline to fail, but it succeeded",
+		`expected call "build_images" was not made in order`,
+		`expected build_images not to be called, but got "build_images --all"`,
+		`expected output to contain "other"`,
+	})
+
+	result.Err = errors.New("exit status 1")
+	assert.DeepEqual(t, Verify(&TestCase{}, result), []string{"pipeline failed: exit status 1"})
+	assert.DeepEqual(t, Verify(&TestCase{Expect: Expectation{ErrorContains: "status 2"}}, result), []string{`expected error to contain "status 2", got: exit status 1`})
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultFile)
+
+	assert.NilError(t, os.WriteFile(path, []byte(`tests:
+- pipeline: deploy
+  flags:
+    skip-build: true
+  stubs:
+  - command: build_images
+    exitCode: 1
+`), 0644))
+	testFile, err := LoadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, testFile.Tests[0].Name, "test 1")
+	assert.Equal(t, testFile.Tests[0].Flags["skip-build"], true)
+
+	assert.NilError(t, os.WriteFile(path, []byte("tests:\n- name: a\n"), 0644))
+	_, err = LoadFile(path)
+	assert.ErrorContains(t, err, "a: pipeline is missing")
+
+	assert.NilError(t, os.WriteFile(path, []byte("tests:\n- pipeline: a\n  stubs:\n  - command: x\n    exitCode: 300\n"), 0644))
+	_, err = LoadFile(path)
+	assert.ErrorContains(t, err, "exit code of stub x must be between 0 and 255")
+
+	assert.NilError(t, os.WriteFile(path, []byte("tests:\n- pipeline: a\n  unknown: true\n"), 0644))
+	_, err = LoadFile(path)
+	assert.ErrorContains(t, err, "unknown")
+}
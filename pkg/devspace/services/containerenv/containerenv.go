@@ -0,0 +1,562 @@
+package containerenv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Options defines how the environment of a container should be resolved
+type Options struct {
+	// MirrorFiles will write all secrets and config maps that are mounted
+	// into the container to a local directory
+	MirrorFiles bool
+
+	// Dir is the directory where mirrored files are written to. If empty,
+	// a new temporary directory is created.
+	Dir string
+}
+
+// EnvVar is a single resolved environment variable
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// Environment is the resolved environment of a container
+type Environment struct {
+	// Env are the resolved environment variables in the order
+	// they are defined in the container
+	Env []EnvVar
+
+	// Mounts maps container mount paths to the local directories
+	// or files the contents were mirrored to
+	Mounts map[string]string
+
+	// Dir is the directory files were mirrored to
+	Dir string
+}
+
+// Map returns the environment variables as map
+func (e *Environment) Map() map[string]string {
+	retMap := map[string]string{}
+	for _, envVar := range e.Env {
+		retMap[envVar.Name] = envVar.Value
+	}
+	return retMap
+}
+
+// Resolve resolves the environment of the given container from the pod spec. This includes
+// env, envFrom, config map and secret references as well as downward API values.
+func Resolve(ctx context.Context, client kubectl.Client, pod *corev1.Pod, containerName string, options *Options) (*Environment, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	container := findContainer(pod, containerName)
+	if container == nil {
+		return nil, fmt.Errorf("couldn't find container %s in pod %s/%s", containerName, pod.Namespace, pod.Name)
+	}
+
+	r := &resolver{
+		ctx:        ctx,
+		client:     client,
+		pod:        pod,
+		container:  container,
+		configMaps: map[string]*corev1.ConfigMap{},
+		secrets:    map[string]*corev1.Secret{},
+	}
+
+	environment := &Environment{
+		Mounts: map[string]string{},
+	}
+	values := map[string]string{}
+	set := func(name, value string) {
+		if _, ok := values[name]; !ok {
+			environment.Env = append(environment.Env, EnvVar{Name: name})
+		}
+		values[name] = value
+	}
+
+	// envFrom comes first, env takes precedence
+	for _, envFrom := range container.EnvFrom {
+		data, err := r.envFromData(envFrom)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := []string{}
+		for k := range data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			set(envFrom.Prefix+k, data[k])
+		}
+	}
+	for _, envVar := range container.Env {
+		if envVar.ValueFrom == nil {
+			set(envVar.Name, expandValue(envVar.Value, values))
+			continue
+		}
+
+		value, found, err := r.valueFrom(envVar.ValueFrom)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve env %s", envVar.Name)
+		} else if found {
+			set(envVar.Name, value)
+		}
+	}
+	for i := range environment.Env {
+		environment.Env[i].Value = values[environment.Env[i].Name]
+	}
+
+	if options.MirrorFiles {
+		err := r.mirrorFiles(environment, options.Dir)
+		if err != nil {
+			return nil, errors.Wrap(err, "mirror files")
+		}
+	}
+
+	return environment, nil
+}
+
+type resolver struct {
+	ctx       context.Context
+	client    kubectl.Client
+	pod       *corev1.Pod
+	container *corev1.Container
+
+	configMaps map[string]*corev1.ConfigMap
+	secrets    map[string]*corev1.Secret
+}
+
+func (r *resolver) envFromData(envFrom corev1.EnvFromSource) (map[string]string, error) {
+	if envFrom.ConfigMapRef != nil {
+		configMap, err := r.getConfigMap(envFrom.ConfigMapRef.Name, isOptional(envFrom.ConfigMapRef.Optional))
+		if err != nil || configMap == nil {
+			return nil, err
+		}
+
+		return configMap.Data, nil
+	} else if envFrom.SecretRef != nil {
+		secret, err := r.getSecret(envFrom.SecretRef.Name, isOptional(envFrom.SecretRef.Optional))
+		if err != nil || secret == nil {
+			return nil, err
+		}
+
+		data := map[string]string{}
+		for k, v := range secret.Data {
+			data[k] = string(v)
+		}
+		return data, nil
+	}
+
+	return nil, nil
+}
+
+func (r *resolver) valueFrom(valueFrom *corev1.EnvVarSource) (string, bool, error) {
+	switch {
+	case valueFrom.ConfigMapKeyRef != nil:
+		ref := valueFrom.ConfigMapKeyRef
+		configMap, err := r.getConfigMap(ref.Name, isOptional(ref.Optional))
+		if err != nil || configMap == nil {
+			return "", false, err
+		}
+
+		value, ok := configMap.Data[ref.Key]
+		if !ok && !isOptional(ref.Optional) {
+			return "", false, fmt.Errorf("config map %s has no key %s", ref.Name, ref.Key)
+		}
+		return value, ok, nil
+	case valueFrom.SecretKeyRef != nil:
+		ref := valueFrom.SecretKeyRef
+		secret, err := r.getSecret(ref.Name, isOptional(ref.Optional))
+		if err != nil || secret == nil {
+			return "", false, err
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok && !isOptional(ref.Optional) {
+			return "", false, fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+		}
+		return string(value), ok, nil
+	case valueFrom.FieldRef != nil:
+		value, err := podFieldValue(r.pod, valueFrom.FieldRef.FieldPath)
+		return value, err == nil, err
+	case valueFrom.ResourceFieldRef != nil:
+		return resourceFieldValue(r.container, valueFrom.ResourceFieldRef)
+	}
+
+	return "", false, nil
+}
+
+func (r *resolver) getConfigMap(name string, optional bool) (*corev1.ConfigMap, error) {
+	if configMap, ok := r.configMaps[name]; ok {
+		return configMap, nil
+	}
+
+	configMap, err := r.client.KubeClient().CoreV1().ConfigMaps(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) && optional {
+			r.configMaps[name] = nil
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "get config map %s", name)
+	}
+
+	r.configMaps[name] = configMap
+	return configMap, nil
+}
+
+func (r *resolver) getSecret(name string, optional bool) (*corev1.Secret, error) {
+	if secret, ok := r.secrets[name]; ok {
+		return secret, nil
+	}
+
+	secret, err := r.client.KubeClient().CoreV1().Secrets(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) && optional {
+			r.secrets[name] = nil
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "get secret %s", name)
+	}
+
+	r.secrets[name] = secret
+	return secret, nil
+}
+
+func (r *resolver) mirrorFiles(environment *Environment, dir string) error {
+	if dir == "" {
+		var err error
+		dir, err = os.MkdirTemp("", "devspace-env-")
+		if err != nil {
+			return err
+		}
+	}
+	environment.Dir = dir
+
+	for _, volumeMount := range r.container.VolumeMounts {
+		volume := findVolume(r.pod, volumeMount.Name)
+		if volume == nil {
+			continue
+		}
+
+		files, err := r.volumeFiles(volume)
+		if err != nil {
+			return err
+		} else if files == nil {
+			continue
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(volumeMount.MountPath))
+		if volumeMount.SubPath != "" {
+			content, ok := files[volumeMount.SubPath]
+			if !ok {
+				continue
+			}
+
+			err = writeFile(target, content)
+			if err != nil {
+				return err
+			}
+		} else {
+			for name, content := range files {
+				err = writeFile(filepath.Join(target, filepath.FromSlash(name)), content)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		environment.Mounts[volumeMount.MountPath] = target
+	}
+
+	return nil
+}
+
+// volumeFiles returns the files of a config map, secret or projected volume
+// keyed by their path relative to the mount path
+func (r *resolver) volumeFiles(volume *corev1.Volume) (map[string][]byte, error) {
+	switch {
+	case volume.ConfigMap != nil:
+		return r.configMapFiles(volume.ConfigMap.Name, volume.ConfigMap.Items, isOptional(volume.ConfigMap.Optional))
+	case volume.Secret != nil:
+		return r.secretFiles(volume.Secret.SecretName, volume.Secret.Items, isOptional(volume.Secret.Optional))
+	case volume.Projected != nil:
+		files := map[string][]byte{}
+		for _, source := range volume.Projected.Sources {
+			var (
+				projectedFiles map[string][]byte
+				err            error
+			)
+			if source.ConfigMap != nil {
+				projectedFiles, err = r.configMapFiles(source.ConfigMap.Name, source.ConfigMap.Items, isOptional(source.ConfigMap.Optional))
+			} else if source.Secret != nil {
+				projectedFiles, err = r.secretFiles(source.Secret.Name, source.Secret.Items, isOptional(source.Secret.Optional))
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			for k, v := range projectedFiles {
+				files[k] = v
+			}
+		}
+		return files, nil
+	}
+
+	return nil, nil
+}
+
+func (r *resolver) configMapFiles(name string, items []corev1.KeyToPath, optional bool) (map[string][]byte, error) {
+	configMap, err := r.getConfigMap(name, optional)
+	if err != nil || configMap == nil {
+		return nil, err
+	}
+
+	data := map[string][]byte{}
+	for k, v := range configMap.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range configMap.BinaryData {
+		data[k] = v
+	}
+	return selectItems(data, items), nil
+}
+
+func (r *resolver) secretFiles(name string, items []corev1.KeyToPath, optional bool) (map[string][]byte, error) {
+	secret, err := r.getSecret(name, optional)
+	if err != nil || secret == nil {
+		return nil, err
+	}
+
+	return selectItems(secret.Data, items), nil
+}
+
+func selectItems(data map[string][]byte, items []corev1.KeyToPath) map[string][]byte {
+	if len(items) == 0 {
+		return data
+	}
+
+	files := map[string][]byte{}
+	for _, item := range items {
+		content, ok := data[item.Key]
+		if ok {
+			files[item.Path] = content
+		}
+	}
+	return files
+}
+
+func writeFile(path string, content []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0600)
+}
+
+func podFieldValue(pod *corev1.Pod, fieldPath string) (string, error) {
+	switch fieldPath {
+	case "metadata.name":
+		return pod.Name, nil
+	case "metadata.namespace":
+		return pod.Namespace, nil
+	case "metadata.uid":
+		return string(pod.UID), nil
+	case "spec.nodeName":
+		return pod.Spec.NodeName, nil
+	case "spec.serviceAccountName":
+		return pod.Spec.ServiceAccountName, nil
+	case "status.hostIP":
+		return pod.Status.HostIP, nil
+	case "status.podIP":
+		return pod.Status.PodIP, nil
+	case "status.podIPs":
+		ips := []string{}
+		for _, ip := range pod.Status.PodIPs {
+			ips = append(ips, ip.IP)
+		}
+		return strings.Join(ips, ","), nil
+	}
+
+	for prefix, values := range map[string]map[string]string{
+		"metadata.labels":      pod.Labels,
+		"metadata.annotations": pod.Annotations,
+	} {
+		if fieldPath == prefix {
+			keys := []string{}
+			for k := range values {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			lines := []string{}
+			for _, k := range keys {
+				lines = append(lines, fmt.Sprintf("%s=%q", k, values[k]))
+			}
+			return strings.Join(lines, "\n"), nil
+		} else if strings.HasPrefix(fieldPath, prefix+"['") && strings.HasSuffix(fieldPath, "']") {
+			key := strings.TrimSuffix(strings.TrimPrefix(fieldPath, prefix+"['"), "']")
+			return values[key], nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported field path %s", fieldPath)
+}
+
+func resourceFieldValue(container *corev1.Container, ref *corev1.ResourceFieldSelector) (string, bool, error) {
+	var quantity resource.Quantity
+	switch ref.Resource {
+	case "limits.cpu":
+		quantity = container.Resources.Limits[corev1.ResourceCPU]
+	case "limits.memory":
+		quantity = container.Resources.Limits[corev1.ResourceMemory]
+	case "limits.ephemeral-storage":
+		quantity = container.Resources.Limits[corev1.ResourceEphemeralStorage]
+	case "requests.cpu":
+		quantity = container.Resources.Requests[corev1.ResourceCPU]
+	case "requests.memory":
+		quantity = container.Resources.Requests[corev1.ResourceMemory]
+	case "requests.ephemeral-storage":
+		quantity = container.Resources.Requests[corev1.ResourceEphemeralStorage]
+	default:
+		return "", false, fmt.Errorf("unsupported resource %s", ref.Resource)
+	}
+
+	// the value depends on the node if no limit is set
+	if quantity.IsZero() {
+		return "", false, nil
+	}
+
+	divisor := ref.Divisor
+	if divisor.IsZero() {
+		divisor = resource.MustParse("1")
+	}
+
+	if strings.HasSuffix(ref.Resource, ".cpu") {
+		return fmt.Sprintf("%d", int64(math.Ceil(float64(quantity.MilliValue())/float64(divisor.MilliValue())))), true, nil
+	}
+
+	return fmt.Sprintf("%d", int64(math.Ceil(float64(quantity.Value())/float64(divisor.Value())))), true, nil
+}
+
+// expandValue expands $(VAR) references the same way Kubernetes does
+func expandValue(value string, values map[string]string) string {
+	buf := strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 >= len(value) {
+			buf.WriteByte(value[i])
+			continue
+		}
+
+		switch value[i+1] {
+		case '$':
+			buf.WriteByte('$')
+			i++
+		case '(':
+			end := strings.IndexByte(value[i+2:], ')')
+			if end == -1 {
+				buf.WriteString(value[i:])
+				return buf.String()
+			}
+
+			name := value[i+2 : i+2+end]
+			if resolved, ok := values[name]; ok {
+				buf.WriteString(resolved)
+			} else {
+				buf.WriteString(value[i : i+3+end])
+			}
+			i += 2 + end
+		default:
+			buf.WriteByte('$')
+		}
+	}
+
+	return buf.String()
+}
+
+func findContainer(pod *corev1.Pod, name string) *corev1.Container {
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == name {
+			return &pod.Spec.Containers[i]
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == name {
+			return &pod.Spec.InitContainers[i]
+		}
+	}
+
+	return nil
+}
+
+func findVolume(pod *corev1.Pod, name string) *corev1.Volume {
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == name {
+			return &pod.Spec.Volumes[i]
+		}
+	}
+
+	return nil
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// WriteDotEnv writes the environment in the dotenv format
+func WriteDotEnv(w io.Writer, environment *Environment) error {
+	for _, envVar := range environment.Env {
+		_, err := fmt.Fprintf(w, "%s=%s\n", envVar.Name, quoteDotEnv(envVar.Value))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteWrapper writes a posix shell script that exports the environment
+// and executes the given arguments
+func WriteWrapper(w io.Writer, environment *Environment) error {
+	_, err := fmt.Fprintln(w, "#!/bin/sh")
+	if err != nil {
+		return err
+	}
+	for _, envVar := range environment.Env {
+		_, err = fmt.Fprintf(w, "export %s=%s\n", envVar.Name, quoteShell(envVar.Value))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w, `exec "$@"`)
+	return err
+}
+
+func quoteDotEnv(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func quoteShell(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
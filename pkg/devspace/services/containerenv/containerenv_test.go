@@ -0,0 +1,98 @@
+package containerenv
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolve(t *testing.T) {
+	optional := true
+	kubeClient := &kubectltesting.Client{
+		Client: fake.NewSimpleClientset(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "test"},
+				Data:       map[string]string{"B": "b", "A": "a"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "test"},
+				Data:       map[string][]byte{"password": []byte("s3cr3t")},
+			},
+		),
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "test"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				EnvFrom: []corev1.EnvFromSource{{
+					Prefix:       "CM_",
+					ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}},
+				}},
+				Env: []corev1.EnvVar{
+					{Name: "PLAIN", Value: "plain"},
+					{Name: "EXPANDED", Value: "$(PLAIN)-$(CM_A)-$(MISSING)-$$(PLAIN)"},
+					{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "secret"},
+						Key:                  "password",
+					}}},
+					{Name: "OPTIONAL", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "does-not-exist"},
+						Key:                  "key",
+						Optional:             &optional,
+					}}},
+					{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "secret", MountPath: "/etc/secret"}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "secret",
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "secret"}},
+			}},
+		},
+	}
+
+	dir := t.TempDir()
+	environment, err := Resolve(context.TODO(), kubeClient, pod, "app", &Options{MirrorFiles: true, Dir: dir})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, environment.Env, []EnvVar{
+		{Name: "CM_A", Value: "a"},
+		{Name: "CM_B", Value: "b"},
+		{Name: "PLAIN", Value: "plain"},
+		{Name: "EXPANDED", Value: "plain-a-$(MISSING)-$(PLAIN)"},
+		{Name: "PASSWORD", Value: "s3cr3t"},
+		{Name: "POD_NAMESPACE", Value: "test"},
+	})
+
+	localPath, ok := environment.Mounts["/etc/secret"]
+	assert.Assert(t, ok)
+	content, err := os.ReadFile(filepath.Join(localPath, "password"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "s3cr3t")
+
+	_, err = Resolve(context.TODO(), kubeClient, pod, "other", nil)
+	assert.ErrorContains(t, err, "couldn't find container other")
+}
+
+func TestWriteEnvironment(t *testing.T) {
+	environment := &Environment{Env: []EnvVar{
+		{Name: "A", Value: `it's "quoted" $HOME`},
+	}}
+
+	buf := &bytes.Buffer{}
+	assert.NilError(t, WriteDotEnv(buf, environment))
+	assert.Equal(t, buf.String(), "A=\"it's \\\"quoted\\\" \\$HOME\"\n")
+
+	buf.Reset()
+	assert.NilError(t, WriteWrapper(buf, environment))
+	assert.Equal(t, buf.String(), "#!/bin/sh\nexport A='it'\"'\"'s \"quoted\" $HOME'\nexec \"$@\"\n")
+}
@@ -0,0 +1,176 @@
+package debug
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+)
+
+// DefaultPorts are the ports the debuggers listen on if no port is configured
+var DefaultPorts = map[latest.DebugPreset]int{
+	latest.DebugPresetGo:     2345,
+	latest.DebugPresetPython: 5678,
+	latest.DebugPresetNode:   9229,
+	latest.DebugPresetJava:   5005,
+}
+
+// Enabled returns if a debugger should be started for the dev container
+func Enabled(devContainer *latest.DevContainer) bool {
+	return devContainer.Debug != nil && (devContainer.Debug.Enabled == nil || *devContainer.Debug.Enabled)
+}
+
+// Port returns the port the debugger listens on inside the container
+func Port(debug *latest.Debug) int {
+	if debug.Port > 0 {
+		return debug.Port
+	}
+
+	return DefaultPorts[debug.Preset]
+}
+
+// LocalPort returns the local port the debugger port is forwarded to
+func LocalPort(debug *latest.Debug) int {
+	if debug.LocalPort > 0 {
+		return debug.LocalPort
+	}
+
+	return Port(debug)
+}
+
+// PortMappings returns the port forwardings that are needed for the debuggers
+// of the dev pod and that are not already forwarded by dev.*.ports
+func PortMappings(devPod *latest.DevPod) []*latest.PortMapping {
+	forwarded := map[string]bool{}
+	for _, portMapping := range devPod.Ports {
+		forwarded[normalizePort(portMapping.Port)] = true
+	}
+
+	retMappings := []*latest.PortMapping{}
+	loader.EachDevContainer(devPod, func(devContainer *latest.DevContainer) bool {
+		if !Enabled(devContainer) {
+			return true
+		}
+
+		port := strconv.Itoa(LocalPort(devContainer.Debug)) + ":" + strconv.Itoa(Port(devContainer.Debug))
+		if forwarded[port] {
+			return true
+		}
+
+		forwarded[port] = true
+		retMappings = append(retMappings, &latest.PortMapping{Port: port})
+		return true
+	})
+
+	sort.Slice(retMappings, func(i, j int) bool {
+		return retMappings[i].Port < retMappings[j].Port
+	})
+	return retMappings
+}
+
+// normalizePort returns the port mapping in the local:remote form, so that
+// 2345 and 2345:2345 are treated as the same forwarding
+func normalizePort(port string) string {
+	port = strings.TrimSpace(port)
+	if !strings.Contains(port, ":") {
+		return port + ":" + port
+	}
+
+	return port
+}
+
+// configPath returns the path of the dev container within the config, which is used in error messages
+func configPath(devPod *latest.DevPod, devContainer *latest.DevContainer) string {
+	if devContainer.Container != "" && len(devPod.Containers) > 0 {
+		return fmt.Sprintf("dev.%s.containers[%s]", devPod.Name, devContainer.Container)
+	}
+
+	return "dev." + devPod.Name
+}
+
+// Wrap returns a copy of the dev container where the command and environment are rewritten to
+// start the container process through the configured debugger. If the debugger is not enabled,
+// the dev container is returned unchanged.
+func Wrap(devPod *latest.DevPod, devContainer *latest.DevContainer) (*latest.DevContainer, error) {
+	if !Enabled(devContainer) {
+		return devContainer, nil
+	}
+
+	debug := devContainer.Debug
+	port := Port(debug)
+	containerPath := configPath(devPod, devContainer)
+	wrapped := *devContainer
+	wrapped.Env = append([]latest.EnvVar{}, devContainer.Env...)
+	switch debug.Preset {
+	case latest.DebugPresetGo:
+		if len(devContainer.Command) == 0 {
+			return nil, fmt.Errorf("%s.debug.preset is go, please specify the binary that should get debugged in %s.command", containerPath, containerPath)
+		}
+
+		command := []string{"dlv", "--listen=:" + strconv.Itoa(port), "--headless=true", "--api-version=2", "--accept-multiclient"}
+		if !debug.Wait {
+			command = append(command, "--continue")
+		}
+		command = append(command, "exec", devContainer.Command[0], "--")
+		wrapped.Command = append(command, devContainer.Command[1:]...)
+	case latest.DebugPresetPython:
+		if len(devContainer.Command) == 0 {
+			return nil, fmt.Errorf("%s.debug.preset is python, please specify the script that should get debugged in %s.command", containerPath, containerPath)
+		}
+
+		interpreter := "python3"
+		program := devContainer.Command
+		if strings.HasPrefix(path.Base(program[0]), "python") {
+			interpreter = program[0]
+			program = program[1:]
+		}
+
+		command := []string{interpreter, "-m", "debugpy", "--listen", "0.0.0.0:" + strconv.Itoa(port)}
+		if debug.Wait {
+			command = append(command, "--wait-for-client")
+		}
+		wrapped.Command = append(command, program...)
+	case latest.DebugPresetNode:
+		inspect := "--inspect=0.0.0.0:" + strconv.Itoa(port)
+		if debug.Wait {
+			inspect = "--inspect-brk=0.0.0.0:" + strconv.Itoa(port)
+		}
+
+		// if node is started directly we pass the flag, otherwise (e.g. npm or nodemon) we use NODE_OPTIONS
+		if len(devContainer.Command) > 0 && path.Base(devContainer.Command[0]) == "node" {
+			command := []string{devContainer.Command[0], inspect}
+			wrapped.Command = append(command, devContainer.Command[1:]...)
+		} else {
+			wrapped.Env = append(wrapped.Env, latest.EnvVar{Name: "NODE_OPTIONS", Value: inspect})
+		}
+	case latest.DebugPresetJava:
+		suspend := "n"
+		if debug.Wait {
+			suspend = "y"
+		}
+
+		wrapped.Env = append(wrapped.Env, latest.EnvVar{
+			Name:  "JAVA_TOOL_OPTIONS",
+			Value: "-agentlib:jdwp=transport=dt_socket,server=y,suspend=" + suspend + ",address=*:" + strconv.Itoa(port),
+		})
+	default:
+		return nil, fmt.Errorf("%s.debug.preset '%s' is not supported, please use one of go, python, node or java", containerPath, debug.Preset)
+	}
+
+	// start the debugger through the restart helper, so that it is restarted on changes
+	if len(wrapped.Command) > 0 && (devContainer.RestartHelper == nil || devContainer.RestartHelper.Inject == nil) {
+		restartHelper := &latest.RestartHelper{}
+		if devContainer.RestartHelper != nil {
+			restartHelper.Path = devContainer.RestartHelper.Path
+		}
+		restartHelper.Inject = ptr.Bool(true)
+		wrapped.RestartHelper = restartHelper
+	}
+
+	return &wrapped, nil
+}
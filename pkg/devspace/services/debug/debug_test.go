@@ -0,0 +1,141 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestWrap(t *testing.T) {
+	devPod := &latest.DevPod{Name: "api"}
+	devContainer := &latest.DevContainer{
+		Command: []string{"/app/main", "serve"},
+		Debug: &latest.Debug{
+			Preset: latest.DebugPresetGo,
+		},
+	}
+	wrapped, err := Wrap(devPod, devContainer)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, wrapped.Command, []string{"dlv", "--listen=:2345", "--headless=true", "--api-version=2", "--accept-multiclient", "--continue", "exec", "/app/main", "--", "serve"})
+	assert.Equal(t, *wrapped.RestartHelper.Inject, true)
+	assert.DeepEqual(t, devContainer.Command, []string{"/app/main", "serve"})
+	assert.Assert(t, devContainer.RestartHelper == nil)
+
+	devContainer = &latest.DevContainer{
+		Command: []string{"python", "app.py"},
+		Debug: &latest.Debug{
+			Preset: latest.DebugPresetPython,
+			Wait:   true,
+		},
+	}
+	wrapped, err = Wrap(devPod, devContainer)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, wrapped.Command, []string{"python", "-m", "debugpy", "--listen", "0.0.0.0:5678", "--wait-for-client", "app.py"})
+
+	devContainer = &latest.DevContainer{
+		Command: []string{"npm", "start"},
+		Debug: &latest.Debug{
+			Preset: latest.DebugPresetNode,
+			Port:   9230,
+		},
+	}
+	wrapped, err = Wrap(devPod, devContainer)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, wrapped.Command, []string{"npm", "start"})
+	assert.DeepEqual(t, wrapped.Env, []latest.EnvVar{{Name: "NODE_OPTIONS", Value: "--inspect=0.0.0.0:9230"}})
+
+	devContainer = &latest.DevContainer{
+		Debug: &latest.Debug{
+			Preset: latest.DebugPresetJava,
+		},
+	}
+	wrapped, err = Wrap(devPod, devContainer)
+	assert.NilError(t, err)
+	assert.Assert(t, wrapped.RestartHelper == nil)
+	assert.DeepEqual(t, wrapped.Env, []latest.EnvVar{{Name: "JAVA_TOOL_OPTIONS", Value: "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:5005"}})
+
+	devContainer.Debug.Preset = latest.DebugPresetGo
+	_, err = Wrap(devPod, devContainer)
+	assert.Error(t, err, "dev.api.debug.preset is go, please specify the binary that should get debugged in dev.api.command")
+
+	devContainer.Container = "worker"
+	devPod.Containers = map[string]*latest.DevContainer{"worker": devContainer}
+	_, err = Wrap(devPod, devContainer)
+	assert.Error(t, err, "dev.api.containers[worker].debug.preset is go, please specify the binary that should get debugged in dev.api.containers[worker].command")
+}
+
+func TestPortMappings(t *testing.T) {
+	devPod := &latest.DevPod{
+		Ports: []*latest.PortMapping{{Port: "2345"}, {Port: "5678:5678"}},
+		Containers: map[string]*latest.DevContainer{
+			"a": {Container: "a", Debug: &latest.Debug{Preset: latest.DebugPresetGo}},
+			"b": {Container: "b", Debug: &latest.Debug{Preset: latest.DebugPresetPython}},
+			"c": {Container: "c", Debug: &latest.Debug{Preset: latest.DebugPresetNode, LocalPort: 9339}},
+		},
+	}
+
+	assert.DeepEqual(t, PortMappings(devPod), []*latest.PortMapping{{Port: "9339:9229"}})
+}
+
+func TestWriteLaunchConfigs(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, ".vscode"), 0755)
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".vscode", "launch.json"), []byte(`{"configurations": [{"type": "go", "name": "Local"}, {"name": "DevSpace: api", "type": "go"}], "version": "0.2.0"}`), 0644)
+	assert.NilError(t, err)
+
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithWorkingDir(dir)
+	devPod := &latest.DevPod{
+		Name: "api",
+		DevContainer: latest.DevContainer{
+			WorkingDir: "/app",
+			Sync: []*latest.SyncConfig{
+				{Path: "./src:."},
+				{Path: "./lib:/usr/lib/app"},
+			},
+			Debug: &latest.Debug{
+				Preset:        latest.DebugPresetGo,
+				LaunchConfigs: []latest.DebugLaunchConfig{latest.DebugLaunchConfigVSCode, latest.DebugLaunchConfigJetBrains},
+			},
+		},
+	}
+	err = WriteLaunchConfigs(ctx, devPod)
+	assert.NilError(t, err)
+
+	out, err := os.ReadFile(filepath.Join(dir, ".vscode", "launch.json"))
+	assert.NilError(t, err)
+	// the order of the existing keys is kept
+	assert.Assert(t, strings.Index(string(out), `"configurations"`) < strings.Index(string(out), `"version"`))
+	assert.Assert(t, strings.Contains(string(out), "{\n\t\t\t\"type\": \"go\",\n\t\t\t\"name\": \"Local\"\n\t\t}"), string(out))
+
+	launch := map[string]interface{}{}
+	err = json.Unmarshal(out, &launch)
+	assert.NilError(t, err)
+
+	configurations := launch["configurations"].([]interface{})
+	assert.Equal(t, len(configurations), 2)
+	assert.Equal(t, configurations[0].(map[string]interface{})["name"], "Local")
+	assert.DeepEqual(t, configurations[1], map[string]interface{}{
+		"name":    "DevSpace: api",
+		"type":    "go",
+		"request": "attach",
+		"mode":    "remote",
+		"host":    "localhost",
+		"port":    float64(2345),
+		"substitutePath": []interface{}{
+			map[string]interface{}{"from": "${workspaceFolder}/src", "to": "/app"},
+			map[string]interface{}{"from": "${workspaceFolder}/lib", "to": "/usr/lib/app"},
+		},
+	})
+
+	_, err = os.Stat(filepath.Join(dir, ".run", "devspace-api.run.xml"))
+	assert.NilError(t, err)
+}
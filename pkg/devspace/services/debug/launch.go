@@ -0,0 +1,386 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/pkg/errors"
+)
+
+// PathMapping maps a local source folder to the folder it is synced to inside the container
+type PathMapping struct {
+	Local  string
+	Remote string
+}
+
+// WriteLaunchConfigs writes the IDE launch configurations for all dev containers of the dev pod
+// that have a debugger configured
+func WriteLaunchConfigs(ctx devspacecontext.Context, devPod *latest.DevPod) error {
+	var err error
+	loader.EachDevContainer(devPod, func(devContainer *latest.DevContainer) bool {
+		if !Enabled(devContainer) {
+			return true
+		}
+
+		name := LaunchConfigName(devPod, devContainer)
+		for _, launchConfig := range launchConfigs(devContainer.Debug) {
+			switch launchConfig {
+			case latest.DebugLaunchConfigVSCode:
+				err = writeVSCodeLaunchConfig(ctx, name, devContainer)
+			case latest.DebugLaunchConfigJetBrains:
+				err = writeJetBrainsRunConfig(ctx, name, devContainer)
+			}
+			if err != nil {
+				err = errors.Wrapf(err, "write %s launch configuration for %s", launchConfig, configPath(devPod, devContainer))
+				return false
+			}
+		}
+
+		ctx.Log().Donef("Debugger (%s) of %s is available at localhost:%d", devContainer.Debug.Preset, name, LocalPort(devContainer.Debug))
+		return true
+	})
+
+	return err
+}
+
+// LaunchConfigName returns the name of the generated launch configuration
+func LaunchConfigName(devPod *latest.DevPod, devContainer *latest.DevContainer) string {
+	if devContainer.Container != "" && len(devPod.Containers) > 0 {
+		return "DevSpace: " + devPod.Name + "/" + devContainer.Container
+	}
+
+	return "DevSpace: " + devPod.Name
+}
+
+// PathMappings returns the local to remote path mappings derived from the sync configuration
+// of the dev container. Local paths are relative to the working dir, remote paths are absolute
+// if possible.
+func PathMappings(ctx devspacecontext.Context, devContainer *latest.DevContainer) []PathMapping {
+	mappings := []PathMapping{}
+	for _, syncConfig := range devContainer.Sync {
+		localPath, remotePath, err := sync.ParseSyncPath(syncConfig.Path)
+		if err != nil {
+			continue
+		}
+
+		localPath = ctx.ResolvePath(localPath)
+		if rel, err := filepath.Rel(ctx.WorkingDir(), localPath); err == nil && !strings.HasPrefix(rel, "..") {
+			localPath = rel
+		}
+		if !path.IsAbs(remotePath) && devContainer.WorkingDir != "" {
+			remotePath = path.Join(devContainer.WorkingDir, remotePath)
+		}
+
+		mappings = append(mappings, PathMapping{
+			Local:  filepath.ToSlash(localPath),
+			Remote: path.Clean(remotePath),
+		})
+	}
+
+	return mappings
+}
+
+func launchConfigs(debug *latest.Debug) []latest.DebugLaunchConfig {
+	if len(debug.LaunchConfigs) == 0 {
+		return []latest.DebugLaunchConfig{latest.DebugLaunchConfigVSCode}
+	}
+
+	return debug.LaunchConfigs
+}
+
+func workspacePath(prefix, local string) string {
+	if path.IsAbs(local) || filepath.IsAbs(local) {
+		return local
+	} else if local == "." {
+		return prefix
+	}
+
+	return prefix + "/" + local
+}
+
+// VSCodeLaunchConfig returns the launch.json configuration to attach to the debugger
+func VSCodeLaunchConfig(name string, debug *latest.Debug, mappings []PathMapping) map[string]interface{} {
+	port := LocalPort(debug)
+	switch debug.Preset {
+	case latest.DebugPresetGo:
+		substitutePath := []interface{}{}
+		for _, mapping := range mappings {
+			substitutePath = append(substitutePath, map[string]interface{}{
+				"from": workspacePath("${workspaceFolder}", mapping.Local),
+				"to":   mapping.Remote,
+			})
+		}
+		return map[string]interface{}{
+			"name":           name,
+			"type":           "go",
+			"request":        "attach",
+			"mode":           "remote",
+			"host":           "localhost",
+			"port":           port,
+			"substitutePath": substitutePath,
+		}
+	case latest.DebugPresetPython:
+		pathMappings := []interface{}{}
+		for _, mapping := range mappings {
+			pathMappings = append(pathMappings, map[string]interface{}{
+				"localRoot":  workspacePath("${workspaceFolder}", mapping.Local),
+				"remoteRoot": mapping.Remote,
+			})
+		}
+		return map[string]interface{}{
+			"name":    name,
+			"type":    "debugpy",
+			"request": "attach",
+			"connect": map[string]interface{}{
+				"host": "localhost",
+				"port": port,
+			},
+			"pathMappings": pathMappings,
+		}
+	case latest.DebugPresetNode:
+		config := map[string]interface{}{
+			"name":    name,
+			"type":    "node",
+			"request": "attach",
+			"address": "localhost",
+			"port":    port,
+			"restart": true,
+		}
+		// the node debugger only supports a single mapping
+		if len(mappings) > 0 {
+			config["localRoot"] = workspacePath("${workspaceFolder}", mappings[0].Local)
+			config["remoteRoot"] = mappings[0].Remote
+		}
+		return config
+	case latest.DebugPresetJava:
+		return map[string]interface{}{
+			"name":     name,
+			"type":     "java",
+			"request":  "attach",
+			"hostName": "localhost",
+			"port":     port,
+		}
+	}
+
+	return nil
+}
+
+func writeVSCodeLaunchConfig(ctx devspacecontext.Context, name string, devContainer *latest.DevContainer) error {
+	config := VSCodeLaunchConfig(name, devContainer.Debug, PathMappings(ctx, devContainer))
+	if config == nil {
+		return nil
+	}
+
+	launchPath := filepath.Join(ctx.WorkingDir(), ".vscode", "launch.json")
+	out, err := os.ReadFile(launchPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	keys, launch := []string{}, map[string]json.RawMessage{}
+	if len(out) > 0 {
+		keys, launch, err = parseOrderedObject(out)
+		if err != nil {
+			ctx.Log().Warnf("Skip writing debug configuration, because %s cannot be parsed (comments are not supported): %v", launchPath, err)
+			return nil
+		}
+	}
+	if _, ok := launch["version"]; !ok {
+		keys = append(keys, "version")
+		launch["version"] = json.RawMessage(`"0.2.0"`)
+	}
+
+	// replace an existing configuration with the same name or add a new one, other
+	// configurations are kept as they are
+	configurations := []json.RawMessage{}
+	if raw, ok := launch["configurations"]; ok {
+		_ = json.Unmarshal(raw, &configurations)
+	} else {
+		keys = append(keys, "configurations")
+	}
+	rawConfig, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, existing := range configurations {
+		existingConfig := struct {
+			Name string `json:"name"`
+		}{}
+		if json.Unmarshal(existing, &existingConfig) == nil && existingConfig.Name == name {
+			configurations[i] = rawConfig
+			replaced = true
+		}
+	}
+	if !replaced {
+		configurations = append(configurations, rawConfig)
+	}
+	launch["configurations"], err = json.Marshal(configurations)
+	if err != nil {
+		return err
+	}
+
+	newOut, err := marshalOrderedObject(keys, launch)
+	if err != nil {
+		return err
+	}
+	newOut = append(newOut, '\n')
+	if bytes.Equal(out, newOut) {
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(launchPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(launchPath, newOut, 0644)
+}
+
+// parseOrderedObject parses a json object and returns its keys in the order they appear
+func parseOrderedObject(data []byte) ([]string, map[string]json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	} else if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("expected a json object")
+	}
+
+	keys := []string{}
+	values := map[string]json.RawMessage{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key, _ := token.(string)
+		value := json.RawMessage{}
+		err = decoder.Decode(&value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = value
+	}
+
+	_, err = decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return keys, values, nil
+}
+
+// marshalOrderedObject writes the json object with its keys in the given order
+func marshalOrderedObject(keys []string, values map[string]json.RawMessage) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		rawKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(rawKey)
+		buf.WriteByte(':')
+		buf.Write(values[key])
+	}
+	buf.WriteByte('}')
+
+	compact := &bytes.Buffer{}
+	err := json.Compact(compact, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+	err = json.Indent(out, compact.Bytes(), "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// JetBrainsRunConfig returns the run configuration to attach to the debugger from a JetBrains IDE
+func JetBrainsRunConfig(name string, debug *latest.Debug, mappings []PathMapping) (string, error) {
+	port := strconv.Itoa(LocalPort(debug))
+	options := ""
+	switch debug.Preset {
+	case latest.DebugPresetGo:
+		options = `<configuration default="false" name="` + html.EscapeString(name) + `" type="GoRemoteDebugConfigurationType" factoryName="Go Remote">
+    <option name="disconnectOption" value="LEAVE" />
+    <option name="host" value="localhost" />
+    <option name="port" value="` + port + `" />
+    <method v="2" />
+  </configuration>`
+	case latest.DebugPresetNode:
+		mappingsXML := ""
+		for _, mapping := range mappings {
+			mappingsXML += `
+      <mapping url="file://` + html.EscapeString(mapping.Remote) + `" local-file="` + html.EscapeString(workspacePath("$PROJECT_DIR$", mapping.Local)) + `" />`
+		}
+		options = `<configuration default="false" name="` + html.EscapeString(name) + `" type="ChromiumRemoteDebugType" factoryName="Chromium Remote" port="` + port + `" restartOnDisconnect="true">
+    <mappings>` + mappingsXML + `
+    </mappings>
+    <method v="2" />
+  </configuration>`
+	case latest.DebugPresetJava:
+		options = `<configuration default="false" name="` + html.EscapeString(name) + `" type="Remote">
+    <option name="USE_SOCKET_TRANSPORT" value="true" />
+    <option name="SERVER_MODE" value="false" />
+    <option name="SHMEM_ADDRESS" />
+    <option name="HOST" value="localhost" />
+    <option name="PORT" value="` + port + `" />
+    <option name="AUTO_RESTART" value="true" />
+    <method v="2" />
+  </configuration>`
+	default:
+		return "", fmt.Errorf("jetbrains run configurations are not supported for the %s preset", debug.Preset)
+	}
+
+	return `<component name="ProjectRunConfigurationManager">
+  ` + options + `
+</component>
+`, nil
+}
+
+func writeJetBrainsRunConfig(ctx devspacecontext.Context, name string, devContainer *latest.DevContainer) error {
+	config, err := JetBrainsRunConfig(name, devContainer.Debug, PathMappings(ctx, devContainer))
+	if err != nil {
+		ctx.Log().Warnf("Skip writing debug configuration: %v", err)
+		return nil
+	}
+
+	runPath := filepath.Join(ctx.WorkingDir(), ".run", "devspace-"+encoding.Convert(strings.TrimPrefix(name, "DevSpace: "))+".run.xml")
+	out, err := os.ReadFile(runPath)
+	if err == nil && string(out) == config {
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(runPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(runPath, []byte(config), 0644)
+}
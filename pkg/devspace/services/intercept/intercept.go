@@ -0,0 +1,393 @@
+package intercept
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/portforward"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	"github.com/loft-sh/devspace/pkg/devspace/services/portforwarding"
+	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// InterceptLabel is the label DevSpace adds to the pod that receives the intercepted traffic
+	InterceptLabel = "devspace.sh/intercept"
+
+	// DefaultContainerPort is the first port DevSpace listens on inside the container if
+	// no container port is specified
+	DefaultContainerPort = 15800
+
+	// tunnelPortOffset is added to the container port for the reverse tunnel if the
+	// header proxy needs to listen on the container port
+	tunnelPortOffset = 100
+)
+
+// labelCheckInterval is how often the label of the intercepted pod is checked
+var labelCheckInterval = time.Second * 5
+
+type interceptPort struct {
+	servicePort int32
+	localPort   int
+	tunnelPort  int
+	proxyPort   int
+	fallback    int
+}
+
+// StartIntercept routes the traffic of the configured service to the local computer. If the
+// dev configuration has no intercept configured, a leftover intercept from a previous run
+// will be restored.
+func StartIntercept(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+	if ctx.IsDone() {
+		return nil
+	}
+
+	devPodCache, ok := ctx.Config().RemoteCache().GetDevPod(devPod.Name)
+	if devPod.Intercept == nil {
+		if ok && devPodCache.Intercept != nil {
+			return RestoreIntercept(ctx, &devPodCache)
+		}
+
+		return nil
+	}
+
+	selector = selector.WithContainer(devPod.Intercept.Container)
+	container, err := selector.SelectSingleContainer(ctx.Context(), ctx.KubeClient(), ctx.Log())
+	if err != nil {
+		return errors.Wrap(err, "error selecting container")
+	}
+
+	// restore an old intercept if it targets a different service
+	namespace := container.Pod.Namespace
+	if devPodCache.Intercept != nil && (devPodCache.Intercept.Service != devPod.Intercept.Service || devPodCache.Intercept.Namespace != namespace) {
+		err = RestoreIntercept(ctx, &devPodCache)
+		if err != nil {
+			return err
+		}
+	}
+
+	service, err := ctx.KubeClient().KubeClient().CoreV1().Services(namespace).Get(ctx.Context(), devPod.Intercept.Service, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "get service %s/%s", namespace, devPod.Intercept.Service)
+	}
+
+	// if we crashed before, the service is still intercepted and we
+	// need to keep the original state from the cache
+	if devPodCache.Intercept == nil {
+		devPodCache.Intercept = &remotecache.InterceptCache{
+			Service:     service.Name,
+			Namespace:   service.Namespace,
+			Selector:    service.Spec.Selector,
+			TargetPorts: map[int32]string{},
+		}
+		for _, port := range service.Spec.Ports {
+			devPodCache.Intercept.TargetPorts[port.Port] = port.TargetPort.String()
+		}
+	}
+
+	ports, err := parsePorts(devPod.Intercept, devPodCache.Intercept, container.Pod)
+	if err != nil {
+		return err
+	}
+
+	// save the original service state before we change anything
+	if devPodCache.Namespace == "" {
+		devPodCache.Namespace = namespace
+	}
+	devPodCache.Intercept.Pod = container.Pod.Name
+	ctx.Config().RemoteCache().SetDevPod(devPod.Name, devPodCache)
+	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	if err != nil {
+		return err
+	}
+
+	// start the reverse tunnel from the container to the local computer
+	portMappings := []*latest.PortMapping{}
+	for _, port := range ports {
+		portMappings = append(portMappings, &latest.PortMapping{Port: fmt.Sprintf("%d:%d", port.localPort, port.tunnelPort)})
+	}
+	err = portforwarding.StartReversePortForwarding(ctx, devPod.Name, string(devPod.Arch), portMappings, selector, parent)
+	if err != nil {
+		return errors.Wrap(err, "start reverse port forwarding")
+	}
+
+	// start the header proxies if needed
+	if len(devPod.Intercept.Headers) > 0 {
+		for _, port := range ports {
+			startProxy(ctx, container.Pod, container.Container.Name, port, devPod.Intercept.Headers, parent)
+		}
+	}
+
+	// route the service traffic to the selected pod
+	err = labelPod(ctx.Context(), ctx, container.Pod.Namespace, container.Pod.Name, devPod.Name)
+	if err != nil {
+		return errors.Wrap(err, "label pod")
+	}
+	err = updateService(ctx.Context(), ctx, namespace, service.Name, func(service *corev1.Service) {
+		service.Spec.Selector = map[string]string{InterceptLabel: devPod.Name}
+		for i := range service.Spec.Ports {
+			for _, port := range ports {
+				if service.Spec.Ports[i].Port != port.servicePort {
+					continue
+				}
+
+				if port.proxyPort != 0 {
+					service.Spec.Ports[i].TargetPort = intstr.FromInt(port.proxyPort)
+				} else {
+					service.Spec.Ports[i].TargetPort = intstr.FromInt(port.tunnelPort)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "intercept service")
+	}
+
+	for _, port := range ports {
+		ctx.Log().Donef("Intercepting service %s port %s", service.Name, ansi.Color(fmt.Sprintf("%d -> localhost:%d", port.servicePort, port.localPort), "white+b"))
+	}
+
+	// make sure the service keeps its endpoints
+	watchPodLabel(ctx, container.Pod.Namespace, container.Pod.Name, devPod.Name, parent)
+
+	// restore the service as soon as we are done
+	parent.Go(func() error {
+		<-ctx.Context().Done()
+
+		timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+		defer cancel()
+
+		devPodCache, ok := ctx.Config().RemoteCache().GetDevPod(devPod.Name)
+		if !ok {
+			return nil
+		}
+
+		err := RestoreIntercept(ctx.WithContext(timeoutCtx), &devPodCache)
+		if err != nil {
+			ctx.Log().Warnf("Error restoring service %s: %v", devPod.Intercept.Service, err)
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// RestoreIntercept restores the original selector and target ports of an intercepted service
+// and removes the intercept from the dev pod cache
+func RestoreIntercept(ctx devspacecontext.Context, devPodCache *remotecache.DevPodCache) error {
+	if devPodCache.Intercept == nil {
+		return nil
+	}
+
+	interceptCache := devPodCache.Intercept
+	namespace := interceptCache.Namespace
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	ctx.Log().Infof("Restore service %s...", interceptCache.Service)
+	err := updateService(ctx.Context(), ctx, namespace, interceptCache.Service, func(service *corev1.Service) {
+		service.Spec.Selector = interceptCache.Selector
+		for i := range service.Spec.Ports {
+			targetPort, ok := interceptCache.TargetPorts[service.Spec.Ports[i].Port]
+			if ok {
+				service.Spec.Ports[i].TargetPort = intstr.Parse(targetPort)
+			}
+		}
+	})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, "restore service")
+	}
+
+	// remove the label from the pod, if the pod is gone this doesn't matter
+	if interceptCache.Pod != "" {
+		patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%s:null}}}`, strconv.Quote(InterceptLabel)))
+		_, err = ctx.KubeClient().KubeClient().CoreV1().Pods(namespace).Patch(ctx.Context(), interceptCache.Pod, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			ctx.Log().Debugf("Error removing intercept label from pod %s/%s: %v", namespace, interceptCache.Pod, err)
+		}
+	}
+
+	devPodCache.Intercept = nil
+	if devPodCache.Deployment == "" {
+		ctx.Config().RemoteCache().DeleteDevPod(devPodCache.Name)
+	} else {
+		ctx.Config().RemoteCache().SetDevPod(devPodCache.Name, *devPodCache)
+	}
+	return ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+}
+
+func parsePorts(intercept *latest.Intercept, interceptCache *remotecache.InterceptCache, pod *corev1.Pod) ([]interceptPort, error) {
+	if len(intercept.Ports) == 0 {
+		return nil, fmt.Errorf("no ports to intercept specified for service %s", intercept.Service)
+	}
+
+	ports := []interceptPort{}
+	for i, port := range intercept.Ports {
+		mappings, err := portforward.ParsePorts([]string{port.Port})
+		if err != nil {
+			return nil, fmt.Errorf("error parsing port %s: %v", port.Port, err)
+		}
+
+		servicePort := int32(mappings[0].Remote)
+		originalTargetPort, ok := interceptCache.TargetPorts[servicePort]
+		if !ok {
+			return nil, fmt.Errorf("service %s has no port %d", intercept.Service, servicePort)
+		}
+
+		containerPort := port.ContainerPort
+		if containerPort == 0 {
+			containerPort = DefaultContainerPort + i
+		}
+
+		newPort := interceptPort{
+			servicePort: servicePort,
+			localPort:   int(mappings[0].Local),
+			tunnelPort:  containerPort,
+		}
+		if len(intercept.Headers) > 0 {
+			newPort.tunnelPort = containerPort + tunnelPortOffset
+			newPort.proxyPort = containerPort
+			newPort.fallback, err = resolveTargetPort(originalTargetPort, servicePort, pod)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ports = append(ports, newPort)
+	}
+
+	return ports, nil
+}
+
+func resolveTargetPort(targetPort string, servicePort int32, pod *corev1.Pod) (int, error) {
+	if targetPort == "" || targetPort == "0" {
+		return int(servicePort), nil
+	}
+
+	parsed := intstr.Parse(targetPort)
+	if parsed.Type == intstr.Int {
+		return parsed.IntValue(), nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == parsed.StrVal {
+				return int(port.ContainerPort), nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("couldn't find named port %s in pod %s/%s", parsed.StrVal, pod.Namespace, pod.Name)
+}
+
+func startProxy(ctx devspacecontext.Context, pod *corev1.Pod, container string, port interceptPort, headers map[string]string, parent *tomb.Tomb) {
+	command := []string{
+		inject.DevSpaceHelperContainerPath,
+		"intercept",
+		"--address", fmt.Sprintf(":%d", port.proxyPort),
+		"--target", fmt.Sprintf("http://localhost:%d", port.tunnelPort),
+		"--fallback", fmt.Sprintf("http://localhost:%d", port.fallback),
+	}
+
+	// sort headers to get a stable command
+	keys := []string{}
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		command = append(command, "--header", k+"="+headers[k])
+	}
+
+	parent.Go(func() error {
+		stdinReader, stdinWriter := io.Pipe()
+		defer stdinWriter.Close()
+
+		err := sync.StartStream(ctx.Context(), ctx.KubeClient(), pod, container, command, stdinReader, io.Discard, true, ctx.Log())
+		if err != nil && !ctx.IsDone() {
+			return errors.Errorf("intercept proxy in pod %s/%s stopped: %v", pod.Namespace, pod.Name, err)
+		}
+
+		return nil
+	})
+}
+
+// watchPodLabel re-applies the intercept label if it was removed from the pod. If the pod is
+// replaced, the label is gone with it and the service would have no endpoints, so we stop the
+// dev pod instead, which restores the service and intercepts again with the new pod.
+func watchPodLabel(ctx devspacecontext.Context, namespace, name, value string, parent *tomb.Tomb) {
+	parent.Go(func() error {
+		for {
+			select {
+			case <-ctx.Context().Done():
+				return nil
+			case <-time.After(labelCheckInterval):
+			}
+
+			pod, err := ctx.KubeClient().KubeClient().CoreV1().Pods(namespace).Get(ctx.Context(), name, metav1.GetOptions{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					return errors.Errorf("intercepted pod %s/%s isn't found anymore", namespace, name)
+				} else if !ctx.IsDone() {
+					ctx.Log().Debugf("Error retrieving intercepted pod %s/%s: %v", namespace, name, err)
+				}
+				continue
+			} else if pod.DeletionTimestamp != nil {
+				return errors.Errorf("intercepted pod %s/%s is terminating", namespace, name)
+			} else if pod.Labels[InterceptLabel] == value {
+				continue
+			}
+
+			ctx.Log().Infof("Intercept label was removed from pod %s/%s, adding it again", namespace, name)
+			err = labelPod(ctx.Context(), ctx, namespace, name, value)
+			if err != nil && !ctx.IsDone() {
+				ctx.Log().Warnf("Error labeling pod %s/%s: %v", namespace, name, err)
+			}
+		}
+	})
+}
+
+func labelPod(ctx context.Context, devCtx devspacecontext.Context, namespace, name, value string) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%s:%s}}}`, strconv.Quote(InterceptLabel), strconv.Quote(value)))
+	_, err := devCtx.KubeClient().KubeClient().CoreV1().Pods(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func updateService(ctx context.Context, devCtx devspacecontext.Context, namespace, name string, mutate func(service *corev1.Service)) error {
+	return wait.PollUntilContextTimeout(ctx, time.Second, time.Second*10, true, func(ctx context.Context) (bool, error) {
+		service, err := devCtx.KubeClient().KubeClient().CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		mutate(service)
+		_, err = devCtx.KubeClient().KubeClient().CoreV1().Services(namespace).Update(ctx, service, metav1.UpdateOptions{})
+		if err != nil {
+			if kerrors.IsConflict(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return true, nil
+	})
+}
@@ -0,0 +1,202 @@
+package intercept
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var testPod = &corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "api-0",
+		Namespace: "test",
+	},
+	Spec: corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "api",
+				Ports: []corev1.ContainerPort{
+					{Name: "http", ContainerPort: 8080},
+				},
+			},
+		},
+	},
+}
+
+type parsePortsTestCase struct {
+	name      string
+	intercept *latest.Intercept
+	cache     *remotecache.InterceptCache
+
+	expectedPorts []interceptPort
+	expectedErr   string
+}
+
+func TestParsePorts(t *testing.T) {
+	testCases := []parsePortsTestCase{
+		{
+			name:        "No ports",
+			intercept:   &latest.Intercept{Service: "api"},
+			expectedErr: "no ports to intercept specified for service api",
+		},
+		{
+			name: "Unknown service port",
+			intercept: &latest.Intercept{
+				Service: "api",
+				Ports:   []*latest.InterceptPort{{Port: "80"}},
+			},
+			cache:       &remotecache.InterceptCache{TargetPorts: map[int32]string{443: "8443"}},
+			expectedErr: "service api has no port 80",
+		},
+		{
+			name: "Default container ports",
+			intercept: &latest.Intercept{
+				Service: "api",
+				Ports:   []*latest.InterceptPort{{Port: "3000:80"}, {Port: "443"}},
+			},
+			cache: &remotecache.InterceptCache{TargetPorts: map[int32]string{80: "http", 443: "8443"}},
+			expectedPorts: []interceptPort{
+				{servicePort: 80, localPort: 3000, tunnelPort: DefaultContainerPort},
+				{servicePort: 443, localPort: 443, tunnelPort: DefaultContainerPort + 1},
+			},
+		},
+		{
+			name: "Headers",
+			intercept: &latest.Intercept{
+				Service: "api",
+				Ports:   []*latest.InterceptPort{{Port: "3000:80", ContainerPort: 9000}, {Port: "443"}},
+				Headers: map[string]string{"x-dev": "me"},
+			},
+			cache: &remotecache.InterceptCache{TargetPorts: map[int32]string{80: "http", 443: "8443"}},
+			expectedPorts: []interceptPort{
+				{servicePort: 80, localPort: 3000, tunnelPort: 9000 + tunnelPortOffset, proxyPort: 9000, fallback: 8080},
+				{servicePort: 443, localPort: 443, tunnelPort: DefaultContainerPort + 1 + tunnelPortOffset, proxyPort: DefaultContainerPort + 1, fallback: 8443},
+			},
+		},
+		{
+			name: "Headers with unknown named port",
+			intercept: &latest.Intercept{
+				Service: "api",
+				Ports:   []*latest.InterceptPort{{Port: "80"}},
+				Headers: map[string]string{"x-dev": "me"},
+			},
+			cache:       &remotecache.InterceptCache{TargetPorts: map[int32]string{80: "grpc"}},
+			expectedErr: "couldn't find named port grpc in pod test/api-0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		ports, err := parsePorts(testCase.intercept, testCase.cache, testPod)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Unexpected error in "+testCase.name)
+			continue
+		}
+
+		assert.NilError(t, err, "Unexpected error in "+testCase.name)
+		assert.Assert(t, reflect.DeepEqual(ports, testCase.expectedPorts), "Unexpected ports in %s: %+v", testCase.name, ports)
+	}
+}
+
+type resolveTargetPortTestCase struct {
+	name        string
+	targetPort  string
+	servicePort int32
+
+	expectedPort int
+	expectedErr  string
+}
+
+func TestResolveTargetPort(t *testing.T) {
+	testCases := []resolveTargetPortTestCase{
+		{
+			name:         "Empty target port",
+			servicePort:  80,
+			expectedPort: 80,
+		},
+		{
+			name:         "Zero target port",
+			targetPort:   "0",
+			servicePort:  80,
+			expectedPort: 80,
+		},
+		{
+			name:         "Numeric target port",
+			targetPort:   "8443",
+			servicePort:  443,
+			expectedPort: 8443,
+		},
+		{
+			name:         "Named target port",
+			targetPort:   "http",
+			servicePort:  80,
+			expectedPort: 8080,
+		},
+		{
+			name:        "Unknown named target port",
+			targetPort:  "grpc",
+			servicePort: 80,
+			expectedErr: "couldn't find named port grpc in pod test/api-0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		port, err := resolveTargetPort(testCase.targetPort, testCase.servicePort, testPod)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Unexpected error in "+testCase.name)
+			continue
+		}
+
+		assert.NilError(t, err, "Unexpected error in "+testCase.name)
+		assert.Equal(t, port, testCase.expectedPort, "Unexpected port in "+testCase.name)
+	}
+}
+
+func TestWatchPodLabel(t *testing.T) {
+	defer func(interval time.Duration) { labelCheckInterval = interval }(labelCheckInterval)
+	labelCheckInterval = time.Millisecond * 10
+
+	pod := testPod.DeepCopy()
+	client := fake.NewSimpleClientset(pod)
+	parent := &tomb.Tomb{}
+	ctx := devspacecontext.NewContext(parent.Context(context.Background()), nil, log.Discard).WithKubeClient(&kubectltesting.Client{Client: client})
+
+	// the label is added again
+	watchPodLabel(ctx, pod.Namespace, pod.Name, "api", parent)
+	assert.NilError(t, waitFor(func() bool {
+		pod, err := client.CoreV1().Pods(testPod.Namespace).Get(context.TODO(), testPod.Name, metav1.GetOptions{})
+		return err == nil && pod.Labels[InterceptLabel] == "api"
+	}))
+
+	// the watch fails if the pod is replaced
+	assert.NilError(t, client.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{}))
+	select {
+	case <-parent.Dead():
+	case <-time.After(time.Second * 5):
+		t.Fatal("watch didn't stop after the pod was deleted")
+	}
+	assert.Error(t, parent.Err(), "intercepted pod test/api-0 isn't found anymore")
+}
+
+func waitFor(condition func() bool) error {
+	for i := 0; i < 500; i++ {
+		if condition() {
+			return nil
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return context.DeadlineExceeded
+}
@@ -2,14 +2,18 @@ package logs
 
 import (
 	"fmt"
+	"io"
+	"sync"
+	"time"
+
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"github.com/mgutz/ansi"
 	"github.com/pkg/errors"
-	"io"
-	"time"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 // StartLogsWithWriter prints the logs and then attaches to the container with the given stdout and stderr
@@ -29,6 +33,60 @@ func StartLogsWithWriter(ctx devspacecontext.Context, selector targetselector.Ta
 	return err
 }
 
+var prefixColors = []string{"blue+b", "green+b", "yellow+b", "magenta+b", "cyan+b", "red+b"}
+
+// StartMultipleLogsWithWriter prints the logs of all given containers multiplexed to the writer.
+// Each line is prefixed with the pod name and, if there are multiple containers per pod, the container name.
+func StartMultipleLogsWithWriter(ctx devspacecontext.Context, containers []*selector.SelectedPodContainer, follow bool, tail int64, writer io.Writer) error {
+	if len(containers) == 0 {
+		return fmt.Errorf("couldn't find any running containers")
+	}
+
+	pods := map[string]bool{}
+	for _, container := range containers {
+		pods[container.Pod.Namespace+"/"+container.Pod.Name] = true
+	}
+
+	m := sync.Mutex{}
+	errChan := make(chan error, len(containers))
+	for i, container := range containers {
+		prefix := container.Pod.Name
+		if len(pods) != len(containers) {
+			prefix += ":" + container.Container.Name
+		}
+		prefix = ansi.Color("["+prefix+"]", prefixColors[i%len(prefixColors)]) + " "
+
+		ctx.Log().Infof("Printing logs of pod:container %s:%s", ansi.Color(container.Pod.Name, "white+b"), ansi.Color(container.Container.Name, "white+b"))
+		reader, err := ctx.KubeClient().Logs(ctx.Context(), container.Pod.Namespace, container.Pod.Name, container.Container.Name, false, &tail, follow)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			defer reader.Close()
+
+			s := scanner.NewScanner(reader)
+			for s.Scan() {
+				m.Lock()
+				_, _ = fmt.Fprintln(writer, prefix+s.Text())
+				m.Unlock()
+			}
+
+			errChan <- s.Err()
+		}()
+	}
+
+	var errs []error
+	for range containers {
+		err := <-errChan
+		if err != nil && !ctx.IsDone() {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
 // StartLogs print the logs and then attaches to the container
 func StartLogs(
 	ctx devspacecontext.Context,
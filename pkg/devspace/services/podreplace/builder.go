@@ -12,7 +12,9 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/debug"
 	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
@@ -135,6 +137,12 @@ func buildDeployment(ctx devspacecontext.Context, name string, target runtime.Ob
 		}
 	}
 
+	// mount workspaces
+	err = mountWorkspaces(devPod, podTemplate)
+	if err != nil {
+		return nil, err
+	}
+
 	// reset the metadata
 	if podTemplate.Labels == nil {
 		podTemplate.Labels = map[string]string{}
@@ -156,6 +164,7 @@ func buildDeployment(ctx devspacecontext.Context, name string, target runtime.Ob
 	}
 
 	deployment.Spec.Template = *podTemplate
+	deployment.Spec.Replicas = ptr.Int32(Replicas(devPod))
 	if deployment.Spec.Selector == nil {
 		deployment.Spec.Selector = &metav1.LabelSelector{}
 	}
@@ -178,7 +187,13 @@ func buildDeployment(ctx devspacecontext.Context, name string, target runtime.Ob
 }
 
 func modifyDevContainer(ctx devspacecontext.Context, devPod *latest.DevPod, devContainer *latest.DevContainer, podTemplate *corev1.PodTemplateSpec) error {
-	err := replaceImage(ctx, devPod, devContainer, podTemplate)
+	// rewrite command and env to start the debugger
+	devContainer, err := debug.Wrap(devPod, devContainer)
+	if err != nil {
+		return err
+	}
+
+	err = replaceImage(ctx, devPod, devContainer, podTemplate)
 	if err != nil {
 		return err
 	}
@@ -203,6 +218,11 @@ func modifyDevContainer(ctx devspacecontext.Context, devPod *latest.DevPod, devC
 		return errors.Wrap(err, "replace entrypoint")
 	}
 
+	err = replaceDebug(ctx, devPod, devContainer, podTemplate)
+	if err != nil {
+		return errors.Wrap(err, "replace debug")
+	}
+
 	err = replaceWorkingDir(ctx, devPod, devContainer, podTemplate)
 	if err != nil {
 		return errors.Wrap(err, "replace working dir")
@@ -247,6 +267,24 @@ func replaceResources(ctx devspacecontext.Context, devPod *latest.DevPod, devCon
 	return nil
 }
 
+func replaceDebug(ctx devspacecontext.Context, devPod *latest.DevPod, devContainer *latest.DevContainer, podTemplate *corev1.PodTemplateSpec) error {
+	if !debug.Enabled(devContainer) {
+		return nil
+	}
+
+	index, container, err := getPodTemplateContainer(ctx, devPod, devContainer, podTemplate)
+	if err != nil {
+		return err
+	}
+
+	// a paused debugger would otherwise fail the probes and get the container killed
+	container.ReadinessProbe = nil
+	container.LivenessProbe = nil
+	container.StartupProbe = nil
+	podTemplate.Spec.Containers[index] = *container
+	return nil
+}
+
 func replaceWorkingDir(ctx devspacecontext.Context, devPod *latest.DevPod, devContainer *latest.DevContainer, podTemplate *corev1.PodTemplateSpec) error {
 	if devContainer.WorkingDir == "" {
 		return nil
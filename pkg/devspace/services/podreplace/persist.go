@@ -2,13 +2,17 @@ package podreplace
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strings"
+
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko/util"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/services/workspace"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	"path"
-	"strings"
 )
 
 type containerPath struct {
@@ -118,3 +122,94 @@ func persistPaths(name string, devPod *latest.DevPod, podTemplate *corev1.PodTem
 
 	return nil
 }
+
+// workspaceNames returns the names of all workspaces that are mounted by the dev pod
+func workspaceNames(devPod *latest.DevPod) []string {
+	names := []string{}
+	loader.EachDevContainer(devPod, func(devContainer *latest.DevContainer) bool {
+		for _, mount := range devContainer.Workspaces {
+			found := false
+			for _, name := range names {
+				if name == mount.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				names = append(names, mount.Name)
+			}
+		}
+		return true
+	})
+
+	sort.Strings(names)
+	return names
+}
+
+func mountWorkspaces(devPod *latest.DevPod, podTemplate *corev1.PodTemplateSpec) error {
+	names := workspaceNames(devPod)
+	if len(names) == 0 {
+		return nil
+	}
+
+	var err error
+	loader.EachDevContainer(devPod, func(devContainer *latest.DevContainer) bool {
+		for _, mount := range devContainer.Workspaces {
+			if len(podTemplate.Spec.Containers) > 1 && devContainer.Container == "" {
+				containerNames := []string{}
+				for _, c := range podTemplate.Spec.Containers {
+					containerNames = append(containerNames, c.Name)
+				}
+
+				err = fmt.Errorf("couldn't mount workspace %s as multiple containers were found %s, but no containerName was specified", mount.Name, strings.Join(containerNames, " "))
+				return false
+			}
+
+			for i, con := range podTemplate.Spec.Containers {
+				if devContainer.Container == "" || devContainer.Container == con.Name {
+					podTemplate.Spec.Containers[i].VolumeMounts = append(podTemplate.Spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+						Name:      workspace.VolumeName(mount.Name),
+						MountPath: mount.Path,
+						SubPath:   mount.SubPath,
+						ReadOnly:  mount.ReadOnly,
+					})
+					break
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	userName := workspace.CurrentUser()
+	for _, name := range names {
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name: workspace.VolumeName(name),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: workspace.ClaimName(userName, name),
+				},
+			},
+		})
+	}
+
+	return nil
+}
+
+func ensureWorkspaces(ctx devspacecontext.Context, namespace string, devPod *latest.DevPod) error {
+	for _, name := range workspaceNames(devPod) {
+		workspaceConfig, ok := ctx.Config().Config().Workspaces[name]
+		if !ok {
+			return fmt.Errorf("workspace %s is not defined", name)
+		}
+
+		err := workspace.Ensure(ctx.Context(), ctx.KubeClient(), namespace, workspaceConfig, ctx.Log())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -43,6 +43,15 @@ type PodReplacer interface {
 	RevertReplacePod(ctx devspacecontext.Context, devPodCache *remotecache.DevPodCache, options *deploy.PurgeOptions) (bool, error)
 }
 
+// Replicas returns the amount of replicas the replaced deployment should have
+func Replicas(devPod *latest.DevPod) int32 {
+	if devPod.Replicas > 1 {
+		return int32(devPod.Replicas)
+	}
+
+	return 1
+}
+
 func NewPodReplacer() PodReplacer {
 	return &replacer{}
 }
@@ -158,7 +167,7 @@ func updateNeeded(ctx devspacecontext.Context, deployment *appsv1.Deployment, de
 
 	// update deployment
 	originalDeployment := deployment.DeepCopy()
-	deployment.Spec.Replicas = ptr.Int32(1)
+	deployment.Spec.Replicas = newDeployment.Spec.Replicas
 	deployment.Spec.Selector = newDeployment.Spec.Selector
 	deployment.Spec.Template = newDeployment.Spec.Template
 	deployment.Annotations = newDeployment.Annotations
@@ -234,6 +243,12 @@ func (p *replacer) replace(ctx devspacecontext.Context, deploymentName string, t
 }
 
 func updatePVC(ctx devspacecontext.Context, deployment *appsv1.Deployment, devPod *latest.DevPod) error {
+	// create the workspaces if needed
+	err := ensureWorkspaces(ctx, deployment.Namespace, devPod)
+	if err != nil {
+		return err
+	}
+
 	// create a pvc if needed
 	hasPersistPath := false
 	loader.EachDevContainer(devPod, func(devContainer *latest.DevContainer) bool {
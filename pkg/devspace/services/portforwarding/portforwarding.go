@@ -15,6 +15,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/devspace/services/debug"
 	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/pkg/errors"
@@ -28,9 +29,10 @@ func StartPortForwarding(ctx devspacecontext.Context, devPod *latest.DevPod, sel
 
 	// forward
 	initDoneArray := []chan struct{}{}
-	if len(devPod.Ports) > 0 {
+	ports := append(append([]*latest.PortMapping{}, devPod.Ports...), debug.PortMappings(devPod)...)
+	if len(ports) > 0 {
 		initDoneArray = append(initDoneArray, parent.NotifyGo(func() error {
-			return startPortForwardingWithHooks(ctx, devPod.Name, devPod.Ports, selector, parent)
+			return startPortForwardingWithHooks(ctx, devPod.Name, ports, selector, parent)
 		}))
 	}
 
@@ -27,9 +27,11 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/devspace/sync"
+	"github.com/loft-sh/devspace/pkg/devspace/tracing"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -65,7 +67,13 @@ func (c *controller) Start(ctx devspacecontext.Context, options *Options, parent
 		return pluginErr
 	}
 
-	err := c.startWithWait(ctx, options, parent)
+	waitInitialSync := options.SyncConfig.WaitInitialSync == nil || *options.SyncConfig.WaitInitialSync
+	spanCtx, span := tracing.Start(ctx.Context(), "initial sync "+options.Name,
+		attribute.String("devspace.sync.path", options.SyncConfig.Path),
+		attribute.Bool("devspace.sync.wait", waitInitialSync),
+	)
+	err := c.startWithWait(ctx.WithContext(spanCtx), options, parent)
+	tracing.End(span, err)
 	if err != nil {
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 			"sync_config": options.SyncConfig,
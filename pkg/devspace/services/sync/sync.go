@@ -62,6 +62,16 @@ func StartSyncFromCmd(ctx devspacecontext.Context, selector targetselector.Targe
 
 // StartSync starts the syncing functionality
 func StartSync(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, parent *tomb.Tomb) (retErr error) {
+	return startSyncs(ctx, devPod, selector, false, parent)
+}
+
+// StartReplicaSync starts the syncing functionality for an additional replica of a dev pod.
+// Changes are only uploaded to the replica, downloading is done from the primary replica only.
+func StartReplicaSync(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, parent *tomb.Tomb) (retErr error) {
+	return startSyncs(ctx, devPod, selector, true, parent)
+}
+
+func startSyncs(ctx devspacecontext.Context, devPod *latest.DevPod, selector targetselector.TargetSelector, uploadOnly bool, parent *tomb.Tomb) error {
 	if ctx == nil || ctx.Config() == nil || ctx.Config().Config() == nil {
 		return fmt.Errorf("DevSpace config is nil")
 	}
@@ -82,6 +92,11 @@ func StartSync(ctx devspacecontext.Context, devPod *latest.DevPod, selector targ
 		for _, syncConfig := range devContainer.Sync {
 			// start a new go routine in the tomb
 			s := syncConfig
+			if uploadOnly {
+				replicaConfig := *s
+				replicaConfig.DisableDownload = true
+				s = &replicaConfig
+			}
 			syncCtx := ctx
 			var cancel context.CancelFunc
 			if s.NoWatch {
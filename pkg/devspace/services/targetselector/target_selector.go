@@ -24,6 +24,9 @@ const DefaultPodQuestion = "Select a pod"
 // DefaultContainerQuestion defines the default question for selecting a container
 const DefaultContainerQuestion = "Select a container"
 
+// DefaultTimeout is how long to wait for a pod if no timeout is specified
+const DefaultTimeout = time.Minute * 10
+
 // Options holds the options for a target selector
 type Options struct {
 	selector selector.Selector
@@ -229,7 +232,7 @@ func (t *targetSelector) SelectSinglePod(ctx context.Context, client kubectl.Cli
 
 func (t *targetSelector) selectSingle(ctx context.Context, client kubectl.Client, options Options, log log.Logger, selectFn func(ctx context.Context, client kubectl.Client, options Options, log log.Logger) (bool, interface{}, error)) (interface{}, error) {
 	if options.wait == nil || *options.wait {
-		timeout := time.Minute * 10
+		timeout := DefaultTimeout
 		if options.timeout > 0 {
 			timeout = time.Duration(options.timeout) * time.Second
 		}
@@ -300,8 +303,9 @@ func (t *targetSelector) selectSingleContainer(ctx context.Context, client kubec
 		}
 
 		containerName, err := log.Question(&survey.QuestionOptions{
-			Question: question,
-			Options:  names,
+			Question:           question,
+			Options:            names,
+			NonInteractiveHint: "--pod and --container, --label-selector or --pick=false",
 		})
 		if err != nil {
 			return false, nil, err
@@ -359,8 +363,9 @@ func (t *targetSelector) selectSinglePod(ctx context.Context, client kubectl.Cli
 		}
 
 		podName, err := log.Question(&survey.QuestionOptions{
-			Question: question,
-			Options:  podNames,
+			Question:           question,
+			Options:            podNames,
+			NonInteractiveHint: "--pod, --label-selector or --pick=false",
 		})
 		if err != nil {
 			return false, nil, err
@@ -0,0 +1,368 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/encoding"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// WorkspaceLabel holds the name of the workspace on the persistent volume claim
+	WorkspaceLabel = "devspace.sh/workspace"
+	// UserLabel holds the user the workspace belongs to
+	UserLabel = "devspace.sh/workspace-user"
+	// SnapshotLabel holds the snapshot name if the claim is a snapshot of a workspace
+	SnapshotLabel = "devspace.sh/workspace-snapshot"
+
+	// DefaultSize is the size of a workspace if none is configured
+	DefaultSize = "10Gi"
+)
+
+// UserEnv can be set to override the identity workspaces are scoped to, e.g. for shared CI accounts
+const UserEnv = "DEVSPACE_WORKSPACE_USER"
+
+var deleteTimeout = 2 * time.Minute
+
+// userOverride is the identity set through --user
+var userOverride = ""
+
+// SetUser overrides the identity workspaces are scoped to
+func SetUser(name string) {
+	userOverride = name
+}
+
+// CurrentUser returns the identity that is used to scope workspaces. It is either the name set
+// through --user or DEVSPACE_WORKSPACE_USER, the global git email or the name of the local user.
+// The git email identifies the same person on different machines.
+func CurrentUser() string {
+	for _, name := range []string{userOverride, os.Getenv(UserEnv), gitEmail()} {
+		if name = encoding.Convert(name); name != "" {
+			return name
+		}
+	}
+
+	name := ""
+	u, err := user.Current()
+	if err == nil {
+		name = u.Username
+	}
+	if name == "" {
+		name = os.Getenv("USER")
+	}
+
+	name = encoding.Convert(name)
+	if name == "" {
+		name = "default"
+	}
+	return name
+}
+
+// gitEmail returns the email of the global git config or an empty string if there is none
+func gitEmail() string {
+	out, err := exec.Command("git", "config", "--global", "--get", "user.email").Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// ValidateSnapshotName returns an error if the name cannot be used for a snapshot
+func ValidateSnapshotName(snapshot string) error {
+	if errs := validation.IsDNS1123Label(snapshot); len(errs) > 0 {
+		return errors.Errorf("invalid snapshot name %s: %s", snapshot, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// ClaimName returns the name of the persistent volume claim of a user's workspace
+func ClaimName(userName, name string) string {
+	return encoding.SafeConcatName("devspace-workspace", userName, name)
+}
+
+// VolumeName returns the name of the pod volume a workspace is mounted with
+func VolumeName(name string) string {
+	return encoding.SafeConcatName("devspace-workspace", name)
+}
+
+// SnapshotClaimName returns the name of the persistent volume claim that holds a workspace snapshot
+func SnapshotClaimName(userName, name, snapshot string) string {
+	return encoding.SafeConcatName("devspace-workspace", userName, name, "snapshot", snapshot)
+}
+
+// Ensure creates the persistent volume claim of the workspace if it does not exist yet. The claim
+// has no owner, which means it won't be deleted if a replaced pod is reverted or the project is purged.
+func Ensure(ctx context.Context, client kubectl.Client, namespace string, workspace *latest.Workspace, log log.Logger) error {
+	userName := CurrentUser()
+	name := ClaimName(userName, workspace.Name)
+	_, err := client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	} else if !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "get workspace %s", workspace.Name)
+	}
+
+	size := DefaultSize
+	if workspace.Size != "" {
+		size = workspace.Size
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return fmt.Errorf("error parsing workspace size %s: %v", size, err)
+	}
+
+	var storageClassName *string
+	if workspace.StorageClassName != "" {
+		storageClassName = &workspace.StorageClassName
+	}
+
+	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	if len(workspace.AccessModes) > 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{}
+		for _, accessMode := range workspace.AccessModes {
+			accessModes = append(accessModes, corev1.PersistentVolumeAccessMode(accessMode))
+		}
+	}
+
+	_, err = client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				WorkspaceLabel: workspace.Name,
+				UserLabel:      userName,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: map[corev1.ResourceName]resource.Quantity{
+					corev1.ResourceStorage: quantity,
+				},
+			},
+			StorageClassName: storageClassName,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		if kerrors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		return errors.Wrapf(err, "create workspace %s", workspace.Name)
+	}
+
+	log.Donef("Created workspace %s", workspace.Name)
+	return nil
+}
+
+// List returns the workspaces of the given user in the namespace. If snapshots is true,
+// the snapshots of the workspaces are returned instead.
+func List(ctx context.Context, client kubectl.Client, namespace, userName string, snapshots bool) ([]corev1.PersistentVolumeClaim, error) {
+	selector := labels.NewSelector()
+	requirement, err := labels.NewRequirement(UserLabel, "=", []string{userName})
+	if err != nil {
+		return nil, err
+	}
+	selector = selector.Add(*requirement)
+	if snapshots {
+		requirement, err = labels.NewRequirement(SnapshotLabel, "exists", nil)
+	} else {
+		requirement, err = labels.NewRequirement(SnapshotLabel, "!", nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	selector = selector.Add(*requirement)
+
+	claims, err := client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrap(err, "list workspaces")
+	}
+
+	retClaims := []corev1.PersistentVolumeClaim{}
+	for _, claim := range claims.Items {
+		if claim.Labels[WorkspaceLabel] == "" {
+			continue
+		}
+
+		retClaims = append(retClaims, claim)
+	}
+	sort.Slice(retClaims, func(i, j int) bool {
+		return retClaims[i].Name < retClaims[j].Name
+	})
+	return retClaims, nil
+}
+
+// Reset deletes the workspace and recreates it either empty or from the given snapshot
+func Reset(ctx context.Context, client kubectl.Client, namespace, name, snapshot string, log log.Logger) error {
+	userName := CurrentUser()
+	claimName := ClaimName(userName, name)
+	claim, err := client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return errors.Errorf("couldn't find workspace %s in namespace %s", name, namespace)
+		}
+
+		return errors.Wrapf(err, "get workspace %s", name)
+	}
+
+	var dataSource *corev1.TypedLocalObjectReference
+	if snapshot != "" {
+		snapshotClaimName := SnapshotClaimName(userName, name, snapshot)
+		_, err = client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, snapshotClaimName, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return errors.Errorf("couldn't find snapshot %s of workspace %s", snapshot, name)
+			}
+
+			return errors.Wrapf(err, "get snapshot %s", snapshot)
+		}
+
+		dataSource = &corev1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: snapshotClaimName,
+		}
+	}
+
+	err = deleteClaim(ctx, client, namespace, claimName, log)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: namespace,
+			Labels:    claim.Labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      claim.Spec.AccessModes,
+			Resources:        claim.Spec.Resources,
+			StorageClassName: claim.Spec.StorageClassName,
+			VolumeMode:       claim.Spec.VolumeMode,
+			DataSource:       dataSource,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "recreate workspace %s", name)
+	}
+
+	return nil
+}
+
+// Snapshot creates a copy of the workspace by cloning its persistent volume claim. This requires
+// a storage class that supports volume cloning.
+func Snapshot(ctx context.Context, client kubectl.Client, namespace, name, snapshot string) error {
+	userName := CurrentUser()
+	claimName := ClaimName(userName, name)
+	claim, err := client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return errors.Errorf("couldn't find workspace %s in namespace %s", name, namespace)
+		}
+
+		return errors.Wrapf(err, "get workspace %s", name)
+	}
+
+	_, err = client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SnapshotClaimName(userName, name, snapshot),
+			Namespace: namespace,
+			Labels: map[string]string{
+				WorkspaceLabel: name,
+				UserLabel:      userName,
+				SnapshotLabel:  snapshot,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      claim.Spec.AccessModes,
+			Resources:        claim.Spec.Resources,
+			StorageClassName: claim.Spec.StorageClassName,
+			VolumeMode:       claim.Spec.VolumeMode,
+			DataSource: &corev1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: claimName,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		if kerrors.IsAlreadyExists(err) {
+			return errors.Errorf("snapshot %s of workspace %s already exists", snapshot, name)
+		}
+
+		return errors.Wrapf(err, "create snapshot %s", snapshot)
+	}
+
+	return nil
+}
+
+// Delete deletes the workspace and all of its snapshots
+func Delete(ctx context.Context, client kubectl.Client, namespace, name string) error {
+	userName := CurrentUser()
+	snapshots, err := List(ctx, client, namespace, userName, true)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Labels[WorkspaceLabel] != name {
+			continue
+		}
+
+		err = client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, snapshot.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "delete snapshot %s", snapshot.Labels[SnapshotLabel])
+		}
+	}
+
+	err = client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, ClaimName(userName, name), metav1.DeleteOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return errors.Errorf("couldn't find workspace %s in namespace %s", name, namespace)
+		}
+
+		return errors.Wrapf(err, "delete workspace %s", name)
+	}
+
+	return nil
+}
+
+func deleteClaim(ctx context.Context, client kubectl.Client, namespace, name string, log log.Logger) error {
+	err := client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return errors.Wrapf(err, "delete persistent volume claim %s", name)
+	}
+
+	log.Infof("Waiting for persistent volume claim %s to terminate. Make sure no pod is using the workspace anymore", name)
+	err = wait.PollUntilContextTimeout(ctx, time.Second, deleteTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		return kerrors.IsNotFound(err), nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "waiting for persistent volume claim %s to terminate", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWorkspaceLifecycle(t *testing.T) {
+	t.Setenv(UserEnv, "tester")
+
+	ctx := context.TODO()
+	client := &kubectltesting.Client{
+		Client: fake.NewSimpleClientset(),
+	}
+	userName := CurrentUser()
+
+	// create the workspace
+	err := Ensure(ctx, client, "test", &latest.Workspace{Name: "cache", Size: "5Gi"}, log.Discard)
+	assert.NilError(t, err)
+	claim, err := client.KubeClient().CoreV1().PersistentVolumeClaims("test").Get(ctx, ClaimName(userName, "cache"), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(claim.OwnerReferences), 0)
+	size := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, size.String(), "5Gi")
+
+	// ensure is idempotent
+	err = Ensure(ctx, client, "test", &latest.Workspace{Name: "cache"}, log.Discard)
+	assert.NilError(t, err)
+
+	// snapshot the workspace
+	err = Snapshot(ctx, client, "test", "cache", "snap")
+	assert.NilError(t, err)
+	err = Snapshot(ctx, client, "test", "cache", "snap")
+	assert.Error(t, err, "snapshot snap of workspace cache already exists")
+
+	workspaces, err := List(ctx, client, "test", userName, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(workspaces), 1)
+	snapshots, err := List(ctx, client, "test", userName, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	assert.Equal(t, snapshots[0].Spec.DataSource.Name, ClaimName(userName, "cache"))
+
+	// restore from the snapshot
+	err = Reset(ctx, client, "test", "cache", "snap", log.Discard)
+	assert.NilError(t, err)
+	claim, err = client.KubeClient().CoreV1().PersistentVolumeClaims("test").Get(ctx, ClaimName(userName, "cache"), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, claim.Spec.DataSource.Name, SnapshotClaimName(userName, "cache", "snap"))
+	assert.Equal(t, claim.Labels[WorkspaceLabel], "cache")
+
+	// delete the workspace and its snapshots
+	err = Delete(ctx, client, "test", "cache")
+	assert.NilError(t, err)
+	claims, err := client.KubeClient().CoreV1().PersistentVolumeClaims("test").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(claims.Items), 0)
+
+	err = Reset(ctx, client, "test", "cache", "", log.Discard)
+	assert.Error(t, err, "couldn't find workspace cache in namespace test")
+}
+
+func TestCurrentUser(t *testing.T) {
+	gitConfig := filepath.Join(t.TempDir(), "gitconfig")
+	assert.NilError(t, os.WriteFile(gitConfig, []byte("[user]\n\temail = Alice@acme.com\n"), 0644))
+	t.Setenv("GIT_CONFIG_GLOBAL", gitConfig)
+	t.Setenv(UserEnv, "")
+
+	// the git email is the same on every machine
+	assert.Equal(t, CurrentUser(), "alice-acme-com")
+
+	t.Setenv(UserEnv, "CI Runner")
+	assert.Equal(t, CurrentUser(), "ci-runner")
+
+	defer SetUser("")
+	SetUser("bob@acme.com")
+	assert.Equal(t, CurrentUser(), "bob-acme-com")
+}
+
+func TestValidateSnapshotName(t *testing.T) {
+	assert.NilError(t, ValidateSnapshotName("20060102-150405"))
+	assert.NilError(t, ValidateSnapshotName("before-upgrade"))
+	assert.ErrorContains(t, ValidateSnapshotName("Before_Upgrade"), "invalid snapshot name Before_Upgrade")
+	assert.ErrorContains(t, ValidateSnapshotName(strings.Repeat("a", 64)), "invalid snapshot name")
+}
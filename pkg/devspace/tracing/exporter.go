@@ -0,0 +1,186 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// httpClient sends the spans to an OTLP/HTTP collector
+type httpClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (h *httpClient) Start(ctx context.Context) error {
+	parsed, err := url.Parse(h.endpoint)
+	if err != nil {
+		return errors.Wrap(err, "parse endpoint")
+	} else if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("endpoint %s must start with http:// or https://", h.endpoint)
+	}
+
+	// the traces path is appended to base endpoints like it's done by the OpenTelemetry SDKs
+	if !strings.HasSuffix(parsed.Path, "/v1/traces") {
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/v1/traces"
+	}
+
+	h.endpoint = parsed.String()
+	h.client = &http.Client{Timeout: 10 * time.Second}
+	return nil
+}
+
+func (h *httpClient) Stop(ctx context.Context) error {
+	h.client.CloseIdleConnections()
+	return nil
+}
+
+func (h *httpClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	body, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		out, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("export traces to %s: %s %s", h.endpoint, resp.Status, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// fileClient collects the spans and writes them as json to the trace file and the history directory
+// when the trace is finished
+type fileClient struct {
+	file       string
+	historyDir string
+	command    string
+
+	m     sync.Mutex
+	spans []*tracepb.ResourceSpans
+}
+
+func (f *fileClient) Start(ctx context.Context) error {
+	return nil
+}
+
+func (f *fileClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	f.spans = append(f.spans, protoSpans...)
+	return nil
+}
+
+func (f *fileClient) Stop(ctx context.Context) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if len(f.spans) == 0 {
+		return nil
+	}
+
+	out, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: f.spans})
+	if err != nil {
+		return err
+	}
+
+	if f.file != "" {
+		err = writeFile(f.file, out)
+		if err != nil {
+			return err
+		}
+	}
+
+	// only keep a history within a project that was already initialized
+	if f.historyDir == "" {
+		return nil
+	} else if _, err := os.Stat(filepath.Dir(f.historyDir)); err != nil {
+		return nil
+	}
+
+	name := time.Now().Format("20060102-150405") + "-" + strings.ReplaceAll(f.command, " ", "-") + ".json"
+	err = writeFile(filepath.Join(f.historyDir, name), out)
+	if err != nil {
+		return err
+	}
+
+	return pruneHistory(f.historyDir)
+}
+
+func writeFile(path string, out []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, out, 0644)
+}
+
+// pruneHistory removes all but the most recent traces in the history directory
+func pruneHistory(dir string) error {
+	traces, err := ListHistory(dir)
+	if err != nil {
+		return err
+	}
+
+	for len(traces) > HistorySize {
+		err = os.Remove(traces[0])
+		if err != nil {
+			return err
+		}
+
+		traces = traces[1:]
+	}
+
+	return nil
+}
+
+// ListHistory returns the traces within the history directory from oldest to newest
+func ListHistory(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	traces := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			traces = append(traces, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	sort.Strings(traces)
+	return traces, nil
+}
@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Span is a recorded span of a trace file
+type Span struct {
+	ID       string
+	ParentID string
+	Name     string
+
+	Start time.Time
+	End   time.Time
+
+	Attributes map[string]string
+	Error      string
+
+	Children []*Span
+}
+
+// Duration returns how long the span took
+func (s *Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// LoadFile reads the spans of a json trace file and returns the root spans with their children
+func LoadFile(path string) ([]*Span, error) {
+	out, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &coltracepb.ExportTraceServiceRequest{}
+	err = protojson.Unmarshal(out, request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse trace file %s", path)
+	}
+
+	spans := []*Span{}
+	for _, resourceSpans := range request.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				spans = append(spans, convertSpan(span))
+			}
+		}
+	}
+
+	return buildTree(spans), nil
+}
+
+func convertSpan(span *tracepb.Span) *Span {
+	s := &Span{
+		ID:         hex.EncodeToString(span.SpanId),
+		ParentID:   hex.EncodeToString(span.ParentSpanId),
+		Name:       span.Name,
+		Start:      time.Unix(0, int64(span.StartTimeUnixNano)),
+		End:        time.Unix(0, int64(span.EndTimeUnixNano)),
+		Attributes: map[string]string{},
+	}
+	for _, attribute := range span.Attributes {
+		s.Attributes[attribute.Key] = attributeValue(attribute.Value)
+	}
+	if span.Status != nil && span.Status.Code == tracepb.Status_STATUS_CODE_ERROR {
+		s.Error = span.Status.Message
+		if s.Error == "" {
+			s.Error = "error"
+		}
+	}
+
+	return s
+}
+
+func attributeValue(value *commonpb.AnyValue) string {
+	if value == nil {
+		return ""
+	}
+
+	switch v := value.Value.(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return fmt.Sprint(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return fmt.Sprint(v.IntValue)
+	case *commonpb.AnyValue_DoubleValue:
+		return fmt.Sprint(v.DoubleValue)
+	case *commonpb.AnyValue_ArrayValue:
+		values := []string{}
+		for _, item := range v.ArrayValue.Values {
+			values = append(values, attributeValue(item))
+		}
+		return strings.Join(values, " ")
+	}
+
+	return ""
+}
+
+// buildTree links the spans to their parents and returns the spans without a recorded parent
+func buildTree(spans []*Span) []*Span {
+	byID := map[string]*Span{}
+	for _, span := range spans {
+		byID[span.ID] = span
+	}
+
+	roots := []*Span{}
+	for _, span := range spans {
+		parent, ok := byID[span.ParentID]
+		if span.ParentID == "" || !ok {
+			roots = append(roots, span)
+			continue
+		}
+
+		parent.Children = append(parent.Children, span)
+	}
+
+	sortSpans(roots)
+	return roots
+}
+
+func sortSpans(spans []*Span) {
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start.Before(spans[j].Start)
+	})
+	for _, span := range spans {
+		sortSpans(span.Children)
+	}
+}
+
+// Waterfall prints the spans as a tree together with a timeline bar of the given width. Spans that took
+// less than minDuration are omitted.
+func Waterfall(roots []*Span, width int, minDuration time.Duration) string {
+	if len(roots) == 0 {
+		return ""
+	}
+
+	start, end := roots[0].Start, roots[0].End
+	for _, root := range roots {
+		if root.Start.Before(start) {
+			start = root.Start
+		}
+		if root.End.After(end) {
+			end = root.End
+		}
+	}
+
+	type line struct {
+		name string
+		span *Span
+	}
+	lines := []line{}
+	var walk func(spans []*Span, depth int)
+	walk = func(spans []*Span, depth int) {
+		for _, span := range spans {
+			if depth > 0 && span.Duration() < minDuration {
+				continue
+			}
+
+			lines = append(lines, line{name: strings.Repeat("  ", depth) + span.Name, span: span})
+			walk(span.Children, depth+1)
+		}
+	}
+	walk(roots, 0)
+
+	nameWidth := 0
+	for _, l := range lines {
+		if len(l.name) > nameWidth {
+			nameWidth = len(l.name)
+		}
+	}
+
+	total := end.Sub(start)
+	out := &strings.Builder{}
+	for _, l := range lines {
+		from, to := 0, width
+		if total > 0 {
+			from = int(float64(l.span.Start.Sub(start)) / float64(total) * float64(width))
+			to = int(float64(l.span.End.Sub(start)) / float64(total) * float64(width))
+		}
+		if to <= from {
+			to = from + 1
+		}
+		if to > width {
+			to = width
+			if from >= to {
+				from = to - 1
+			}
+		}
+
+		bar := strings.Repeat(" ", from) + strings.Repeat("█", to-from) + strings.Repeat(" ", width-to)
+		fmt.Fprintf(out, "%-*s  %9s  |%s|", nameWidth, l.name, formatDuration(l.span.Duration()), bar)
+		if l.span.Error != "" {
+			errorLine, _, _ := strings.Cut(l.span.Error, "\n")
+			fmt.Fprintf(out, " ERROR: %s", errorLine)
+		}
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
+
+func formatDuration(duration time.Duration) string {
+	switch {
+	case duration >= time.Minute:
+		return duration.Round(time.Second).String()
+	case duration >= time.Second:
+		return duration.Round(10 * time.Millisecond).String()
+	default:
+		return duration.Round(time.Millisecond).String()
+	}
+}
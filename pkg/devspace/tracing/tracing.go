@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// TracerName is the name of the tracer that records the devspace spans
+const TracerName = "github.com/loft-sh/devspace"
+
+// HistoryDir is the directory relative to the project root where the most recent traces are kept
+var HistoryDir = filepath.Join(".devspace", "traces")
+
+// HistorySize is the number of traces that are kept in the history directory
+const HistorySize = 10
+
+// Options configure where the spans of a command are exported to
+type Options struct {
+	// File is the path of a json file the trace is written to
+	File string
+
+	// Endpoint is the url of an OTLP/HTTP collector the trace is sent to, e.g. http://localhost:4318
+	Endpoint string
+}
+
+// EndpointFromEnv returns the OTLP endpoint configured through the standard OpenTelemetry
+// environment variables
+func EndpointFromEnv() string {
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+}
+
+// Start starts a new span as child of the span within the context. If tracing was not started,
+// the returned span does nothing.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records the error on the span and ends it. Exit status 0 returned by pipeline commands is no error.
+func End(span trace.Span, err error) {
+	if status, ok := interp.IsExitStatus(err); ok && status == 0 {
+		err = nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// StartCommand starts tracing a devspace command. The trace is always kept in the history directory of the
+// project and additionally exported as configured by the options. The returned function ends the root span
+// and flushes the trace, it can be called multiple times.
+func StartCommand(ctx context.Context, name string, options Options, log log.Logger) (context.Context, func(err error)) {
+	var err error
+	if options.File != "" {
+		options.File, err = filepath.Abs(options.File)
+		if err != nil {
+			log.Warnf("Error resolving trace file %s: %v", options.File, err)
+			options.File = ""
+		}
+	}
+
+	exporters := []*otlptrace.Exporter{}
+	fileExporter, err := otlptrace.New(ctx, &fileClient{
+		file:       options.File,
+		historyDir: HistoryDir,
+		command:    name,
+	})
+	if err != nil {
+		log.Warnf("Error starting trace file exporter: %v", err)
+	} else {
+		exporters = append(exporters, fileExporter)
+	}
+
+	if options.Endpoint != "" {
+		endpointExporter, err := otlptrace.New(ctx, &httpClient{endpoint: options.Endpoint})
+		if err != nil {
+			log.Warnf("Error starting trace exporter for %s: %v", options.Endpoint, err)
+		} else {
+			exporters = append(exporters, endpointExporter)
+		}
+	}
+
+	providerOptions := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "devspace"),
+			attribute.String("service.version", upgrade.GetVersion()),
+		)),
+	}
+	for _, exporter := range exporters {
+		providerOptions = append(providerOptions, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(providerOptions...)
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Debugf("Error exporting trace: %v", err)
+	}))
+
+	ctx, span := Start(ctx, name, attribute.String("devspace.command", strings.TrimPrefix(name, "devspace ")))
+	once := sync.Once{}
+	return ctx, func(err error) {
+		once.Do(func() {
+			End(span, err)
+
+			err := provider.Shutdown(context.Background())
+			if err != nil {
+				log.Debugf("Error flushing trace: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"go.opentelemetry.io/otel/attribute"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestStartCommand(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, ".devspace"), 0755))
+
+	oldHistoryDir := HistoryDir
+	HistoryDir = filepath.Join(dir, ".devspace", "traces")
+	defer func() { HistoryDir = oldHistoryDir }()
+
+	received := make(chan *coltracepb.ExportTraceServiceRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/traces")
+		body, _ := io.ReadAll(r.Body)
+		request := &coltracepb.ExportTraceServiceRequest{}
+		assert.NilError(t, proto.Unmarshal(body, request))
+		received <- request
+	}))
+	defer server.Close()
+
+	traceFile := filepath.Join(dir, "trace.json")
+	ctx, stop := StartCommand(context.Background(), "devspace dev", Options{File: traceFile, Endpoint: server.URL}, log.Discard)
+	pipelineCtx, pipelineSpan := Start(ctx, "pipeline dev", attribute.String("devspace.pipeline", "dev"))
+	_, buildSpan := Start(pipelineCtx, "build image api")
+	End(buildSpan, errors.New("docker not running"))
+	End(pipelineSpan, nil)
+	stop(nil)
+	stop(nil)
+
+	request := <-received
+	assert.Equal(t, len(request.ResourceSpans[0].ScopeSpans[0].Spans), 3)
+
+	roots, err := LoadFile(traceFile)
+	assert.NilError(t, err)
+	assert.Equal(t, len(roots), 1)
+	assert.Equal(t, roots[0].Name, "devspace dev")
+	assert.Equal(t, roots[0].Attributes["devspace.command"], "dev")
+	assert.Equal(t, roots[0].Children[0].Name, "pipeline dev")
+	assert.Equal(t, roots[0].Children[0].Attributes["devspace.pipeline"], "dev")
+	assert.Equal(t, roots[0].Children[0].Children[0].Error, "docker not running")
+
+	history, err := ListHistory(HistoryDir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(history), 1)
+	assert.Assert(t, strings.HasSuffix(history[0], "-devspace-dev.json"), history[0])
+}
+
+func TestPruneHistory(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < HistorySize+3; i++ {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, "2026010"+string(rune('a'+i))+".json"), []byte("{}"), 0644))
+	}
+
+	assert.NilError(t, pruneHistory(dir))
+	history, err := ListHistory(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(history), HistorySize)
+	assert.Equal(t, filepath.Base(history[0]), "2026010d.json")
+}
+
+func TestWaterfall(t *testing.T) {
+	start := time.Unix(0, 0)
+	roots := buildTree([]*Span{
+		{ID: "1", Name: "devspace deploy", Start: start, End: start.Add(10 * time.Second)},
+		{ID: "3", ParentID: "2", Name: "helm upgrade", Start: start.Add(5 * time.Second), End: start.Add(10 * time.Second), Error: "release failed\ndetails"},
+		{ID: "2", ParentID: "1", Name: "create_deployments", Start: start, End: start.Add(10 * time.Second)},
+		{ID: "4", ParentID: "2", Name: "kubectl apply", Start: start, End: start.Add(time.Millisecond)},
+	})
+
+	assert.Equal(t, Waterfall(roots, 10, 0), strings.Join([]string{
+		"devspace deploy             10s  |██████████|",
+		"  create_deployments        10s  |██████████|",
+		"    kubectl apply           1ms  |█         |",
+		"    helm upgrade             5s  |     █████| ERROR: release failed",
+		"",
+	}, "\n"))
+
+	assert.Equal(t, Waterfall(roots, 10, time.Second), strings.Join([]string{
+		"devspace deploy             10s  |██████████|",
+		"  create_deployments        10s  |██████████|",
+		"    helm upgrade             5s  |     █████| ERROR: release failed",
+		"",
+	}, "\n"))
+}
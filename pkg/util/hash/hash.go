@@ -80,6 +80,37 @@ func Directory(path string) (string, error) {
 	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
+// Contents creates the hash value of the contents of a file or of all files within a directory.
+// In contrast to Directory, the hash doesn't change if only the modification times change.
+func Contents(path string) (string, error) {
+	hash := sha256.New()
+	err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(path, filePath)
+		if err != nil {
+			return err
+		}
+
+		fileHash, err := File(filePath)
+		if err != nil {
+			return err
+		}
+
+		_, _ = io.WriteString(hash, filepath.ToSlash(relPath)+";"+fileHash+";")
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
 // DirectoryExcludes calculates a hash for a directory and excludes the submitted patterns
 func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (string, error) {
 	srcPath, err := filepath.Abs(srcPath)
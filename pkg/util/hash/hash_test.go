@@ -2,7 +2,9 @@ package hash
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 
@@ -57,6 +59,33 @@ func TestHashDirectory(t *testing.T) {
 
 }
 
+func TestHashContents(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "gen"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "gen", "a.pb.go"), []byte("package gen"), 0644))
+
+	dirHash, err := Contents(dir)
+	assert.NilError(t, err)
+
+	// the hash only depends on the paths and contents of the files
+	assert.NilError(t, os.Chtimes(filepath.Join(dir, "gen", "a.pb.go"), time.Now().Add(time.Hour), time.Now().Add(time.Hour)))
+	changedHash, err := Contents(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, changedHash, dirHash)
+
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "gen", "a.pb.go"), []byte("package changed"), 0644))
+	changedHash, err = Contents(dir)
+	assert.NilError(t, err)
+	assert.Assert(t, changedHash != dirHash)
+
+	fileHash, err := Contents(filepath.Join(dir, "gen", "a.pb.go"))
+	assert.NilError(t, err)
+	assert.Assert(t, fileHash != changedHash)
+
+	_, err = Contents(filepath.Join(dir, "missing"))
+	assert.Assert(t, os.IsNotExist(err))
+}
+
 func TestHashDirectoryExcludes(t *testing.T) {
 	dir := t.TempDir()
 
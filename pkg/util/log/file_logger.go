@@ -31,6 +31,9 @@ type fileLogger struct {
 	level    logrus.Level
 	sinks    []Logger
 	prefixes []string
+
+	// pending is the beginning of a secret that is held back until the next write
+	pending []byte
 }
 
 func GetDevPodFileLogger(devPodName string) Logger {
@@ -136,7 +139,7 @@ func (f *fileLogger) addPrefixes(message string) string {
 		prefix += p
 	}
 
-	return prefix + message
+	return Redact(prefix + message)
 }
 
 func (f *fileLogger) Debug(args ...interface{}) {
@@ -335,7 +338,17 @@ func (f *fileLogger) Writer(level logrus.Level, raw bool) io.WriteCloser {
 }
 
 func (f *fileLogger) Write(message []byte) (int, error) {
-	return f.logger.Out.Write(message)
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	var redacted []byte
+	redacted, f.pending = redactStream(f.pending, message)
+	if len(redacted) == 0 {
+		return len(message), nil
+	}
+
+	_, err := f.logger.Out.Write(redacted)
+	return len(message), err
 }
 
 func (f *fileLogger) WriteString(level logrus.Level, message string) {
@@ -346,7 +359,7 @@ func (f *fileLogger) WriteString(level logrus.Level, message string) {
 		return
 	}
 
-	_, _ = f.logger.Out.Write([]byte(stripEscapeSequences(message)))
+	_, _ = f.logger.Out.Write([]byte(Redact(stripEscapeSequences(message))))
 }
 
 func stripEscapeSequences(str string) string {
@@ -364,6 +377,7 @@ func (f *fileLogger) WithLevel(level logrus.Level) Logger {
 
 	n := *f
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.level = level
 	return &n
 }
@@ -374,6 +388,7 @@ func (f *fileLogger) WithSink(log Logger) Logger {
 
 	n := *f
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.sinks = append(n.sinks, log)
 	return &n
 }
@@ -391,6 +406,7 @@ func (f *fileLogger) WithPrefix(prefix string) Logger {
 
 	n := *f
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.prefixes = append(n.prefixes, prefix)
 	return &n
 }
@@ -401,6 +417,7 @@ func (f *fileLogger) WithPrefixColor(prefix, color string) Logger {
 
 	n := *f
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.prefixes = append(n.prefixes, prefix)
 	return &n
 }
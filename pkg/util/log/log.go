@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"runtime"
+	"sync"
 
 	"github.com/loft-sh/devspace/pkg/util/scanner"
 	"github.com/mgutz/ansi"
@@ -33,10 +34,14 @@ func PrintLogo() {
 	stdout.Write([]byte(ansi.Color("\r\n"+logo+"\r\n\r\n", "cyan+b")))
 }
 
+var startFileLoggingOnce sync.Once
+
 // StartFileLogging logs the output of the global logger to the file default.log
 func StartFileLogging() {
-	defaultLog.AddSink(GetFileLogger("default"))
-	OverrideRuntimeErrorHandler(false)
+	startFileLoggingOnce.Do(func() {
+		defaultLog.AddSink(GetFileLogger("default"))
+		OverrideRuntimeErrorHandler(false)
+	})
 }
 
 // GetInstance returns the Logger instance
@@ -0,0 +1,145 @@
+package log
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Redacted is the replacement for secret values in the log output
+const Redacted = "******"
+
+// minSecretLength is the minimum length of a secret to be redacted, shorter values
+// would redact too much of the regular output
+const minSecretLength = 4
+
+var (
+	secretsMutex sync.RWMutex
+	secrets      = map[string]bool{}
+	redactor     *strings.Replacer
+
+	// lineSecrets are the single line secrets with the longest first, multi line secrets are
+	// redacted line by line in streamed output
+	lineSecrets []string
+)
+
+// RegisterSecret makes sure the value is redacted from all log output. Multi line
+// values are redacted line by line as well. Returns false if the value (or one of its
+// lines) is too short to be redacted.
+func RegisterSecret(secret string) bool {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	values := []string{strings.TrimSpace(secret)}
+	if strings.Contains(secret, "\n") {
+		values = append(values, strings.Split(secret, "\n")...)
+	}
+
+	changed := false
+	redacted := true
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || secrets[value] {
+			continue
+		} else if len(value) < minSecretLength {
+			redacted = false
+			continue
+		}
+
+		secrets[value] = true
+		changed = true
+	}
+	if !changed {
+		return redacted
+	}
+
+	// replace longer secrets first
+	sorted := make([]string, 0, len(secrets))
+	for value := range secrets {
+		sorted = append(sorted, value)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	oldNew := make([]string, 0, len(sorted)*2)
+	for _, value := range sorted {
+		oldNew = append(oldNew, value, Redacted)
+	}
+	redactor = strings.NewReplacer(oldNew...)
+	lineSecrets = []string{}
+	for _, value := range sorted {
+		if !strings.Contains(value, "\n") {
+			lineSecrets = append(lineSecrets, value)
+		}
+	}
+	return redacted
+}
+
+// Redact replaces all registered secrets in the message
+func Redact(message string) string {
+	secretsMutex.RLock()
+	defer secretsMutex.RUnlock()
+
+	if redactor == nil {
+		return message
+	}
+
+	return redactor.Replace(message)
+}
+
+// redactStream redacts the secrets in pending and message. If the end of the message could be
+// the beginning of a secret, it is returned as the new pending output and held back until the
+// next write, so that secrets that are split across several writes are redacted as well.
+func redactStream(pending, message []byte) ([]byte, []byte) {
+	data := append(pending, message...)
+	secretsMutex.RLock()
+	defer secretsMutex.RUnlock()
+
+	if len(lineSecrets) == 0 {
+		return data, nil
+	}
+
+	redacted := make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		rest := data[i:]
+		if isSecretPrefix(rest) {
+			return redacted, append([]byte{}, rest...)
+		} else if secret := secretAt(rest); secret != "" {
+			redacted = append(redacted, Redacted...)
+			i += len(secret)
+			continue
+		}
+
+		redacted = append(redacted, data[i])
+		i++
+	}
+
+	return redacted, nil
+}
+
+// isSecretPrefix returns true if data is the incomplete beginning of a secret
+func isSecretPrefix(data []byte) bool {
+	for _, secret := range lineSecrets {
+		if len(data) < len(secret) && strings.HasPrefix(secret, string(data)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// secretAt returns the longest secret data starts with
+func secretAt(data []byte) string {
+	for _, secret := range lineSecrets {
+		if bytes.HasPrefix(data, []byte(secret)) {
+			return secret
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,133 @@
+package log
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestRedact(t *testing.T) {
+	defer resetSecrets()
+	RegisterSecret("abc")
+	RegisterSecret("super-secret-token")
+	RegisterSecret("first-line\nsecond-line")
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	logger := NewStreamLoggerWithFormat(stdout, stderr, logrus.InfoLevel, RawFormat)
+
+	logger.Infof("token is %s", "super-secret-token")
+	logger.WriteString(logrus.InfoLevel, "abc second-line\n")
+	n, err := logger.Write([]byte("first-line\n"))
+	if err != nil {
+		t.Fatal(err)
+	} else if n != len("first-line\n") {
+		t.Fatalf("expected %d bytes written, got %d", len("first-line\n"), n)
+	}
+
+	expected := "token is ******\nabc ******\n******\n"
+	if stdout.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRedactSplitWrites(t *testing.T) {
+	defer resetSecrets()
+	RegisterSecret("super-secret-token")
+
+	stdout := &bytes.Buffer{}
+	logger := NewStreamLoggerWithFormat(stdout, stdout, logrus.InfoLevel, RawFormat)
+
+	_, _ = logger.Write([]byte("token is super-se"))
+	if stdout.String() != "token is " {
+		t.Fatalf("expected only the beginning of the secret to be held back, got %q", stdout.String())
+	}
+
+	_, _ = logger.Write([]byte("cret-token and more"))
+	_, _ = logger.Write([]byte("\nnext "))
+	logger.Info("line")
+
+	expected := "token is ****** and more\nnext line\n"
+	if stdout.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRedactTrailingOutput(t *testing.T) {
+	defer resetSecrets()
+	RegisterSecret("super-secret-token")
+
+	// output without a newline is written right away
+	stdout := &syncBuffer{}
+	logger := NewStreamLoggerWithFormat(stdout, stdout, logrus.InfoLevel, RawFormat)
+	_, _ = logger.Write([]byte("Enter a value: "))
+	if stdout.String() != "Enter a value: " {
+		t.Fatalf("expected trailing output to be written, got %q", stdout.String())
+	}
+
+	// the beginning of a secret is held back until a writer is closed
+	_, _ = logger.Write([]byte("last super"))
+	if stdout.String() != "Enter a value: last " {
+		t.Fatalf("expected the beginning of the secret to be held back, got %q", stdout.String())
+	}
+	writer := logger.Writer(logrus.InfoLevel, true)
+	_ = writer.Close()
+	for i := 0; i < 100 && stdout.String() != "Enter a value: last super"; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stdout.String() != "Enter a value: last super" {
+		t.Fatalf("expected trailing output to be flushed, got %q", stdout.String())
+	}
+
+	// the file logger writes trailing output right away as well
+	out := &bytes.Buffer{}
+	fileLogger := &fileLogger{logger: logrus.New(), m: &sync.Mutex{}}
+	fileLogger.logger.Out = out
+	_, _ = fileLogger.Write([]byte("done"))
+	if out.String() != "done" {
+		t.Fatalf("expected trailing output to be written, got %q", out.String())
+	}
+}
+
+func TestRegisterShortSecret(t *testing.T) {
+	defer resetSecrets()
+	if RegisterSecret("abc") {
+		t.Fatal("expected a short secret to be reported as not redacted")
+	}
+	if RegisterSecret("long-enough\n\nab") {
+		t.Fatal("expected a short line of a secret to be reported as not redacted")
+	}
+	if !RegisterSecret("long-enough-secret") {
+		t.Fatal("expected secret to be redacted")
+	}
+}
+
+// syncBuffer is a buffer that can be written and read at the same time
+type syncBuffer struct {
+	m      sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (s *syncBuffer) Write(message []byte) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.buffer.Write(message)
+}
+
+func (s *syncBuffer) String() string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.buffer.String()
+}
+
+func resetSecrets() {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	secrets = map[string]bool{}
+	redactor = nil
+	lineSecrets = nil
+}
@@ -107,6 +107,10 @@ type StreamLogger struct {
 	survey survey.Survey
 
 	sinks []Logger
+
+	// pending is the beginning of a secret that is held back until the next write
+	pending      []byte
+	pendingLevel logrus.Level
 }
 
 type Prefix struct {
@@ -185,6 +189,7 @@ func (s *StreamLogger) ErrorStreamOnly() Logger {
 
 	n := *s
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.stream = s.errorStream
 	return &n
 }
@@ -200,6 +205,7 @@ func (s *StreamLogger) WithPrefix(prefix string) Logger {
 
 	n := *s
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.prefixes = []Prefix{}
 	n.prefixes = append(n.prefixes, s.prefixes...)
 	n.prefixes = append(n.prefixes, Prefix{
@@ -215,6 +221,7 @@ func (s *StreamLogger) WithPrefixColor(prefix, color string) Logger {
 
 	n := *s
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.prefixes = []Prefix{}
 	n.prefixes = append(n.prefixes, s.prefixes...)
 	n.prefixes = append(n.prefixes, Prefix{
@@ -237,6 +244,7 @@ func (s *StreamLogger) WithSink(log Logger) Logger {
 
 	n := *s
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.sinks = []Logger{}
 	n.sinks = append(n.sinks, s.sinks...)
 	n.sinks = append(n.sinks, log)
@@ -249,6 +257,7 @@ func (s *StreamLogger) WithLevel(level logrus.Level) Logger {
 
 	n := *s
 	n.m = &sync.Mutex{}
+	n.pending = nil
 	n.level = level
 	return &n
 }
@@ -275,8 +284,9 @@ func (s *StreamLogger) writePrefixes(message string) string {
 }
 
 func (s *StreamLogger) writeMessage(fnType logFunctionType, message string) {
+	s.flush()
 	fnInformation := fnTypeInformationMap[fnType]
-	message = s.writePrefixes(message)
+	message = Redact(s.writePrefixes(message))
 	for _, s := range s.sinks {
 		if fnInformation.logLevel == logrus.PanicLevel || fnInformation.logLevel == logrus.FatalLevel {
 			s.Print(logrus.ErrorLevel, message)
@@ -498,6 +508,11 @@ func (s *StreamLogger) Writer(level logrus.Level, raw bool) io.WriteCloser {
 				s.Print(level, sa.Text())
 			}
 		}
+
+		// write the held back output when the writer is closed
+		s.m.Lock()
+		s.flush()
+		s.m.Unlock()
 	}()
 
 	return writer
@@ -525,28 +540,52 @@ func (s *StreamLogger) Write(message []byte) (int, error) {
 }
 
 func (s *StreamLogger) write(level logrus.Level, message []byte) (int, error) {
-	var (
-		n   int
-		err error
-	)
-	if s.format == JSONFormat {
+	if level != s.pendingLevel {
+		s.flush()
+		s.pendingLevel = level
+	}
+
+	var redacted []byte
+	redacted, s.pending = redactStream(s.pending, message)
+	return len(message), s.writeRaw(level, redacted)
+}
+
+// flush writes the held back output
+func (s *StreamLogger) flush() {
+	if len(s.pending) == 0 {
+		return
+	}
+
+	pending := s.pending
+	s.pending = nil
+	_ = s.writeRaw(s.pendingLevel, []byte(Redact(string(pending))))
+}
+
+func (s *StreamLogger) writeRaw(level logrus.Level, message []byte) error {
+	if len(message) == 0 {
+		return nil
+	} else if s.format == JSONFormat {
 		s.writeJSON(string(message), logrus.InfoLevel)
-		n = len(message)
-	} else {
-		s.getStream(level)
-		n, err = s.stream.Write(message)
+		return nil
 	}
-	return n, err
+
+	s.getStream(level)
+	_, err := s.stream.Write(message)
+	return err
 }
 
 func (s *StreamLogger) Question(params *survey.QuestionOptions) (string, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
+	s.flush()
+
+	// use the default value or fail if we cannot ask
+	if !s.isTerminal || survey.IsNonInteractive() {
+		if params.DefaultValueSet {
+			return params.DefaultValue, nil
+		}
 
-	if !s.isTerminal && !params.DefaultValueSet {
-		return "", fmt.Errorf("cannot ask question '%s' because currently you're not using devspace in a terminal and default value is also not provided", params.Question)
-	} else if !s.isTerminal && params.DefaultValueSet {
-		return params.DefaultValue, nil
+		return "", survey.NewMissingInputError(params)
 	}
 
 	// Check if we can ask the question
@@ -0,0 +1,110 @@
+package survey
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+var nonInteractive atomic.Bool
+
+// SetNonInteractive enables or disables the non-interactive mode in which questions are not asked and
+// fail with a MissingInputError instead
+func SetNonInteractive(enabled bool) {
+	nonInteractive.Store(enabled)
+}
+
+// IsNonInteractive returns if questions should fail instead of being asked
+func IsNonInteractive() bool {
+	return nonInteractive.Load()
+}
+
+// MissingInput is a question that could not be asked
+type MissingInput struct {
+	Question string
+	Hint     string
+}
+
+// String returns the question and how it can be answered without asking it
+func (m MissingInput) String() string {
+	if m.Hint == "" {
+		return m.Question
+	}
+
+	return m.Question + " (use " + m.Hint + ")"
+}
+
+// MissingInputError is returned if one or more questions could not be asked, because devspace
+// is not running interactively
+type MissingInputError struct {
+	Inputs []MissingInput
+
+	// NoTerminal is true if the questions could not be asked, because devspace is not running
+	// in a terminal, and false if the non-interactive mode is enabled
+	NoTerminal bool
+}
+
+// NewMissingInputError creates a new error for the given question
+func NewMissingInputError(params *QuestionOptions) *MissingInputError {
+	return &MissingInputError{
+		Inputs: []MissingInput{
+			{
+				Question: params.Question,
+				Hint:     params.NonInteractiveHint,
+			},
+		},
+		NoTerminal: !IsNonInteractive(),
+	}
+}
+
+// Add adds the missing inputs of err and returns false if err is not a MissingInputError
+func (m *MissingInputError) Add(err error) bool {
+	other := &MissingInputError{}
+	if !errors.As(err, &other) {
+		return false
+	} else if len(m.Inputs) == 0 {
+		m.NoTerminal = other.NoTerminal
+	}
+
+	for _, input := range other.Inputs {
+		found := false
+		for _, existing := range m.Inputs {
+			if existing == input {
+				found = true
+				break
+			}
+		}
+		if !found {
+			m.Inputs = append(m.Inputs, input)
+		}
+	}
+
+	return true
+}
+
+// Error implements the error interface
+func (m *MissingInputError) Error() string {
+	if len(m.Inputs) == 1 && m.NoTerminal {
+		return "cannot ask question '" + m.Inputs[0].Question + "' because currently you're not using devspace in a terminal and default value is also not provided"
+	} else if len(m.Inputs) == 1 {
+		message := "cannot ask question '" + m.Inputs[0].Question + "' in non-interactive mode"
+		if m.Inputs[0].Hint != "" {
+			message += ", please use " + m.Inputs[0].Hint
+		}
+
+		return message
+	}
+
+	header := "cannot ask the following questions in non-interactive mode:"
+	if m.NoTerminal {
+		header = "cannot ask the following questions because currently you're not using devspace in a terminal and default values are also not provided:"
+	}
+
+	lines := []string{header}
+	for _, input := range m.Inputs {
+		lines = append(lines, "  - "+input.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -20,6 +20,10 @@ type QuestionOptions struct {
 	Options                []string
 	Sort                   bool
 	IsPassword             bool
+
+	// NonInteractiveHint describes how the answer can be provided upfront, e.g. with a flag
+	// or an environment variable, if the question cannot be asked
+	NonInteractiveHint string
 }
 
 // DefaultValidationRegexPattern is the default regex pattern to validate the input